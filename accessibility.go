@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/ui"
+)
+
+const (
+	minGameSpeed = 0.5 // Slowest game speed, for players who need more time to react
+	maxGameSpeed = 1.5 // Fastest game speed
+)
+
+// speedLabel labels the game speed as a percentage
+func speedLabel(speed float32) string {
+	return fmt.Sprintf("Game Speed: %.0f%%", speed*100)
+}
+
+// speedPercent returns the game speed to record with a score, 0 at full speed
+func (g *Game) speedPercent() int {
+	if g.gameSpeed == 1 {
+		return 0
+	}
+	return int(math.Round(float64(g.gameSpeed) * 100))
+}
+
+// blinking reports whether something blinking at rate times a second is in its off half, when it
+// is hidden or flashed. It never is without flashing, so warnings hold steady.
+func (g *Game) blinking(rate float64) bool {
+	return !g.noFlashing && int(rl.GetTime()*rate)%2 == 0
+}
+
+// onOff labels a toggle option
+func onOff(label string, on bool) string {
+	if on {
+		return label + ": On"
+	}
+	return label + ": Off"
+}
+
+// openAccessibilityScreen turns off the motion and flashing that can trouble photosensitive and
+// motion-sensitive players: screen shake, particles, flashing warnings and the falling menu
+// sprites. It also turns on speech, slows or speeds up the game and scales the text and buttons.
+// Clicking a button toggles it, and choices are saved straight away.
+func (g *Game) openAccessibilityScreen() {
+	buttonWidth := float32(420)
+	buttonHeight := float32(36)
+	buttonSpacing := float32(10)
+	startY := float32(g.screenHeight) * 0.17
+	x := float32(g.screenWidth)/2 - buttonWidth/2
+	half := buttonWidth/2 - buttonSpacing/2
+	option := func(column, row int, width float32) MenuButton {
+		return NewMenuButton(x+float32(column)*(half+buttonSpacing), startY+float32(row)*(buttonHeight+buttonSpacing), width, buttonHeight, "", 28, g.menu.font)
+	}
+	shakeButton := option(0, 0, half)
+	particlesButton := option(1, 0, half)
+	flashingButton := option(0, 1, half)
+	motionButton := option(1, 1, half)
+	speechButton := option(0, 2, buttonWidth)
+
+	// Scores set below full speed are marked, and kept off the global leaderboard
+	speedSlider := ui.NewSlider(g.uiStyle(28), option(0, 3, buttonWidth).rect, minGameSpeed, maxGameSpeed, 0.05, g.gameSpeed, speedLabel)
+	speedSlider.OnChange = func(value float32) {
+		// Whole percents, so stepping back to 100% is exactly full speed
+		g.gameSpeed = float32(math.Round(float64(value)*100)) / 100
+	}
+	// Screens already laid out keep their buttons until they are next opened
+	scaleSlider := ui.NewSlider(g.uiStyle(28), option(0, 4, buttonWidth).rect, minUIScale, maxUIScale, 0.05, uiScale, uiScaleLabel)
+	scaleSlider.OnChange = func(value float32) {
+		uiScale = float32(math.Round(float64(value)*100)) / 100
+	}
+	widgets := ui.NewGroup(&menuFocus, speedSlider, scaleSlider)
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		float32(g.screenHeight)-buttonHeight-24,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		28,
+		g.menu.font,
+	)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateSettings
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		widgets.Update(mousePoint)
+		switch {
+		case g.clicked(&shakeButton, mousePoint):
+			// Turning shake back on restores it to full, the Settings slider fine tunes it
+			if g.camFX.intensity > 0 {
+				g.camFX.intensity = 0
+			} else {
+				g.camFX.intensity = 1
+			}
+			g.saveSettings()
+		case g.clicked(&particlesButton, mousePoint):
+			g.noParticles = !g.noParticles
+			g.saveSettings()
+		case g.clicked(&flashingButton, mousePoint):
+			g.noFlashing = !g.noFlashing
+			g.saveSettings()
+		case g.clicked(&motionButton, mousePoint):
+			g.menu.static = !g.menu.static
+			g.saveSettings()
+		case g.clicked(&speechButton, mousePoint) && speaker.Available():
+			speaker.Enabled = !speaker.Enabled
+			g.saveSettings()
+			announceFocus(onOff("Speech", speaker.Enabled))
+		case g.clicked(&backButton, mousePoint):
+			g.state = StateSettings
+			return
+		}
+		shakeButton.text = onOff("Screen Shake", g.camFX.intensity > 0)
+		particlesButton.text = onOff("Particles", !g.noParticles)
+		flashingButton.text = onOff("Flashing", !g.noFlashing)
+		motionButton.text = onOff("Menu Motion", !g.menu.static)
+		speechButton.text = onOff("Speech", speaker.Enabled)
+		if !speaker.Available() {
+			speechButton.text = "Speech: N/A"
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+		g.drawCenteredText("ACCESSIBILITY", float32(g.screenHeight)*0.06, 40, rl.DarkGreen)
+		shakeButton.Draw()
+		particlesButton.Draw()
+		flashingButton.Draw()
+		motionButton.Draw()
+		speechButton.Draw()
+		widgets.Draw()
+		note := "For photosensitive and motion-sensitive players"
+		if g.gameSpeed < 1 {
+			note = "Scores below 100% speed are marked and stay off the global leaderboard"
+		}
+		g.drawCenteredText(note, startY+5*(buttonHeight+buttonSpacing)+4, 20, rl.Gray)
+		backButton.Draw()
+		g.endFrame()
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/achievements"
+	"github.com/ztkent/snake/internal/game"
+)
+
+const toastTime = 3 // Seconds an unlock notification stays up
+
+// toast is an unlock notification shown over the game
+type toast struct {
+	text      string
+	remaining float32
+}
+
+// trackRun updates the run's achievement stats from a frame's events and the engine state
+func trackRun(run *achievements.Run, events []game.Event, state *game.State) {
+	for _, event := range events {
+		switch event {
+		case game.EventAte, game.EventAteGolden:
+			run.Food++
+		case game.EventPowerUp:
+			run.PowerUps++
+		}
+	}
+	run.Duration = state.Elapsed
+	run.Length = len(state.Snake.Segments)
+	run.Wraps = state.Wraps
+	run.Over = state.Over
+}
+
+// checkAchievements unlocks anything the run has earned so far, with a toast for each
+func (g *Game) checkAchievements(run achievements.Run) {
+	locked := g.lockedCosmetics()
+	unlocked := g.achievements.Check(run)
+	for _, achievement := range unlocked {
+		g.toasts = append(g.toasts, toast{text: "Achievement unlocked: " + achievement.Name, remaining: toastTime})
+	}
+	if len(unlocked) > 0 {
+		g.announceCosmetics(locked)
+		g.saveAchievements()
+	}
+}
+
+// finishAchievements checks the run one last time and adds it to the lifetime totals
+func (g *Game) finishAchievements(run achievements.Run) {
+	g.checkAchievements(run)
+	locked := g.lockedCosmetics()
+	g.achievements.Finish(run)
+	g.announceCosmetics(locked)
+	g.saveAchievements()
+}
+
+func (g *Game) saveAchievements() {
+	if err := achievements.Save(g.profiles.Current().Dir, g.achievements); err != nil {
+		fmt.Println("Failed to save achievements:", err)
+	}
+}
+
+// drawToasts shows pending unlock notifications one at a time at the top of the screen
+func (g *Game) drawToasts() {
+	if len(g.toasts) == 0 {
+		return
+	}
+	g.toasts[0].remaining -= rl.GetFrameTime()
+	if g.toasts[0].remaining <= 0 {
+		g.toasts = g.toasts[1:]
+		return
+	}
+
+	fontSize := hudFont(20)
+	textSize := rl.MeasureTextEx(g.menu.font, g.toasts[0].text, fontSize, 1)
+	box := rl.NewRectangle(float32(g.screenWidth)/2-textSize.X/2-12, 8, textSize.X+24, textSize.Y+12)
+	rl.DrawRectangleRounded(box, 0.3, 4, rl.Color{R: 0, G: 0, B: 0, A: 180})
+	rl.DrawTextEx(g.menu.font, g.toasts[0].text, rl.Vector2{X: box.X + 12, Y: box.Y + 6}, fontSize, 1, rl.Gold)
+}
+
+// openAchievementsScreen lists every achievement and when it was unlocked
+func (g *Game) openAchievementsScreen() {
+	buttonWidth := float32(200)
+	buttonHeight := float32(50)
+
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		float32(g.screenHeight)*0.8,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		30,
+		g.menu.font,
+	)
+
+	tableWidth := float32(g.screenWidth) * 0.9
+	table := NewTable(
+		float32(g.screenWidth)/2-tableWidth/2,
+		float32(g.screenHeight)*0.3,
+		tableWidth,
+		float32(g.screenHeight)*0.45,
+		[]TableColumn{
+			{title: "Achievement", width: 0.3},
+			{title: "Goal", width: 0.48},
+			{title: "Unlocked", width: 0.22},
+		},
+		20,
+		g.menu.font,
+	)
+	rows := make([][]string, len(achievements.Registry))
+	unlockedCount := 0
+	for i, achievement := range achievements.Registry {
+		unlocked := "---"
+		if date, ok := g.achievements.Unlocked[achievement.ID]; ok {
+			unlocked = date.Format("2006-01-02")
+			unlockedCount++
+		}
+		rows[i] = []string{achievement.Name, achievement.Description, unlocked}
+	}
+	table.SetRows(rows)
+	summary := fmt.Sprintf("%d of %d unlocked", unlockedCount, len(achievements.Registry))
+
+	for {
+		g.updateFramePacing()
+
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		table.HandleScroll(mousePoint)
+		table.HandleKeys()
+
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateMainMenu
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+
+		g.drawCenteredText("ACHIEVEMENTS", float32(g.screenHeight)*0.05, 60, rl.DarkGreen)
+		g.drawCenteredText(summary, float32(g.screenHeight)*0.2, 20, rl.DarkGray)
+		table.Draw()
+
+		backButton.Draw()
+		g.endFrame()
+	}
+}
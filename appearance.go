@@ -0,0 +1,363 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/assets"
+	"github.com/ztkent/snake/internal/cosmetics"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/render"
+)
+
+// previewSnake is the shape of the snake shown in Appearance, head first
+var previewSnake = []game.Point{
+	{X: 9, Y: 0}, {X: 8, Y: 0}, {X: 7, Y: 0}, {X: 6, Y: 0}, {X: 5, Y: 0}, {X: 5, Y: 1},
+	{X: 5, Y: 2}, {X: 4, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 2}, {X: 1, Y: 2},
+}
+
+// atlasFile holds the tiles the board is drawn with
+const atlasFile = "assets/atlas.png"
+
+// rivalSkin is how AI rival snakes are drawn, whichever skin the player has picked
+var rivalSkin = cosmetics.Skin{Name: "Rival", Head: rl.Maroon, Body: []rl.Color{rl.Orange}}
+
+// loadAtlas loads the board atlas, returning nil to draw plain shapes if it can't be loaded
+func loadAtlas(loader *assets.Manager) *render.Atlas {
+	texture, ok := loader.Texture(atlasFile)
+	if !ok {
+		fmt.Println("Drawing shapes instead of the atlas")
+		return nil
+	}
+	atlas, err := render.NewAtlas(texture)
+	if err != nil {
+		fmt.Println("Failed to load atlas, drawing shapes instead:", err)
+		return nil
+	}
+	return atlas
+}
+
+// loadSkinSprites loads the sprite sheet of every skin that has one. Sheets that fail to load
+// are left out, and those skins draw flat.
+func loadSkinSprites(loader *assets.Manager) map[string]rl.Texture2D {
+	sprites := make(map[string]rl.Texture2D)
+	for _, skin := range cosmetics.Skins {
+		if skin.Sprite == "" {
+			continue
+		}
+		texture, ok := loader.Texture(skin.Sprite)
+		if !ok {
+			continue
+		}
+		sprites[skin.Sprite] = texture
+	}
+	return sprites
+}
+
+// drawSkinned draws snake segments in a skin, head first, each gridSize across. The pieces
+// pick atlas tiles for the segments, which are drawn as plain squares without an atlas.
+func (g *Game) drawSkinned(skin cosmetics.Skin, pieces []render.Piece, segments []rl.Vector2) {
+	size := rl.Vector2{X: gridSize, Y: gridSize}
+	sprite, hasSprite := g.skinSprites[skin.Sprite]
+
+	// Draw the tail first so the head ends up on top
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		color := skin.Head
+		if i > 0 {
+			color = skin.BodyColor(i - 1)
+		}
+
+		dest := rl.NewRectangle(segment.X, segment.Y, gridSize, gridSize)
+		if skin.Style == cosmetics.StyleSprite && hasSprite {
+			// The sheet is a square head cell then a square body cell
+			cell := float32(sprite.Height)
+			source := rl.NewRectangle(0, 0, cell, cell)
+			if i > 0 {
+				source.X = cell
+			}
+			rl.DrawTexturePro(sprite, source, dest, rl.Vector2{}, 0, rl.White)
+			continue
+		}
+
+		if skin.Style == cosmetics.StyleGlow {
+			halo := rl.NewRectangle(segment.X-3, segment.Y-3, gridSize+6, gridSize+6)
+			rl.DrawRectangleRounded(halo, 0.5, 4, rl.Fade(skin.Glow, 0.25))
+		}
+		if g.atlas != nil && i < len(pieces) {
+			g.atlas.Draw(pieces[i].Tile, dest, pieces[i].Rotation, color)
+		} else {
+			rl.DrawRectangleV(segment, size, color)
+		}
+	}
+}
+
+// openAppearanceScreen picks the snake skin, hat, board theme, board pattern, movement, HUD and
+// cursor, with a preview of the skin, hat, theme and pattern. Clicking a button cycles to the next choice, and choices are saved straight
+// away. Locked choices can be previewed, with what unlocks them, but aren't kept.
+func (g *Game) openAppearanceScreen() {
+	skin, hat, theme := g.skin, g.hat, g.theme
+
+	buttonWidth := float32(260)
+	buttonHeight := float32(36)
+	buttonSpacing := float32(8)
+	startY := float32(g.screenHeight) * 0.13
+
+	// Two columns of options
+	leftX := float32(g.screenWidth)/2 - buttonWidth - buttonSpacing/2
+	rightX := float32(g.screenWidth)/2 + buttonSpacing/2
+	option := func(x float32, row int, width float32) MenuButton {
+		return NewMenuButton(x, startY+float32(row)*(buttonHeight+buttonSpacing), width, buttonHeight, "", 28, g.menu.font)
+	}
+	skinButton := option(leftX, 0, buttonWidth)
+	themeButton := option(rightX, 0, buttonWidth)
+	movementButton := option(leftX, 1, buttonWidth)
+	layoutButton := option(rightX, 1, buttonWidth)
+	cornerButton := option(leftX, 2, buttonWidth)
+	cursorButton := option(rightX, 2, buttonWidth)
+	patternButton := option(leftX, 3, buttonWidth)
+	hatButton := option(rightX, 3, buttonWidth)
+
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		float32(g.screenHeight)-buttonHeight-12,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		28,
+		g.menu.font,
+	)
+
+	// The preview is a small board of 11x4 cells under the buttons
+	preview := rl.NewRectangle(float32(g.screenWidth)/2-5.5*gridSize, float32(g.screenHeight)*0.56, 11*gridSize, 4*gridSize)
+	previewPieces := render.SnakePieces(game.Snake{Segments: previewSnake, Direction: game.Right}, 11, 4)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateSettings
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		if skinButton.IsHovered(mousePoint) {
+			skinButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				skin = nextSkin(skin)
+				if skin.Unlock.Met(&g.achievements) {
+					g.skin = skin
+					g.saveSettings()
+				}
+			}
+		} else {
+			skinButton.color = rl.LightGray
+		}
+		if themeButton.IsHovered(mousePoint) {
+			themeButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				theme = nextTheme(theme)
+				if theme.Unlock.Met(&g.achievements) {
+					g.setTheme(theme)
+					g.saveSettings()
+				}
+			}
+		} else {
+			themeButton.color = rl.LightGray
+		}
+		if movementButton.IsHovered(mousePoint) {
+			movementButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.stepped = !g.stepped
+				g.saveSettings()
+			}
+		} else {
+			movementButton.color = rl.LightGray
+		}
+		if layoutButton.IsHovered(mousePoint) {
+			layoutButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.hudLayout = g.hudLayout.Next()
+				g.saveSettings()
+			}
+		} else {
+			layoutButton.color = rl.LightGray
+		}
+		if cornerButton.IsHovered(mousePoint) {
+			cornerButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.hudCorner = g.hudCorner.Next()
+				g.saveSettings()
+			}
+		} else {
+			cornerButton.color = rl.LightGray
+		}
+		if cursorButton.IsHovered(mousePoint) {
+			cursorButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.cursor = g.cursor.Next()
+				g.saveSettings()
+			}
+		} else {
+			cursorButton.color = rl.LightGray
+		}
+		if patternButton.IsHovered(mousePoint) {
+			patternButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.boardPattern = g.boardPattern.Next()
+				g.saveSettings()
+			}
+		} else {
+			patternButton.color = rl.LightGray
+		}
+		if hatButton.IsHovered(mousePoint) {
+			hatButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				hat = nextHat(hat)
+				if hat.Unlock.Met(&g.achievements) {
+					g.hat = hat
+					g.saveSettings()
+				}
+			}
+		} else {
+			hatButton.color = rl.LightGray
+		}
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateSettings
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+		skinButton.text = "Skin: " + skin.Name
+		themeButton.text = "Theme: " + theme.Name
+		movementButton.text = "Movement: Smooth"
+		if g.stepped {
+			movementButton.text = "Movement: Stepped"
+		}
+		layoutButton.text = "HUD: " + g.hudLayout.String()
+		cornerButton.text = "HUD Corner: " + g.hudCorner.String()
+		cursorButton.text = "Cursor: " + g.cursor.String()
+		patternButton.text = "Board: " + g.boardPattern.String()
+		hatButton.text = "Hat: " + hat.Name
+		var lockedText []string
+		if !skin.Unlock.Met(&g.achievements) {
+			skinButton.text += " (Locked)"
+			lockedText = append(lockedText, skin.Name+": "+skin.Unlock.String())
+		}
+		if !hat.Unlock.Met(&g.achievements) {
+			hatButton.text += " (Locked)"
+			lockedText = append(lockedText, hat.Name+": "+hat.Unlock.String())
+		}
+		if !theme.Unlock.Met(&g.achievements) {
+			themeButton.text += " (Locked)"
+			lockedText = append(lockedText, theme.Name+": "+theme.Unlock.String())
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.drawCenteredText("APPEARANCE", float32(g.screenHeight)*0.03, 40, rl.DarkGreen)
+		skinButton.Draw()
+		themeButton.Draw()
+		movementButton.Draw()
+		layoutButton.Draw()
+		cornerButton.Draw()
+		cursorButton.Draw()
+		patternButton.Draw()
+		hatButton.Draw()
+
+		frame := rl.NewRectangle(preview.X-gridSize, preview.Y-gridSize, preview.Width+2*gridSize, preview.Height+2*gridSize)
+		rl.DrawRectangleRec(frame, theme.Background)
+		rl.DrawRectangleRec(preview, theme.Board)
+		if g.boardPattern != PatternPlain {
+			// A corner of the game board's cached pattern
+			width, height := g.boardSize()
+			texture := g.patternCache.Texture(g.boardPattern, width, height, theme.Grid)
+			rl.DrawTextureRec(texture, rl.NewRectangle(0, 0, preview.Width, preview.Height), rl.Vector2{X: preview.X, Y: preview.Y}, rl.White)
+		}
+		rl.DrawRectangleV(rl.Vector2{X: preview.X, Y: preview.Y + 3*gridSize}, rl.Vector2{X: gridSize, Y: gridSize}, theme.Wall)
+		g.drawApple(rl.Vector2{X: preview.X + 10*gridSize, Y: preview.Y}, theme.Food)
+		segments := make([]rl.Vector2, len(previewSnake))
+		for i, cell := range previewSnake {
+			segments[i] = rl.Vector2{X: preview.X + float32(cell.X*gridSize), Y: preview.Y + float32(cell.Y*gridSize)}
+		}
+		g.drawSkinned(theme.Skinned(skin), previewPieces, segments)
+		g.drawHat(hat, hatOnHead(segments[0]), 0)
+		rl.DrawRectangleRec(rl.NewRectangle(preview.X+3*gridSize, preview.Y+3*gridSize, gridSize, gridSize), theme.Bomb)
+		for i, text := range lockedText {
+			g.drawCenteredText(text, frame.Y+frame.Height+6+float32(i)*22, 20, rl.Maroon)
+		}
+
+		backButton.Draw()
+		g.endFrame()
+	}
+}
+
+// lockedCosmetics returns the unlock notification of every skin, hat and theme still locked
+func (g *Game) lockedCosmetics() []string {
+	var locked []string
+	for _, skin := range cosmetics.Skins {
+		if !skin.Unlock.Met(&g.achievements) {
+			locked = append(locked, "Skin unlocked: "+skin.Name)
+		}
+	}
+	for _, hat := range cosmetics.Hats {
+		if !hat.Unlock.Met(&g.achievements) {
+			locked = append(locked, "Hat unlocked: "+hat.Name)
+		}
+	}
+	for _, theme := range cosmetics.Themes {
+		if !theme.Unlock.Met(&g.achievements) {
+			locked = append(locked, "Theme unlocked: "+theme.Name)
+		}
+	}
+	return locked
+}
+
+// announceCosmetics shows a toast for each of the previously locked cosmetics that is now unlocked
+func (g *Game) announceCosmetics(locked []string) {
+	stillLocked := g.lockedCosmetics()
+	for _, text := range locked {
+		if !slices.Contains(stillLocked, text) {
+			g.toasts = append(g.toasts, toast{text: text, remaining: toastTime})
+		}
+	}
+}
+
+// nextSkin cycles to the skin after the given one, wrapping back to the first
+func nextSkin(skin cosmetics.Skin) cosmetics.Skin {
+	for i, s := range cosmetics.Skins {
+		if s.Name == skin.Name {
+			return cosmetics.Skins[(i+1)%len(cosmetics.Skins)]
+		}
+	}
+	return cosmetics.Skins[0]
+}
+
+// menuTheme is the theme the menus are drawn in. Buttons and menu text are drawn without the game
+// at hand, so setTheme keeps it in step with the game's theme.
+var menuTheme = cosmetics.Themes[0]
+
+// setTheme switches to a theme, for the board and the menus
+func (g *Game) setTheme(theme cosmetics.Theme) {
+	g.theme = theme
+	menuTheme = theme
+}
+
+// nextTheme cycles to the theme after the given one, wrapping back to the first
+func nextTheme(theme cosmetics.Theme) cosmetics.Theme {
+	for i, t := range cosmetics.Themes {
+		if t.Name == theme.Name {
+			return cosmetics.Themes[(i+1)%len(cosmetics.Themes)]
+		}
+	}
+	return cosmetics.Themes[0]
+}
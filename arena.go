@@ -0,0 +1,43 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+var arenaColor = rl.Color{R: 120, G: 20, B: 20, A: 255}
+
+// drawArena darkens the closed rings of an Arena game, and makes the next ring to close flash
+// red as its time runs out. Call it in board coordinates, over the board.
+func (g *Game) drawArena(engine *game.Engine) {
+	if engine.Config.Mode != game.ModeArena {
+		return
+	}
+	width, height := g.boardSize()
+	board := game.Bounds{Max: game.Point{X: width, Y: height}}
+	drawRing(board, engine.ArenaBounds(engine.State.Ring), rl.Fade(arenaColor, 0.75))
+
+	left, ok := engine.NextRing()
+	if !ok || left > game.ArenaWarning {
+		return
+	}
+	// Flash faster as the ring is about to close
+	flash := float32(0.5 + 0.5*math.Sin(rl.GetTime()*float64(20-4*left)))
+	if g.noFlashing {
+		flash = 0.5
+	}
+	drawRing(engine.ArenaBounds(engine.State.Ring), engine.ArenaBounds(engine.State.Ring+1), rl.Fade(rl.Red, 0.2+0.3*flash))
+}
+
+// drawRing fills the cells inside outer but not inside inner
+func drawRing(outer, inner game.Bounds, color rl.Color) {
+	rect := func(from, to game.Point) {
+		rl.DrawRectangle(int32(from.X*gridSize), int32(from.Y*gridSize), int32((to.X-from.X)*gridSize), int32((to.Y-from.Y)*gridSize), color)
+	}
+	rect(outer.Min, game.Point{X: outer.Max.X, Y: inner.Min.Y})
+	rect(game.Point{X: outer.Min.X, Y: inner.Max.Y}, outer.Max)
+	rect(game.Point{X: outer.Min.X, Y: inner.Min.Y}, game.Point{X: inner.Min.X, Y: inner.Max.Y})
+	rect(game.Point{X: inner.Max.X, Y: inner.Min.Y}, game.Point{X: outer.Max.X, Y: inner.Max.Y})
+}
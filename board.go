@@ -0,0 +1,99 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// BoardSize selects how many cells the board has. The board is scaled to fit the canvas,
+// so a bigger board means smaller cells rather than a bigger window.
+type BoardSize int
+
+const (
+	BoardSmall BoardSize = iota
+	BoardMedium
+	BoardLarge
+	BoardSizeCount
+)
+
+var boardSizeNames = [BoardSizeCount]string{"Small", "Medium", "Large"}
+
+// boardCells are the board dimensions in cells for each size
+var boardCells = [BoardSizeCount]game.Point{
+	BoardSmall:  {X: 28, Y: 16},
+	BoardMedium: {X: 40, Y: 22},
+	BoardLarge:  {X: 56, Y: 31},
+}
+
+func (b BoardSize) String() string {
+	return boardSizeNames[b]
+}
+
+// ParseBoardSize returns the board size with the given name, defaulting to Medium
+func ParseBoardSize(name string) BoardSize {
+	for i, sizeName := range boardSizeNames {
+		if sizeName == name {
+			return BoardSize(i)
+		}
+	}
+	return BoardMedium
+}
+
+// boardSizeOf returns the board size with the given dimensions in cells, if there is one
+func boardSizeOf(width, height int) (BoardSize, bool) {
+	for size, cells := range boardCells {
+		if cells.X == width && cells.Y == height {
+			return BoardSize(size), true
+		}
+	}
+	return 0, false
+}
+
+// Next cycles to the following board size, wrapping back to Small
+func (b BoardSize) Next() BoardSize {
+	return (b + 1) % BoardSizeCount
+}
+
+// boardCamera maps board coordinates, gridSize units per cell, onto the canvas. The board
+// is scaled to fit and centered.
+func (g *Game) boardCamera() rl.Camera2D {
+	width, height := g.boardSize()
+	boardWidth := float32(width * gridSize)
+	boardHeight := float32(height * gridSize)
+	zoom := min(float32(g.screenWidth)/boardWidth, float32(g.screenHeight)/boardHeight)
+	return rl.Camera2D{
+		Offset: rl.Vector2{
+			X: (float32(g.screenWidth) - boardWidth*zoom) / 2,
+			Y: (float32(g.screenHeight) - boardHeight*zoom) / 2,
+		},
+		Zoom: zoom,
+	}
+}
+
+// beginBoard starts drawing in board coordinates through the camera, over the board background.
+// Finish with rl.EndMode2D.
+func (g *Game) beginBoard(camera rl.Camera2D) {
+	rl.BeginMode2D(camera)
+	width, height := g.boardSize()
+	rl.DrawRectangle(0, 0, int32(width*gridSize), int32(height*gridSize), g.theme.Board)
+	g.drawBoardPattern(width, height)
+}
+
+// viewedThrough returns a camera that applies board and then view, such as photo mode's
+// pan and zoom on top of the board transform. Neither may be rotated.
+func viewedThrough(board, view rl.Camera2D) rl.Camera2D {
+	return rl.Camera2D{
+		Target: board.Target,
+		Offset: rl.Vector2{
+			X: (board.Offset.X-view.Target.X)*view.Zoom + view.Offset.X,
+			Y: (board.Offset.Y-view.Target.Y)*view.Zoom + view.Offset.Y,
+		},
+		Zoom: board.Zoom * view.Zoom,
+	}
+}
+
+// boardMouseCell returns the board cell under the mouse
+func (g *Game) boardMouseCell() game.Point {
+	mouse := rl.GetScreenToWorld2D(rl.GetMousePosition(), g.boardCamera())
+	return game.Point{X: int(mouse.X / gridSize), Y: int(mouse.Y / gridSize)}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/render"
+)
+
+// bombWarning is how many seconds before exploding a bomb starts to flash
+const bombWarning = 2
+
+// drawBomb draws a bomb with its fuse countdown, flashing faster as it nears zero. Without
+// flashing it turns orange instead.
+func (g *Game) drawBomb(bomb game.Entity) {
+	position := cellPosition(bomb.Position)
+	cell := rl.NewRectangle(position.X, position.Y, gridSize, gridSize)
+	color := g.theme.Bomb
+	if bomb.Remaining > 0 && bomb.Remaining < bombWarning {
+		rate := 4 + 8*(bombWarning-bomb.Remaining)/bombWarning
+		if g.noFlashing {
+			color = rl.Orange
+		} else if g.blinking(float64(rate)) {
+			color = rl.White
+		}
+	}
+	if g.atlas != nil {
+		g.atlas.Draw(render.TileBomb, cell, 0, color)
+	} else {
+		rl.DrawRectangleRec(cell, color)
+	}
+	if bomb.Remaining > 0 {
+		g.drawIcon(fmt.Sprintf("%d", int(math.Ceil(float64(bomb.Remaining)))), cell, 16)
+	}
+}
+
+// drawExplosion draws a blast filling its radius, fading out as it ends
+func drawExplosion(explosion game.Explosion) {
+	progress := 1 - explosion.Remaining/game.ExplosionTime
+	center := cellPosition(explosion.Position)
+	center.X += gridSize / 2
+	center.Y += gridSize / 2
+	reach := float32(explosion.Radius*gridSize) + gridSize/2
+	alpha := uint8(255 * (1 - progress))
+	rl.DrawRectangleV(
+		rl.Vector2{X: center.X - reach, Y: center.Y - reach},
+		rl.Vector2{X: reach * 2, Y: reach * 2},
+		rl.Color{R: 255, G: 120, B: 0, A: alpha / 2},
+	)
+	rl.DrawCircleV(center, reach*(0.5+0.5*progress), rl.Color{R: 255, G: 200, B: 0, A: alpha})
+}
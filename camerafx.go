@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math/rand/v2"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+const (
+	maxShake    = 10   // Largest shake offset in canvas pixels, at full trauma and intensity
+	shakeDecay  = 2.5  // Trauma lost per second
+	hitStopTime = 0.05 // Seconds the game freezes for when food is eaten, a few frames
+)
+
+// shakeTrauma is how hard each event shakes the board, from 0 to 1
+var shakeTrauma = map[game.Event]float32{
+	game.EventExploded:    0.5,
+	game.EventShieldUsed:  0.4,
+	game.EventShrunk:      0.6,
+	game.EventArenaShrank: 0.3,
+	game.EventLifeLost:    0.8,
+	game.EventDowned:      0.8,
+	game.EventDied:        0.8,
+}
+
+// cameraEffects shakes the board camera and holds the game still for a moment on impacts.
+// Intensity scales the shake, and 0 turns both shake and hit-stop off.
+type cameraEffects struct {
+	intensity float32
+	trauma    float32 // Decays to 0, the shake grows with its square
+	hitStop   float32 // Seconds left frozen
+}
+
+// React starts the shake and hit-stop for a frame's events
+func (c *cameraEffects) React(events []game.Event) {
+	if c.intensity == 0 {
+		return
+	}
+	for _, event := range events {
+		c.trauma = min(1, c.trauma+shakeTrauma[event])
+		if event == game.EventAte || event == game.EventAteGolden {
+			c.hitStop = hitStopTime
+		}
+	}
+}
+
+// Step runs the effects on by a frame of dt seconds and returns how much of it the simulation
+// should get, none during hit-stop
+func (c *cameraEffects) Step(dt float32) float32 {
+	c.trauma = max(0, c.trauma-shakeDecay*dt)
+	if c.hitStop > 0 {
+		c.hitStop -= dt
+		return 0
+	}
+	return dt
+}
+
+// Apply returns the camera moved by the current shake
+func (c *cameraEffects) Apply(camera rl.Camera2D) rl.Camera2D {
+	if c.trauma == 0 {
+		return camera
+	}
+	shake := maxShake * c.intensity * c.trauma * c.trauma
+	camera.Offset.X += (rand.Float32()*2 - 1) * shake
+	camera.Offset.Y += (rand.Float32()*2 - 1) * shake
+	return camera
+}
+
+// Reset stops any shake or hit-stop in progress, for a new game
+func (c *cameraEffects) Reset() {
+	c.trauma = 0
+	c.hitStop = 0
+}
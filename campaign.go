@@ -0,0 +1,305 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/campaign"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/levels"
+)
+
+// openCampaignScreen lists the campaign stages with their objectives. Cleared stages and the
+// next one can be played, the rest stay locked.
+func (g *Game) openCampaignScreen() {
+	buttonWidth := float32(480)
+	buttonHeight := float32(36)
+	buttonSpacing := float32(8)
+	startY := float32(g.screenHeight) * 0.2
+
+	stageButtons := make([]MenuButton, len(campaign.Stages))
+	for i, stage := range campaign.Stages {
+		label := "Locked"
+		if g.campaign.Unlocked(i) {
+			label = fmt.Sprintf("%d. %s (%s): %s", i+1, stage.Level, stage.Difficulty, stage.Objective)
+		}
+		stageButtons[i] = NewMenuButton(
+			float32(g.screenWidth)/2-buttonWidth/2,
+			startY+float32(i)*(buttonHeight+buttonSpacing),
+			buttonWidth,
+			buttonHeight,
+			label,
+			20,
+			g.menu.font,
+		)
+	}
+
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-100,
+		float32(g.screenHeight)-buttonHeight-20,
+		200,
+		buttonHeight,
+		"Back",
+		24,
+		g.menu.font,
+	)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		for i := range stageButtons {
+			switch {
+			case !g.campaign.Unlocked(i):
+				stageButtons[i].color = rl.DarkGray
+			case stageButtons[i].IsHovered(mousePoint):
+				stageButtons[i].color = rl.Gray
+				if g.menu.handleButtonClick() {
+					g.campaignStage = i
+					g.state = StateCampaignStage
+					return
+				}
+			case i < g.campaign.Cleared:
+				stageButtons[i].color = rl.Green
+			default:
+				stageButtons[i].color = rl.LightGray
+			}
+		}
+
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateMainMenu
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText("CAMPAIGN", float32(g.screenHeight)*0.06, 40, rl.DarkGreen)
+		for i := range stageButtons {
+			stageButtons[i].Draw()
+		}
+		backButton.Draw()
+
+		g.endFrame()
+	}
+}
+
+// StartCampaignStage plays the selected campaign stage until its objective is met or the snake
+// crashes. Clearing a stage unlocks the next and offers to go straight on to it.
+func (g *Game) StartCampaignStage() {
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+	defer g.audio.PlayMusic(&g.audio.MenuMusic)
+
+	stage := campaign.Stages[g.campaignStage]
+	width, height := g.boardSize()
+	config := ParseDifficulty(stage.Difficulty).Config(width, height)
+	level := levels.Find(stage.Level)
+	config.Walls = level.Walls(width, height)
+	config.Portals = level.Portals(width, height)
+	engine := game.NewEngine(config, g.newSeed())
+	g.particles.Clear()
+	g.camFX.Reset()
+	g.startCountdown()
+	food := 0
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		if g.controls.Pressed(input.ActionPause) {
+			if !g.openPauseScreen(&engine.State) {
+				g.recordGame(engine)
+				g.state = StateCampaign
+				return
+			}
+			continue
+		} else if rl.WindowShouldClose() {
+			g.recordGame(engine)
+			g.running = false
+			return
+		}
+
+		g.handleSnakeInput(engine)
+
+		delta := g.camFX.Step(g.holdForCountdown(g.simulationDelta(backgrounded))) * g.gameSpeed
+		events := engine.Update(delta)
+		g.playEventSounds(events, &engine.State)
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		for _, event := range events {
+			if event == game.EventAte || event == game.EventAteGolden {
+				food++
+			}
+		}
+
+		if stage.Objective.Met(food, &engine.State) {
+			g.recordGame(engine)
+			g.clearCampaignStage(engine.State.Points)
+			return
+		}
+		if engine.State.Over && !g.hatFlying() {
+			g.recordGame(engine)
+			title := fmt.Sprintf("STAGE %d FAILED", g.campaignStage+1)
+			lines := []string{
+				stage.Objective.String(),
+				fmt.Sprintf("Reached %d of %d", stage.Objective.Value(food, &engine.State), stage.Objective.Target),
+			}
+			if message := deathMessage(engine.State.Cause); message != "" {
+				lines = append(lines, message)
+			}
+			if g.openStageResults(title, lines, "Retry") {
+				g.state = StateCampaignStage
+			}
+			return
+		}
+
+		g.updateDebug(engine)
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		rl.EndMode2D()
+		g.drawObjectiveHUD(stage.Objective, stage.Objective.Value(food, &engine.State))
+		g.drawHUD(engine)
+
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// clearCampaignStage saves the stage as cleared and shows the result, moving on to the next
+// stage if the player chooses to
+func (g *Game) clearCampaignStage(points int) {
+	g.campaign.Clear(g.campaignStage)
+	if err := campaign.Save(g.campaign); err != nil {
+		fmt.Println("Failed to save campaign progress:", err)
+	}
+
+	lines := []string{fmt.Sprintf("Score: %d", points)}
+	if g.campaignStage == len(campaign.Stages)-1 {
+		g.openStageResults("CAMPAIGN COMPLETE!", lines, "")
+		return
+	}
+	if g.openStageResults(fmt.Sprintf("STAGE %d CLEAR!", g.campaignStage+1), lines, "Next Stage") {
+		g.campaignStage++
+		g.state = StateCampaignStage
+	}
+}
+
+// drawObjectiveHUD shows the stage objective at the top of the screen with a bar filling up
+// towards it
+func (g *Game) drawObjectiveHUD(objective campaign.Objective, value int) {
+	text := fmt.Sprintf("%s  %d/%d", objective, value, objective.Target)
+	g.drawCenteredText(text, 10, 20, rl.White)
+	barWidth := float32(240)
+	x := float32(g.screenWidth)/2 - barWidth/2
+	rl.DrawRectangleV(rl.Vector2{X: x, Y: 36}, rl.Vector2{X: barWidth, Y: 6}, rl.Fade(rl.Black, 0.5))
+	progress := min(1, float32(value)/float32(objective.Target))
+	rl.DrawRectangleV(rl.Vector2{X: x, Y: 36}, rl.Vector2{X: barWidth * progress, Y: 6}, rl.Green)
+}
+
+// openStageResults shows how a stage went, with a button to go on and one back to the campaign.
+// It reports whether the first was picked, and has only the back button without a label for it.
+func (g *Game) openStageResults(titleText string, lines []string, primary string) bool {
+	buttonWidth := float32(220)
+	buttonHeight := float32(50)
+
+	campaignX := float32(g.screenWidth)/2 - buttonWidth/2
+	if primary != "" {
+		campaignX = float32(g.screenWidth)/2 + 10
+	}
+	primaryButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth-10,
+		float32(g.screenHeight)*0.75,
+		buttonWidth,
+		buttonHeight,
+		primary,
+		30,
+		g.menu.font,
+	)
+	campaignButton := NewMenuButton(
+		campaignX,
+		float32(g.screenHeight)*0.75,
+		buttonWidth,
+		buttonHeight,
+		"Campaign",
+		30,
+		g.menu.font,
+	)
+
+	titleFontSize := float32(60)
+	statsFontSize := float32(30)
+	titleSize := rl.MeasureTextEx(g.menu.font, titleText, titleFontSize, 1)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return false
+		}
+
+		mousePoint := rl.GetMousePosition()
+		if primary != "" && primaryButton.IsHovered(mousePoint) {
+			primaryButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				return true
+			}
+		} else {
+			primaryButton.color = rl.LightGray
+		}
+		if campaignButton.IsHovered(mousePoint) {
+			campaignButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateCampaign
+				return false
+			}
+		} else {
+			campaignButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		rl.DrawTextEx(
+			g.menu.font,
+			titleText,
+			rl.Vector2{X: float32(g.screenWidth)/2 - titleSize.X/2, Y: float32(g.screenHeight) * 0.2},
+			titleFontSize,
+			1,
+			rl.DarkGreen,
+		)
+		for i, line := range lines {
+			g.drawCenteredText(line, float32(g.screenHeight)*0.42+float32(i)*statsFontSize*1.5, statsFontSize, rl.DarkGray)
+		}
+
+		if primary != "" {
+			primaryButton.Draw()
+		}
+		campaignButton.Draw()
+		g.endFrame()
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/levels"
+)
+
+const (
+	consoleFontSize    = 16
+	consoleOutputLines = 10 // Lines of output shown above the input
+	consoleHistorySize = 50
+	consoleMaxInput    = 64
+)
+
+// console is the developer console's state kept between openings: the output and the commands run
+type console struct {
+	open    bool
+	output  []string
+	history []string
+}
+
+// print adds lines to the console output
+func (c *console) print(lines ...string) {
+	c.output = append(c.output, lines...)
+	if len(c.output) > consoleOutputLines {
+		c.output = slices.Delete(c.output, 0, len(c.output)-consoleOutputLines)
+	}
+}
+
+// consoleCommand is a command the console runs. Those marked inGame need a game being played.
+type consoleCommand struct {
+	name   string
+	usage  string
+	inGame bool
+	run    func(g *Game, engine *game.Engine, args []string) string
+}
+
+var consoleCommands = []consoleCommand{
+	{name: "speed", usage: "speed <ticks per second>", inGame: true, run: consoleSpeed},
+	{name: "points", usage: "points <amount>", inGame: true, run: consolePoints},
+	{name: "bomb", usage: "bomb [x y], under the mouse without a cell", inGame: true, run: consoleBomb},
+	{name: "level", usage: "level <name>, for the next game", run: consoleLevel},
+	{name: "seed", usage: "seed <n>, 0 for a new one each game", run: consoleSeed},
+	{name: "clear", usage: "clear"},
+	{name: "help", usage: "help"},
+}
+
+// consoleSpeed fixes the tick rate, so it no longer ramps up with the score
+func consoleSpeed(g *Game, engine *game.Engine, args []string) string {
+	rate, err := strconv.ParseFloat(firstArg(args), 32)
+	if err != nil || rate <= 0 {
+		return "Speed must be a number of ticks per second"
+	}
+	engine.Config.TickRate = float32(rate)
+	engine.Config.SpeedStep = 0
+	engine.Config.MaxTickRate = 0
+	return fmt.Sprintf("Speed set to %.1f ticks per second", rate)
+}
+
+func consolePoints(g *Game, engine *game.Engine, args []string) string {
+	points, err := strconv.Atoi(firstArg(args))
+	if err != nil {
+		return "Points must be a whole number"
+	}
+	engine.State.Points = max(0, engine.State.Points+points)
+	return fmt.Sprintf("Score is now %d", engine.State.Points)
+}
+
+func consoleBomb(g *Game, engine *game.Engine, args []string) string {
+	cell := g.boardMouseCell()
+	if len(args) >= 2 {
+		x, errX := strconv.Atoi(args[0])
+		y, errY := strconv.Atoi(args[1])
+		if errX != nil || errY != nil {
+			return "The cell must be two whole numbers"
+		}
+		cell = game.Point{X: x, Y: y}
+	}
+	if !engine.InBounds(cell) || engine.At(cell) != game.CellEmpty {
+		return fmt.Sprintf("Cell %d,%d isn't free", cell.X, cell.Y)
+	}
+	engine.AddBomb(cell)
+	return fmt.Sprintf("Bomb placed at %d,%d", cell.X, cell.Y)
+}
+
+func consoleLevel(g *Game, engine *game.Engine, args []string) string {
+	name := strings.Join(args, " ")
+	for _, level := range levels.All() {
+		if strings.EqualFold(level.Name, name) {
+			g.level = level
+			return "Level set to " + level.Name
+		}
+	}
+	return "Levels: " + strings.Join(levelNames(), ", ")
+}
+
+func consoleSeed(g *Game, engine *game.Engine, args []string) string {
+	seed, err := strconv.ParseUint(firstArg(args), 10, 64)
+	if err != nil {
+		return "The seed must be a whole number"
+	}
+	g.seed = seed
+	if seed == 0 {
+		return "Each game gets a new seed"
+	}
+	return fmt.Sprintf("Games start from seed %d", seed)
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+func levelNames() []string {
+	all := levels.All()
+	names := make([]string, len(all))
+	for i, level := range all {
+		names[i] = level.Name
+	}
+	return names
+}
+
+// run executes a line typed into the console and prints the result
+func (c *console) run(g *Game, engine *game.Engine, line string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+	c.print("> " + line)
+	if len(c.history) == 0 || c.history[len(c.history)-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > consoleHistorySize {
+			c.history = c.history[1:]
+		}
+	}
+
+	name := strings.ToLower(fields[0])
+	index := slices.IndexFunc(consoleCommands, func(command consoleCommand) bool { return command.name == name })
+	switch {
+	case index < 0:
+		c.print("Unknown command, try help")
+	case name == "clear":
+		c.output = c.output[:0]
+	case name == "help":
+		for _, command := range consoleCommands {
+			c.print("  " + command.usage)
+		}
+	case consoleCommands[index].inGame && engine == nil:
+		c.print("Only during a game")
+	default:
+		c.print(consoleCommands[index].run(g, engine, fields[1:]))
+	}
+}
+
+// complete finishes the command or level name being typed as far as the matches agree,
+// listing them when there are several
+func (c *console) complete(input string) string {
+	prefix, last := "", input
+	var candidates []string
+	if space := strings.LastIndex(input, " "); space >= 0 {
+		prefix, last = input[:space+1], input[space+1:]
+		if strings.ToLower(strings.TrimSpace(prefix)) != "level" {
+			return input
+		}
+		candidates = levelNames()
+	} else {
+		for _, command := range consoleCommands {
+			candidates = append(candidates, command.name)
+		}
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(last)) {
+			matches = append(matches, candidate)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return input
+	case 1:
+		return prefix + matches[0] + " "
+	}
+	c.print(strings.Join(matches, "  "))
+	common := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(strings.ToLower(match), strings.ToLower(common)) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(common) < len(last) {
+		return input
+	}
+	return prefix + common
+}
+
+// consoleScene is the developer console, an overlay over whatever screen it was opened on.
+// Backtick or Escape closes it, Up/Down recall earlier commands and Tab completes names.
+type consoleScene struct {
+	g       *Game
+	engine  *game.Engine // Game being played when the console opened, nil in menus
+	input   string
+	recall  int  // Index into the history while recalling commands, its length when not
+	started bool // Past the first frame, when the key that opened the console still reads as pressed
+}
+
+func (s *consoleScene) overlay() {}
+
+// openConsole opens the console over the current screen. Screens that are scenes keep drawing
+// under it; the others are paused in their loop, so the last frame they drew is shown instead.
+func (g *Game) openConsole() {
+	g.console.open = true
+	g.typing = true
+	scene := &consoleScene{g: g, engine: g.playing, recall: len(g.console.history)}
+	if !g.scenes.Empty() {
+		g.scenes.Push(scene)
+		return
+	}
+
+	image := rl.LoadImageFromTexture(g.canvas.Texture)
+	rl.ImageFlipVertical(image)
+	backdrop := rl.LoadTextureFromImage(image)
+	rl.UnloadImage(image)
+	defer rl.UnloadTexture(backdrop)
+	g.openScenes(&snapshotScene{texture: backdrop}, scene)
+}
+
+// close closes the console, and the still under it if it was opened on one
+func (s *consoleScene) close() {
+	s.g.console.open = false
+	s.g.typing = false
+	s.g.scenes.Pop()
+	if _, ok := s.g.scenes.Top().(*snapshotScene); ok {
+		s.g.scenes.Pop()
+	}
+}
+
+func (s *consoleScene) Update(dt float32) {
+	menuFocus.Hold()
+	// Skip the frame the console opened on, so the opening key doesn't close it again. Its
+	// character is dropped below.
+	if !s.started {
+		s.started = true
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyGrave) || rl.IsKeyReleased(rl.KeyEscape) {
+		s.close()
+		return
+	}
+
+	for char := rl.GetCharPressed(); char > 0; char = rl.GetCharPressed() {
+		if char >= ' ' && char < 127 && char != '`' && char != '~' && len(s.input) < consoleMaxInput {
+			s.input += string(char)
+		}
+	}
+	if (rl.IsKeyPressed(rl.KeyBackspace) || rl.IsKeyPressedRepeat(rl.KeyBackspace)) && len(s.input) > 0 {
+		s.input = s.input[:len(s.input)-1]
+	}
+
+	console := &s.g.console
+	switch {
+	case rl.IsKeyPressed(rl.KeyEnter):
+		console.run(s.g, s.engine, s.input)
+		s.input = ""
+		s.recall = len(console.history)
+	case rl.IsKeyPressed(rl.KeyTab):
+		s.input = console.complete(s.input)
+	case rl.IsKeyPressed(rl.KeyUp) && s.recall > 0:
+		s.recall--
+		s.input = console.history[s.recall]
+	case rl.IsKeyPressed(rl.KeyDown) && s.recall < len(console.history):
+		s.recall++
+		s.input = ""
+		if s.recall < len(console.history) {
+			s.input = console.history[s.recall]
+		}
+	}
+}
+
+func (s *consoleScene) Draw() {
+	lineHeight := float32(consoleFontSize + 4)
+	panel := rl.NewRectangle(0, 0, float32(s.g.screenWidth), float32(consoleOutputLines+1)*lineHeight+16)
+	rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.85))
+	rl.DrawLineEx(rl.Vector2{Y: panel.Height}, rl.Vector2{X: panel.Width, Y: panel.Height}, 2, rl.DarkGreen)
+
+	y := float32(8)
+	for _, line := range s.g.console.output {
+		rl.DrawTextEx(s.g.menu.font, line, rl.Vector2{X: 10, Y: y}, consoleFontSize, 1, rl.LightGray)
+		y += lineHeight
+	}
+
+	input := "> " + s.input
+	if int(rl.GetTime()*2)%2 == 0 {
+		input += "_"
+	}
+	rl.DrawTextEx(s.g.menu.font, input, rl.Vector2{X: 10, Y: panel.Height - lineHeight - 4}, consoleFontSize, 1, rl.Green)
+}
+
+// snapshotScene shows a still of a screen that can't draw itself, under an overlay
+type snapshotScene struct {
+	texture rl.Texture2D
+}
+
+func (s *snapshotScene) Update(dt float32) {}
+
+func (s *snapshotScene) Draw() {
+	rl.DrawTexture(s.texture, 0, 0, rl.White)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/input"
+)
+
+// openControlsScreen lists the key bound to each action. Clicking an action waits for the next key
+// pressed and binds it, clicking again cancels, and the layout button switches between ready-made
+// bindings for either hand. Bindings are saved to the profile as soon as they change.
+func (g *Game) openControlsScreen() {
+	buttonWidth := float32(340)
+	buttonHeight := float32(32)
+	buttonSpacing := float32(8)
+	buttonCount := float32(input.ActionCount + 3)
+	startY := float32(g.screenHeight)/2 - (buttonHeight*buttonCount+buttonSpacing*(buttonCount-1))/2 + 20
+
+	actionButtons := make([]MenuButton, input.ActionCount)
+	for i := range actionButtons {
+		actionButtons[i] = NewMenuButton(
+			float32(g.screenWidth)/2-buttonWidth/2,
+			startY+float32(i)*(buttonHeight+buttonSpacing),
+			buttonWidth,
+			buttonHeight,
+			"",
+			24,
+			g.menu.font,
+		)
+	}
+
+	layoutButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		startY+float32(input.ActionCount)*(buttonHeight+buttonSpacing),
+		buttonWidth,
+		buttonHeight,
+		"",
+		24,
+		g.menu.font,
+	)
+
+	resetButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		startY+float32(input.ActionCount+1)*(buttonHeight+buttonSpacing),
+		buttonWidth,
+		buttonHeight,
+		"Reset to Defaults",
+		24,
+		g.menu.font,
+	)
+
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		startY+float32(input.ActionCount+2)*(buttonHeight+buttonSpacing),
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		24,
+		g.menu.font,
+	)
+
+	// The action waiting for a key, if any
+	rebinding := input.ActionCount
+	defer func() { g.typing = false }()
+
+	for {
+		g.updateFramePacing()
+		// The key pressed to rebind an action is left alone by the hotkeys
+		g.typing = rebinding < input.ActionCount
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+
+		if rebinding < input.ActionCount {
+			// Any key binds, including Escape, so only a click cancels
+			if key := rl.GetKeyPressed(); key != 0 {
+				g.controls.Bind(rebinding, key)
+				g.saveSettings()
+				rebinding = input.ActionCount
+			}
+		} else if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateSettings
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		for i := range actionButtons {
+			action := input.Action(i)
+			if actionButtons[i].IsHovered(mousePoint) || rebinding == action {
+				actionButtons[i].color = rl.Gray
+				if actionButtons[i].IsHovered(mousePoint) && g.menu.handleButtonClick() {
+					if rebinding == action {
+						rebinding = input.ActionCount
+					} else {
+						rebinding = action
+					}
+				}
+			} else {
+				actionButtons[i].color = rl.LightGray
+			}
+
+			if rebinding == action {
+				actionButtons[i].text = fmt.Sprintf("%s: press a key", action)
+			} else {
+				actionButtons[i].text = fmt.Sprintf("%s: %s", action, input.KeyName(g.controls.Keys[action]))
+			}
+		}
+
+		// Custom bindings go on to the first layout
+		layout := g.controls.Layout()
+		if g.clicked(&layoutButton, mousePoint) {
+			layout = (layout + 1) % len(input.Layouts)
+			g.controls.Keys = input.Layouts[layout].Keys
+			g.saveSettings()
+			rebinding = input.ActionCount
+		}
+		layoutButton.text = "Layout: Custom"
+		if layout >= 0 {
+			layoutButton.text = "Layout: " + input.Layouts[layout].Name
+		}
+
+		if resetButton.IsHovered(mousePoint) {
+			resetButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.controls = input.DefaultInputMap()
+				g.saveSettings()
+				rebinding = input.ActionCount
+			}
+		} else {
+			resetButton.color = rl.LightGray
+		}
+
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateSettings
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+
+		g.drawCenteredText("CONTROLS", startY-70, 40, rl.DarkGreen)
+		g.drawCenteredText("Click an action, then press its new key", startY-buttonSpacing*3, 20, rl.DarkGray)
+
+		for i := range actionButtons {
+			actionButtons[i].Draw()
+		}
+		layoutButton.Draw()
+		resetButton.Draw()
+		backButton.Draw()
+
+		g.endFrame()
+	}
+}
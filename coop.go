@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// lanMatchText labels the host lobby's toggle between a versus and a co-op match
+func (g *Game) lanMatchText() string {
+	if g.lanCoop {
+		return "Match: Co-op"
+	}
+	return "Match: Versus"
+}
+
+// drawHeart draws the heart that revives a downed co-op partner, beating so it stands out
+func (g *Game) drawHeart(heart game.Entity) {
+	position := cellPosition(heart.Position)
+	beat := 1 + 0.12*float32(math.Sin(rl.GetTime()*6))
+	center := rl.Vector2{X: position.X + gridSize/2, Y: position.Y + gridSize/2}
+	r := gridSize / 4 * beat
+	rl.DrawCircleV(rl.Vector2{X: center.X - r*0.9, Y: center.Y - r*0.4}, r, rl.Red)
+	rl.DrawCircleV(rl.Vector2{X: center.X + r*0.9, Y: center.Y - r*0.4}, r, rl.Red)
+	rl.DrawTriangle(
+		rl.Vector2{X: center.X - r*1.85, Y: center.Y - r*0.1},
+		rl.Vector2{X: center.X, Y: center.Y + r*1.9},
+		rl.Vector2{X: center.X + r*1.85, Y: center.Y - r*0.1},
+		rl.Red,
+	)
+}
+
+// drawCoopScores shows the team's points, and what to do while either snake is down, from the
+// side of the given player: 0 the host, 1 the guest
+func (g *Game) drawCoopScores(state *game.State, player int) {
+	g.drawCenteredText(fmt.Sprintf("Team: %d", state.TeamPoints()), 10, 24, rl.Green)
+	if state.Over || len(state.Rivals) == 0 {
+		return
+	}
+	down := [2]bool{state.Down, !state.Rivals[0].Alive()}
+	switch {
+	case down[player]:
+		g.drawCenteredText("You're down! Hold on while your partner gets the heart", 40, 20, rl.Red)
+	case down[1-player]:
+		g.drawCenteredText("Your partner is down! Eat the heart to bring them back", 40, 20, rl.Red)
+	}
+}
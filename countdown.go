@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/audio"
+)
+
+const (
+	countdownTime = 3   // Seconds counted down before play starts or resumes
+	countdownGo   = 0.6 // Seconds "GO!" stays up once play has started
+)
+
+// startCountdown holds the next game or the resumed one still for a 3-2-1
+func (g *Game) startCountdown() {
+	g.countdown = countdownTime
+}
+
+// countingDown reports whether the countdown is still holding play
+func (g *Game) countingDown() bool {
+	return g.countdown > 0
+}
+
+// holdForCountdown runs the countdown on by a frame of dt seconds, with a beep for each number
+// and another as play starts. It returns how much of the frame the simulation should get, none
+// until the countdown is over.
+func (g *Game) holdForCountdown(dt float32) float32 {
+	if g.countdown <= -countdownGo {
+		return dt
+	}
+	before := g.countdown
+	g.countdown -= dt
+	if before <= 0 {
+		return dt
+	}
+	if g.countdown <= 0 {
+		g.audio.Play(audio.EventGo)
+	} else if before == countdownTime || math.Ceil(float64(before)) != math.Ceil(float64(g.countdown)) {
+		g.audio.Play(audio.EventCountdown)
+	}
+	return 0
+}
+
+// drawCountdown shows the number counting down over the board, then "GO!" as play starts
+func (g *Game) drawCountdown() {
+	if g.countdown <= -countdownGo {
+		return
+	}
+	text := "GO!"
+	// Each number pops in large and shrinks over its second
+	scale := float32(1)
+	if g.countdown > 0 {
+		text = fmt.Sprintf("%d", int(math.Ceil(float64(g.countdown))))
+		scale = 1 + 0.5*(g.countdown-float32(math.Floor(float64(g.countdown))))
+	}
+	fontSize := 80 * scale
+	rl.DrawRectangle(0, 0, g.screenWidth, g.screenHeight, rl.Fade(rl.Black, 0.25))
+	g.drawCenteredText(text, float32(g.screenHeight)/2-fontSize/2, fontSize, rl.White)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	_ "embed"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/render"
+)
+
+// iconPNG is the window icon, built into the binary so it shows even without the assets folder
+//
+//go:embed assets/icon.png
+var iconPNG []byte
+
+// setWindowIcon gives the window the snake icon
+func setWindowIcon() {
+	image := rl.LoadImageFromMemory(".png", iconPNG, int32(len(iconPNG)))
+	defer rl.UnloadImage(image)
+	rl.SetWindowIcon(*image)
+}
+
+// CursorStyle is how the mouse pointer looks
+type CursorStyle int
+
+const (
+	CursorSystem CursorStyle = iota
+	CursorThemed             // An apple over menus, the snake's head during play
+	CursorStyleCount
+)
+
+var cursorStyleNames = [CursorStyleCount]string{"System", "Themed"}
+
+func (c CursorStyle) String() string {
+	return cursorStyleNames[c]
+}
+
+// ParseCursorStyle returns the style with the given name, defaulting to System
+func ParseCursorStyle(name string) CursorStyle {
+	for i, styleName := range cursorStyleNames {
+		if styleName == name {
+			return CursorStyle(i)
+		}
+	}
+	return CursorSystem
+}
+
+// Next cycles to the following style, wrapping back to System
+func (c CursorStyle) Next() CursorStyle {
+	return (c + 1) % CursorStyleCount
+}
+
+// cursorSize is the side of the themed cursor
+const cursorSize = gridSize * 0.8
+
+// drawCursor draws the themed cursor at the mouse, hiding the system one under it. Screens with
+// buttons get the apple, and play the snake's head.
+func (g *Game) drawCursor() {
+	if g.cursor != CursorThemed {
+		if rl.IsCursorHidden() {
+			rl.ShowCursor()
+		}
+		return
+	}
+	if !rl.IsCursorHidden() {
+		rl.HideCursor()
+	}
+	if !rl.IsCursorOnScreen() {
+		return
+	}
+
+	mouse := rl.GetMousePosition()
+	rect := rl.NewRectangle(mouse.X, mouse.Y, cursorSize, cursorSize)
+	tile, color := render.TileApple, g.theme.Food
+	if !menuFocus.HasControls() {
+		tile, color = render.TileHead, g.skin.Head
+		// The head is centered on the pointer, the apple hangs from it like an arrow
+		rect.X -= cursorSize / 2
+		rect.Y -= cursorSize / 2
+	}
+	if g.atlas != nil {
+		g.atlas.Draw(tile, rect, 0, color)
+		return
+	}
+	rl.DrawRectangleRec(rect, color)
+	rl.DrawRectangleLinesEx(rect, 1, rl.Black)
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/daily"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/highscores"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/levels"
+)
+
+// dailyBoard is the board size every daily challenge is played on, so all players share one board
+const dailyBoard = BoardMedium
+
+// openDailyScreen shows today's challenge and, once played, today's top scores from the
+// leaderboard server or the player's past daily results without one. Each challenge can only
+// be attempted once.
+func (g *Game) openDailyScreen() {
+	challenge := daily.For(time.Now())
+	buttonWidth := float32(200)
+	buttonHeight := float32(44)
+
+	playButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth-10,
+		float32(g.screenHeight)-buttonHeight-20,
+		buttonWidth,
+		buttonHeight,
+		"Play",
+		28,
+		g.menu.font,
+	)
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2+10,
+		float32(g.screenHeight)-buttonHeight-20,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		28,
+		g.menu.font,
+	)
+
+	// Without a server the table lists the player's own daily results, newest first
+	nameTitle := "Name"
+	if g.leaderboard == nil {
+		nameTitle = "Date"
+	}
+	tableWidth := float32(g.screenWidth) * 0.7
+	table := NewTable(
+		float32(g.screenWidth)/2-tableWidth/2,
+		float32(g.screenHeight)*0.42,
+		tableWidth,
+		float32(g.screenHeight)*0.36,
+		[]TableColumn{
+			{title: "#", width: 0.1, alignRight: true},
+			{title: nameTitle, width: 0.4},
+			{title: "Score", width: 0.2, alignRight: true},
+			{title: "Time", width: 0.3, alignRight: true},
+		},
+		20,
+		g.menu.font,
+	)
+
+	played := g.daily.Played(challenge.Date)
+	status := ""
+	fetched := make(chan globalBoard, 1)
+	if g.leaderboard != nil {
+		status = "Loading..."
+		go fetchDailyBoard(g.leaderboard, challenge.Date, g.settings.HighScores, fetched)
+	} else {
+		rows := make([][]string, 0, len(g.daily.Results))
+		for i := len(g.daily.Results) - 1; i >= 0; i-- {
+			result := g.daily.Results[i]
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", len(rows)+1),
+				result.Date,
+				fmt.Sprintf("%d", result.Score),
+				fmt.Sprintf("%.1fs", result.Duration),
+			})
+		}
+		table.SetRows(rows)
+	}
+
+	summary := fmt.Sprintf("%s on %s, %s mode", challenge.Level, challenge.Difficulty, challenge.Mode)
+	if challenge.Transform != levels.TransformNone {
+		summary += ", twist: " + challenge.Transform.String()
+	}
+	playedText := ""
+	if result, ok := g.daily.Result(challenge.Date); ok && result.Finished {
+		playedText = fmt.Sprintf("Played today: %d points. Come back tomorrow!", result.Score)
+	} else if ok {
+		playedText = "Today's attempt was quit. Come back tomorrow!"
+	}
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		for len(fetched) > 0 {
+			result := <-fetched
+			status = ""
+			if result.err != nil {
+				fmt.Println("Failed to fetch daily leaderboard:", result.err)
+				status = "Leaderboard unavailable"
+			}
+			rows := make([][]string, len(result.scores))
+			for i, score := range result.scores {
+				rows[i] = []string{
+					fmt.Sprintf("%d", i+1),
+					score.Name,
+					fmt.Sprintf("%d", score.Score),
+					fmt.Sprintf("%.1fs", score.Duration),
+				}
+			}
+			table.SetRows(rows)
+		}
+
+		mousePoint := rl.GetMousePosition()
+		table.HandleScroll(mousePoint)
+		table.HandleKeys()
+
+		if played {
+			playButton.color = rl.DarkGray
+		} else if playButton.IsHovered(mousePoint) {
+			playButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateDailyGame
+				return
+			}
+		} else {
+			playButton.color = rl.LightGray
+		}
+
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateMainMenu
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText("DAILY CHALLENGE", float32(g.screenHeight)*0.05, 40, rl.DarkGreen)
+		g.drawCenteredText(challenge.Date, float32(g.screenHeight)*0.16, 24, rl.DarkGray)
+		g.drawCenteredText(summary, float32(g.screenHeight)*0.24, 24, rl.Black)
+		if playedText != "" {
+			g.drawCenteredText(playedText, float32(g.screenHeight)*0.32, 20, rl.Maroon)
+		}
+
+		table.Draw()
+		if status != "" {
+			g.drawCenteredText(status, float32(g.screenHeight)*0.55, 20, rl.DarkGray)
+		}
+		playButton.Draw()
+		backButton.Draw()
+
+		g.endFrame()
+	}
+}
+
+// StartDailyGame plays today's challenge. The attempt is recorded as soon as it starts, so
+// quitting or closing the game uses it up too.
+func (g *Game) StartDailyGame() {
+	challenge := daily.For(time.Now())
+	if g.daily.Played(challenge.Date) {
+		g.state = StateDaily
+		return
+	}
+	g.daily.Set(daily.Result{Date: challenge.Date})
+	g.saveDaily()
+
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+	defer g.audio.PlayMusic(&g.audio.MenuMusic)
+
+	// Every player gets the same board, whatever size they have picked
+	board := g.board
+	g.board = dailyBoard
+	defer func() { g.board = board }()
+
+	width, height := g.boardSize()
+	config := ParseDifficulty(challenge.Difficulty).Config(width, height)
+	level := levels.Find(challenge.Level).Transformed(challenge.Transform)
+	config.Walls = level.Walls(width, height)
+	config.Portals = level.Portals(width, height)
+	config.Mode = challenge.Mode
+	engine := game.NewEngine(config, challenge.Seed)
+	g.particles.Clear()
+	g.camFX.Reset()
+	g.startCountdown()
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		if g.controls.Pressed(input.ActionPause) {
+			if !g.openPauseScreen(&engine.State) {
+				g.recordGame(engine)
+				g.state = StateDaily
+				return
+			}
+			continue
+		} else if rl.WindowShouldClose() {
+			g.recordGame(engine)
+			g.running = false
+			return
+		}
+
+		g.handleSnakeInput(engine)
+
+		delta := g.camFX.Step(g.holdForCountdown(g.simulationDelta(backgrounded))) * g.gameSpeed
+		events := engine.Update(delta)
+		g.playEventSounds(events, &engine.State)
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		if engine.State.Over && !g.hatFlying() {
+			g.recordGame(engine)
+			g.finishDaily(challenge, engine)
+			return
+		}
+
+		g.updateDebug(engine)
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		g.drawArena(engine)
+		rl.EndMode2D()
+		g.drawHUD(engine, hudLine{text: "Daily " + challenge.Date, color: g.theme.HUD})
+
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// finishDaily records the day's result, submits it to the daily leaderboard and shows it
+func (g *Game) finishDaily(challenge daily.Challenge, engine *game.Engine) {
+	g.daily.Set(daily.Result{
+		Date:     challenge.Date,
+		Score:    engine.State.Points,
+		Duration: engine.Duration(),
+		Finished: true,
+	})
+	g.saveDaily()
+
+	// Slowed scores stay off the global leaderboard
+	if g.leaderboard != nil && g.gameSpeed >= 1 {
+		name := g.playerName
+		if name == "" {
+			var ok bool
+			if name, ok = g.openNameEntryScreen(); !ok {
+				return
+			}
+		}
+		go submitGlobalScore(g.leaderboard, highscores.HighScore{
+			Name:       name,
+			Score:      engine.State.Points,
+			Duration:   engine.Duration(),
+			Difficulty: challenge.Difficulty,
+			Level:      challenge.Level,
+			Board:      dailyBoard.String(),
+			Length:     len(engine.State.Snake.Segments),
+			MaxCombo:   engine.State.MaxCombo,
+			Mode:       challenge.Mode.String(),
+			Daily:      challenge.Date,
+			Transform:  transformName(challenge.Transform),
+			Date:       time.Now(),
+			Version:    gameVersion,
+			Speed:      g.speedPercent(),
+		})
+	}
+
+	g.openResultsScreen("DAILY DONE!", []string{
+		fmt.Sprintf("Score: %d", engine.State.Points),
+		fmt.Sprintf("Time: %.1fs", engine.Duration()),
+	})
+}
+
+func (g *Game) saveDaily() {
+	if err := daily.Save(g.daily); err != nil {
+		fmt.Println("Failed to save daily challenge results:", err)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+const (
+	dangerAlertOn   = 0.6 // Danger level that raises the alert
+	dangerAlertOff  = 0.4 // Danger level the alert clears below, lower than On to avoid flapping
+	dangerLookout   = 4   // Cells ahead of the head checked for the snake's body
+	dangerBombReach = 4   // Cells from the head at which bombs start to count
+	heartbeatRate   = 1.8 // Beats per second at full danger
+)
+
+// dangerMeter tracks how close the snake is to dying and drives the heartbeat overlay
+type dangerMeter struct {
+	level     float32 // 0-1, recomputed every tick
+	alert     bool
+	intensity float32 // Smoothed overlay strength, eased every frame
+	phase     float64 // How far through the current heartbeat, from 0 to 1
+}
+
+// update sets the danger level for this tick and applies hysteresis to the alert
+func (d *dangerMeter) update(level float32) {
+	d.level = level
+	if !d.alert && level >= dangerAlertOn {
+		d.alert = true
+	} else if d.alert && level <= dangerAlertOff {
+		d.alert = false
+	}
+}
+
+// computeDanger scores the snake's situation from 0 (safe) to 1 (about to die) from
+// the distance to the nearest bomb, its own body or a wall ahead of the head, and how full the board is
+func computeDanger(engine *game.Engine) float32 {
+	snake := engine.State.Snake
+	head := snake.Head()
+
+	// Nearest bomb, in cells
+	bombDanger := float32(0)
+	for _, bomb := range engine.State.Entities {
+		if bomb.Kind != game.EntityBomb {
+			continue
+		}
+		dist := float32(abs(bomb.Position.X-head.X) + abs(bomb.Position.Y-head.Y))
+		bombDanger = max(bombDanger, 1-(dist-1)/dangerBombReach)
+	}
+
+	// Own body or a wall straight ahead
+	bodyDanger := float32(0)
+	pos := head
+	for step := 1; step <= dangerLookout; step++ {
+		pos = engine.Next(pos, snake.Direction)
+		if cell := engine.At(pos); cell == game.CellSnake || cell == game.CellWall {
+			bodyDanger = 1 - float32(step-1)/dangerLookout
+			break
+		}
+	}
+
+	// Share of the board taken by the snake and bombs
+	cells := float32(engine.Config.Width * engine.Config.Height)
+	crowding := float32(len(snake.Segments)+engine.State.Count(game.EntityBomb)) / cells
+
+	level := max(bombDanger, bodyDanger)*0.8 + crowding*0.2
+	return min(1, max(0, level))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// drawDangerOverlay pulses a red border in a heartbeat rhythm while the alert is raised, or holds
+// it steady without flashing
+func (g *Game) drawDangerOverlay(danger *dangerMeter) {
+	target := float32(0)
+	if danger.alert {
+		target = danger.level
+	}
+	// Ease towards the target so the overlay fades in and out
+	dt := rl.GetFrameTime()
+	danger.intensity += (target - danger.intensity) * min(1, dt*4)
+	if danger.intensity < 0.01 {
+		return
+	}
+
+	// Two quick beats per cycle, like a heartbeat. The phase is summed frame by frame, so the
+	// rate follows the intensity without the beat jumping as it changes.
+	danger.phase = math.Mod(danger.phase+float64(dt)*heartbeatRate*float64(danger.intensity), 1)
+	phase := danger.phase
+	beat := math.Max(math.Exp(-phase*12), 0.6*math.Exp(-math.Abs(phase-0.25)*12))
+	if g.noFlashing {
+		beat = 0.3
+	}
+
+	alpha := uint8(danger.intensity * float32(60+100*beat))
+	thickness := float32(12 + 10*beat)
+	rl.DrawRectangleLinesEx(
+		rl.NewRectangle(0, 0, float32(g.screenWidth), float32(g.screenHeight)),
+		thickness,
+		rl.Color{R: 200, G: 0, B: 0, A: alpha},
+	)
+}
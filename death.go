@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/ztkent/snake/internal/audio"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// deathMessages say what ended the run, by cause
+var deathMessages = [game.CauseCount]string{
+	game.CauseWall:  "You ran into a wall",
+	game.CauseSelf:  "You bit your own tail",
+	game.CauseRival: "You ran into another snake",
+	game.CauseBomb:  "You stepped on a bomb",
+	game.CauseBlast: "You were caught in a blast",
+}
+
+// causeName is how a cause is saved in stats and high scores, empty if the snake didn't crash
+func causeName(cause game.Cause) string {
+	if cause == game.CauseNone {
+		return ""
+	}
+	return cause.String()
+}
+
+// deathMessage returns what ended the run, empty if the snake didn't crash
+func deathMessage(cause game.Cause) string {
+	return deathMessages[cause]
+}
+
+// deathSound picks the game over sound for what the snake died to, falling back to the usual
+// game over sound if that one is missing. A blast already has its explosion.
+func (g *Game) deathSound(cause game.Cause) audio.Event {
+	sound := audio.EventGameOver
+	switch cause {
+	case game.CauseWall:
+		sound = audio.EventCrash
+	case game.CauseBomb:
+		sound = audio.EventExplosion
+	}
+	if !g.audio.Has(sound) {
+		return audio.EventGameOver
+	}
+	return sound
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+const (
+	debugFontSize    = 14
+	debugGraphFrames = 120 // Recent frames shown in the frame time graph
+	debugGraphHeight = 40
+)
+
+// debugOverlay is the F3 overlay of frame and game stats, with commands for testing. It is
+// only available when the game is started with -debug.
+type debugOverlay struct {
+	shown      bool
+	invincible bool                      // The snake can't die, toggled with F4
+	frameTimes [debugGraphFrames]float32 // Recent frame times in seconds, a ring starting at next
+	next       int
+}
+
+// recordFrameTime adds the last frame's time to the graph
+func (d *debugOverlay) recordFrameTime() {
+	d.frameTimes[d.next] = rl.GetFrameTime()
+	d.next = (d.next + 1) % debugGraphFrames
+}
+
+// updateDebug notes the game being played, for the overlay and the console, and runs the debug
+// commands on it. Called each frame before beginFrame. F4 toggles invincibility and F5 drops
+// food on the cell under the mouse.
+func (g *Game) updateDebug(engine *game.Engine) {
+	g.playing = engine
+	if !g.debugMode {
+		return
+	}
+	if rl.IsKeyPressed(rl.KeyF4) {
+		g.debug.invincible = !g.debug.invincible
+	}
+	if rl.IsKeyPressed(rl.KeyF5) && !engine.AddFood(g.boardMouseCell()) {
+		fmt.Println("Debug: no room for food under the mouse")
+	}
+	// Keep the respawn invulnerability topped up, which also makes the snake blink
+	if g.debug.invincible && !engine.State.Over {
+		engine.State.Invulnerable = max(engine.State.Invulnerable, 1)
+	}
+}
+
+// drawDebugOverlay draws the stats at the top of the screen, on the side away from the HUD
+func (g *Game) drawDebugOverlay() {
+	engine := g.playing
+	if !g.debug.shown {
+		return
+	}
+
+	frameTime := g.debug.frameTimes[(g.debug.next+debugGraphFrames-1)%debugGraphFrames]
+	lines := []string{
+		fmt.Sprintf("FPS: %d (%.1f ms)", rl.GetFPS(), frameTime*1000),
+	}
+	if engine != nil {
+		state := &engine.State
+		lines = append(lines,
+			fmt.Sprintf("Tick rate: %.1f/s", engine.TickRate()),
+			fmt.Sprintf("Seed: %d", engine.Seed()),
+			fmt.Sprintf("Hash: %016x", engine.Hash()),
+			fmt.Sprintf("Length: %d", len(state.Snake.Segments)),
+			fmt.Sprintf("Food: %d  Bombs: %d", state.Count(game.EntityFood), state.Count(game.EntityBomb)),
+			fmt.Sprintf("Power-ups: %d  Rivals: %d", state.Count(game.EntityPowerUp), len(state.Rivals)),
+			fmt.Sprintf("Particles: %d", g.particles.Live()),
+			fmt.Sprintf("Invincible (F4): %t", g.debug.invincible),
+			"F5: food at mouse",
+		)
+	}
+
+	width := float32(debugGraphFrames * 1.5)
+	lineHeight := float32(debugFontSize + 2)
+	panel := rl.NewRectangle(hudMargin, hudMargin, width+12, float32(len(lines))*lineHeight+debugGraphHeight+18)
+	if g.hudCorner.left() {
+		panel.X = float32(g.screenWidth) - hudMargin - panel.Width
+	}
+	rl.DrawRectangleRec(panel, rl.Fade(rl.Black, 0.7))
+
+	x, y := panel.X+6, panel.Y+6
+	for _, line := range lines {
+		rl.DrawTextEx(g.menu.font, line, rl.Vector2{X: x, Y: y}, debugFontSize, 1, rl.Yellow)
+		y += lineHeight
+	}
+
+	// Frame time bars, oldest on the left, scaled so two target frames fill the height
+	y += 6
+	scale := debugGraphHeight * targetFPS / 2
+	for i := range debugGraphFrames {
+		frame := g.debug.frameTimes[(g.debug.next+i)%debugGraphFrames]
+		height := min(debugGraphHeight, frame*float32(scale))
+		color := rl.Green
+		if frame > 1.5/targetFPS {
+			color = rl.Red
+		}
+		rl.DrawRectangleRec(rl.NewRectangle(x+float32(i)*1.5, y+debugGraphHeight-height, 1.5, height), color)
+	}
+	// The target frame time
+	target := y + debugGraphHeight/2
+	rl.DrawLineEx(rl.Vector2{X: x, Y: target}, rl.Vector2{X: x + width, Y: target}, 1, rl.Fade(rl.White, 0.5))
+}
@@ -0,0 +1,48 @@
+package main
+
+import "github.com/ztkent/snake/internal/game"
+
+// Difficulty selects the speed, spawn and scoring rules used by StartGame
+type Difficulty int
+
+const (
+	DifficultyEasy Difficulty = iota
+	DifficultyNormal
+	DifficultyHard
+	DifficultyCount
+)
+
+var difficultyNames = [DifficultyCount]string{"Easy", "Normal", "Hard"}
+
+func (d Difficulty) String() string {
+	return difficultyNames[d]
+}
+
+// ParseDifficulty returns the difficulty with the given name, defaulting to Normal
+func ParseDifficulty(name string) Difficulty {
+	for i, difficultyName := range difficultyNames {
+		if difficultyName == name {
+			return Difficulty(i)
+		}
+	}
+	return DifficultyNormal
+}
+
+var difficultyProfiles = [DifficultyCount]game.Config{
+	DifficultyEasy:   {TickRate: 10, SpeedStep: 0.1, MaxTickRate: 15, MaxFood: 4, FoodInterval: 15, BombDivisor: 3, ScoreMultiplier: 1, PowerUpChance: 0.006, GoldenChance: 0.003, PoisonChance: 0.001, PortalChance: 0.002, BombFuse: 12, BlastRadius: 1},
+	DifficultyNormal: {TickRate: tickRate, SpeedStep: 0.2, MaxTickRate: 24, MaxFood: 6, FoodInterval: 10, BombDivisor: 2, ScoreMultiplier: 1, PowerUpChance: 0.004, GoldenChance: 0.002, PoisonChance: 0.0015, PortalChance: 0.002, BombFuse: 10, BlastRadius: 1},
+	DifficultyHard:   {TickRate: 20, SpeedStep: 0.25, MaxTickRate: 32, MaxFood: 8, FoodInterval: 8, BombDivisor: 1, ScoreMultiplier: 2, PowerUpChance: 0.003, GoldenChance: 0.0015, PoisonChance: 0.002, PortalChance: 0.0025, BombFuse: 8, BlastRadius: 2},
+}
+
+// Config returns the engine rules for the difficulty on a board of the given size in cells
+func (d Difficulty) Config(width, height int) game.Config {
+	config := difficultyProfiles[d]
+	config.Width = width
+	config.Height = height
+	return config
+}
+
+// Next cycles to the following difficulty, wrapping back to Easy
+func (d Difficulty) Next() Difficulty {
+	return (d + 1) % DifficultyCount
+}
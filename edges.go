@@ -0,0 +1,20 @@
+package main
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// edgeColor outlines a board whose edges are deadly
+var edgeColor = rl.Color{R: 200, G: 40, B: 40, A: 255}
+
+// edgesText labels the board edges toggle
+func (g *Game) edgesText() string {
+	if g.solidEdges {
+		return "Edges: Solid"
+	}
+	return "Edges: Wrap"
+}
+
+// drawSolidEdges outlines the board to show its edges are deadly. Call it in board coordinates.
+func (g *Game) drawSolidEdges() {
+	width, height := g.boardSize()
+	rl.DrawRectangleLinesEx(rl.NewRectangle(0, 0, float32(width*gridSize), float32(height*gridSize)), 3, edgeColor)
+}
@@ -0,0 +1,34 @@
+package main
+
+import "github.com/ztkent/snake/internal/game"
+
+// entityDrawers draw each kind of entity on the board, given its place among the entities of its
+// kind, which tells portal pairs apart
+var entityDrawers = [game.EntityKindCount]func(g *Game, entity game.Entity, index int){
+	game.EntityFood:    func(g *Game, food game.Entity, _ int) { g.drawFood(food) },
+	game.EntityBomb:    func(g *Game, bomb game.Entity, _ int) { g.drawBomb(bomb) },
+	game.EntityPowerUp: func(g *Game, powerUp game.Entity, _ int) { g.drawPowerUp(powerUp) },
+	game.EntityPortal:  (*Game).drawPortalPair,
+	game.EntityHeart:   func(g *Game, heart game.Entity, _ int) { g.drawHeart(heart) },
+}
+
+// drawEntities draws everything on the board besides the snakes and walls
+func (g *Game) drawEntities(entities []game.Entity) {
+	var counts [game.EntityKindCount]int
+	for _, entity := range entities {
+		entityDrawers[entity.Kind](g, entity, counts[entity.Kind])
+		counts[entity.Kind]++
+	}
+}
+
+// drawFood draws a piece of food in its kind's style
+func (g *Game) drawFood(food game.Entity) {
+	switch food.FoodKind() {
+	case game.FoodGolden:
+		g.drawGoldenApple(food)
+	case game.FoodPoison:
+		g.drawPoisonApple(food)
+	default:
+		g.drawApple(cellPosition(food.Position), g.theme.Food)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/cosmetics"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/ui"
+)
+
+// firstRunSteps are the titles of the first-run wizard's steps, in order
+var firstRunSteps = []string{"Controls", "Volume", "Colors", "Window"}
+
+// Window modes on the wizard's last step
+const (
+	windowModeWindowed = iota
+	windowModeFullscreen
+)
+
+// firstRunScene walks a new player through the controls, volume, colors and window mode before
+// the first menu. Each choice takes effect straight away, and the settings file is written once
+// the last step is done, so closing the game part way through asks again next time.
+type firstRunScene struct {
+	g *Game
+
+	step       int
+	steps      []*ui.Group // The widgets of each step
+	themes     []cosmetics.Theme
+	backButton MenuButton
+	nextButton MenuButton
+}
+
+func (g *Game) newFirstRunScene() *firstRunScene {
+	width := float32(360)
+	height := float32(30)
+	spacing := float32(8)
+	x := float32(g.screenWidth)/2 - width/2
+	startY := float32(g.screenHeight) * 0.3
+	row := func(i float32) rl.Rectangle {
+		return rl.NewRectangle(x, startY+i*(height+spacing), width, height)
+	}
+	style := g.uiStyle(30)
+	s := &firstRunScene{g: g}
+
+	schemes := make([]string, ControlSchemeCount)
+	for i := range schemes {
+		schemes[i] = ControlScheme(i).String()
+	}
+	scheme := ui.NewDropdown(style, row(0), "Steering", schemes, int(g.scheme))
+	scheme.OnChange = func(index int) {
+		g.scheme = ControlScheme(index)
+	}
+	layouts := make([]string, len(input.Layouts))
+	for i, layout := range input.Layouts {
+		layouts[i] = layout.Name
+	}
+	layout := ui.NewDropdown(style, row(1), "Keys", layouts, max(0, g.controls.Layout()))
+	layout.OnChange = func(index int) {
+		g.controls.Keys = input.Layouts[index].Keys
+	}
+
+	volume := ui.NewSlider(style, row(0), 0, 100, 1, g.volume, func(value float32) string {
+		return fmt.Sprintf("Master: %0.f%%", value)
+	})
+	volume.OnChange = func(value float32) {
+		g.volume = value
+		g.audio.SetMasterVolume(value)
+	}
+	musicVolume := ui.NewSlider(style, row(1), 0, 100, 1, g.musicVolume, func(value float32) string {
+		return fmt.Sprintf("Music: %0.f%%", value)
+	})
+	musicVolume.OnChange = func(value float32) {
+		g.musicVolume = value
+		g.audio.SetMusicVolume(value)
+	}
+	sfxVolume := ui.NewSlider(style, row(2), 0, 100, 1, g.sfxVolume, func(value float32) string {
+		return fmt.Sprintf("SFX: %0.f%%", value)
+	})
+	sfxVolume.OnChange = func(value float32) {
+		g.sfxVolume = value
+		g.audio.SetSfxVolume(value)
+	}
+
+	// Only the themes a new player already has, which include the colorblind palette
+	var names []string
+	for _, theme := range cosmetics.Themes {
+		if theme.Unlock.Met(&g.achievements) {
+			s.themes = append(s.themes, theme)
+			names = append(names, theme.Name)
+		}
+	}
+	selected := 0
+	for i, theme := range s.themes {
+		if theme.Name == g.theme.Name {
+			selected = i
+		}
+	}
+	theme := ui.NewDropdown(style, row(0), "Theme", names, selected)
+	theme.OnChange = func(index int) {
+		g.setTheme(s.themes[index])
+	}
+
+	mode := windowModeWindowed
+	if rl.IsWindowState(rl.FlagBorderlessWindowedMode) {
+		mode = windowModeFullscreen
+	}
+	window := ui.NewDropdown(style, row(0), "Window", []string{"Windowed", "Fullscreen"}, mode)
+	window.OnChange = func(index int) {
+		if (index == windowModeFullscreen) != rl.IsWindowState(rl.FlagBorderlessWindowedMode) {
+			rl.ToggleBorderlessWindowed()
+		}
+	}
+
+	s.steps = []*ui.Group{
+		ui.NewGroup(&menuFocus, scheme, layout),
+		ui.NewGroup(&menuFocus, volume, musicVolume, sfxVolume),
+		ui.NewGroup(&menuFocus, theme),
+		ui.NewGroup(&menuFocus, window),
+	}
+
+	half := width/2 - spacing/2
+	buttonY := float32(g.screenHeight) - height - 30
+	s.backButton = NewMenuButton(x, buttonY, half, height, "Back", 30, g.menu.font)
+	s.nextButton = NewMenuButton(x+half+spacing, buttonY, half, height, "Next", 30, g.menu.font)
+	return s
+}
+
+// move goes forward or back a step, closing the wizard after the last
+func (s *firstRunScene) move(by int) {
+	s.step += by
+	if s.step >= len(s.steps) {
+		// Closing the scene saves the choices, which also stops the wizard coming back
+		s.g.closeScene(StateMainMenu)
+		return
+	}
+	s.nextButton.text = "Next"
+	if s.step == len(s.steps)-1 {
+		s.nextButton.text = "Done"
+	}
+	announceFocus(firstRunSteps[s.step])
+}
+
+func (s *firstRunScene) Update(dt float32) {
+	g := s.g
+	g.audio.UpdateMusic()
+	mousePoint := rl.GetMousePosition()
+	widgets := s.steps[s.step]
+
+	// An open dropdown takes the frame's clicks and keys
+	if widgets.Capturing() {
+		widgets.Update(mousePoint)
+		return
+	}
+	if rl.IsKeyReleased(rl.KeyEscape) && s.step > 0 {
+		s.move(-1)
+		return
+	}
+
+	widgets.Update(mousePoint)
+	switch {
+	case s.step > 0 && g.clicked(&s.backButton, mousePoint):
+		s.move(-1)
+	case g.clicked(&s.nextButton, mousePoint):
+		s.move(1)
+	}
+}
+
+func (s *firstRunScene) Draw() {
+	g := s.g
+	rl.ClearBackground(menuTheme.Menu)
+	g.menu.updateBackground()
+	g.drawCenteredText("WELCOME TO SNAKE", float32(g.screenHeight)*0.06, 40, rl.DarkGreen)
+	title := fmt.Sprintf("Step %d of %d: %s", s.step+1, len(s.steps), firstRunSteps[s.step])
+	g.drawCenteredText(title, float32(g.screenHeight)*0.18, 28, rl.DarkGray)
+
+	// The colors step previews the snake and an apple on the theme's board
+	if s.step == 2 {
+		board := rl.NewRectangle(float32(g.screenWidth)/2-2.5*gridSize, float32(g.screenHeight)*0.5, 5*gridSize, gridSize)
+		rl.DrawRectangleRec(board, g.theme.Board)
+		g.drawAvatar(board.X, board.Y)
+		g.drawApple(rl.Vector2{X: board.X + 4*gridSize, Y: board.Y}, g.theme.Food)
+	}
+
+	g.drawCenteredText("Everything here can be changed later under Settings", s.nextButton.rect.Y-30, 20, rl.Gray)
+	if s.step > 0 {
+		s.backButton.Draw()
+	}
+	s.nextButton.Draw()
+	s.steps[s.step].Draw()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/ghost"
+)
+
+// ghostColor is the translucent tint the best run is drawn in
+var ghostColor = rl.Color{R: 255, G: 255, B: 255, A: 64}
+
+// loadGhost returns the best recorded run on the current level, difficulty and board size,
+// or nil if there isn't one
+func (g *Game) loadGhost() *ghost.Run {
+	best, err := ghost.Load(g.ghostLevel(), g.difficulty.String(), g.board.String())
+	if err != nil {
+		fmt.Println("Failed to load ghost:", err)
+	}
+	return best
+}
+
+// saveGhost keeps the finished run if it beat the best one
+func (g *Game) saveGhost(run *ghost.Run, best *ghost.Run) {
+	run.Score = g.score.points
+	if best != nil && best.Score >= run.Score {
+		return
+	}
+	if err := ghost.Save(run); err != nil {
+		fmt.Println("Failed to save ghost:", err)
+	}
+}
+
+// drawGhost draws the best run as it was at the same time into the run
+func (g *Game) drawGhost(best *ghost.Run, elapsed float32) {
+	if best == nil {
+		return
+	}
+	size := rl.Vector2{X: gridSize, Y: gridSize}
+	for _, segment := range best.Segments(elapsed) {
+		rl.DrawRectangleV(cellPosition(segment), size, ghostColor)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+var goldenColor = rl.Color{R: 255, G: 215, B: 60, A: 255}
+
+// drawGoldenApple draws a golden apple inside a ring that shrinks away as it is about to despawn
+func (g *Game) drawGoldenApple(food game.Entity) {
+	center := cellPosition(food.Position)
+	center.X += gridSize / 2
+	center.Y += gridSize / 2
+	left := food.Remaining / game.GoldenLifetime
+	if g.atlas != nil {
+		g.drawApple(cellPosition(food.Position), goldenColor)
+	} else {
+		rl.DrawCircleV(center, gridSize/2-2, goldenColor)
+	}
+	rl.DrawRing(center, gridSize/2, gridSize/2+3, -90, -90+360*left, 24, rl.Orange)
+}
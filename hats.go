@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/cosmetics"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/particles"
+)
+
+// hatHop is how high, in pixels, a hat hops each cell the snake glides
+const hatHop = 3
+
+// hatOnHead returns where a hat sits on a head drawn at position: the middle of its top edge,
+// hopping once per cell as the head glides from one to the next
+func hatOnHead(head rl.Vector2) rl.Vector2 {
+	travelled := math.Mod(math.Abs(float64(head.X+head.Y)), gridSize) / gridSize
+	hop := float32(math.Sin(travelled*math.Pi)) * hatHop
+	return rl.Vector2{X: head.X + gridSize/2, Y: head.Y + 2 - hop}
+}
+
+// throwHat knocks the hat off a snake that has just crashed, from where its head was, to tumble
+// off the bottom of the board
+func (g *Game) throwHat(engine *game.Engine) {
+	previous := engine.Previous()
+	if g.hat.Shape == cosmetics.HatNone || len(previous) == 0 {
+		return
+	}
+	_, height := g.boardSize()
+	floor := float32((height + 2) * gridSize)
+	g.particles.Throw(particles.HatToss, hatOnHead(cellPosition(previous[0])), floor)
+}
+
+// hatFlying reports whether a hat is still in the air, so the board stays up until it lands
+func (g *Game) hatFlying() bool {
+	return len(g.particles.Bodies()) > 0
+}
+
+// drawHat draws a hat with the middle of its brim at base, turned by angle degrees
+func (g *Game) drawHat(hat cosmetics.Hat, base rl.Vector2, angle float32) {
+	point := func(x, y float32) rl.Vector2 {
+		return rl.Vector2Add(base, rl.Vector2Rotate(rl.Vector2{X: x, Y: y}, angle*rl.Deg2rad))
+	}
+	triangle := func(x1, y1, x2, y2, x3, y3 float32, color rl.Color) {
+		rl.DrawTriangle(point(x1, y1), point(x2, y2), point(x3, y3), color)
+	}
+	rect := func(left, top, right, bottom float32, color rl.Color) {
+		triangle(left, top, left, bottom, right, bottom, color)
+		triangle(left, top, right, bottom, right, top, color)
+	}
+
+	switch hat.Shape {
+	case cosmetics.HatParty:
+		triangle(0, -16, -7, 0, 7, 0, hat.Color)
+		rl.DrawCircleV(point(0, -16), 2.5, rl.White)
+	case cosmetics.HatTop:
+		rect(-9, -3, 9, 0, hat.Color)
+		rect(-6, -15, 6, -3, hat.Color)
+		rect(-6, -6, 6, -3, rl.Red)
+	case cosmetics.HatCrown:
+		rect(-8, -5, 8, 0, hat.Color)
+		triangle(-6, -12, -8, -5, -3, -5, hat.Color)
+		triangle(0, -13, -3, -5, 3, -5, hat.Color)
+		triangle(6, -12, 3, -5, 8, -5, hat.Color)
+		rl.DrawCircleV(point(0, -3), 1.5, rl.Red)
+	}
+}
+
+// nextHat cycles to the hat after the given one, wrapping back to none
+func nextHat(hat cosmetics.Hat) cosmetics.Hat {
+	for i, h := range cosmetics.Hats {
+		if h.Name == hat.Name {
+			return cosmetics.Hats[(i+1)%len(cosmetics.Hats)]
+		}
+	}
+	return cosmetics.Hats[0]
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// HUDLayout is how much the in-game HUD shows
+type HUDLayout int
+
+const (
+	HUDFull    HUDLayout = iota // Score, time, length, speed, combo, lives and power-ups
+	HUDMinimal                  // Score, time, length, boost and lives only
+	HUDLayoutCount
+)
+
+var hudLayoutNames = [HUDLayoutCount]string{"Full", "Minimal"}
+
+func (l HUDLayout) String() string {
+	return hudLayoutNames[l]
+}
+
+// ParseHUDLayout returns the layout with the given name, defaulting to Full
+func ParseHUDLayout(name string) HUDLayout {
+	for i, layoutName := range hudLayoutNames {
+		if layoutName == name {
+			return HUDLayout(i)
+		}
+	}
+	return HUDFull
+}
+
+// Next cycles to the following layout, wrapping back to Full
+func (l HUDLayout) Next() HUDLayout {
+	return (l + 1) % HUDLayoutCount
+}
+
+// HUDCorner is the corner of the screen the HUD stats are stacked in
+type HUDCorner int
+
+const (
+	CornerTopRight HUDCorner = iota
+	CornerTopLeft
+	CornerBottomRight
+	CornerBottomLeft
+	HUDCornerCount
+)
+
+var hudCornerNames = [HUDCornerCount]string{"Top Right", "Top Left", "Bottom Right", "Bottom Left"}
+
+func (c HUDCorner) String() string {
+	return hudCornerNames[c]
+}
+
+// ParseHUDCorner returns the corner with the given name, defaulting to Top Right
+func ParseHUDCorner(name string) HUDCorner {
+	for i, cornerName := range hudCornerNames {
+		if cornerName == name {
+			return HUDCorner(i)
+		}
+	}
+	return CornerTopRight
+}
+
+// Next cycles to the following corner, wrapping back to Top Right
+func (c HUDCorner) Next() HUDCorner {
+	return (c + 1) % HUDCornerCount
+}
+
+func (c HUDCorner) left() bool {
+	return c == CornerTopLeft || c == CornerBottomLeft
+}
+
+func (c HUDCorner) bottom() bool {
+	return c == CornerBottomRight || c == CornerBottomLeft
+}
+
+const (
+	hudFontSize = 20
+	hudMargin   = 10
+	hudSpacing  = 5  // Between lines
+	hudLifeSize = 16 // Side of a life icon
+)
+
+// hudLine is one row of the HUD stack: text, optionally with a bar under it, or a row of lives
+type hudLine struct {
+	text  string
+	color rl.Color
+	bar   float32 // Fraction of the text's width to underline, 0 for no bar
+	lives int
+}
+
+func (l hudLine) size(font rl.Font) rl.Vector2 {
+	if l.lives > 0 {
+		return rl.Vector2{X: float32(l.lives)*(hudLifeSize+6) - 6, Y: hudLifeSize}
+	}
+	size := rl.MeasureTextEx(font, l.text, hudFont(hudFontSize), 1)
+	if l.bar > 0 {
+		size.Y += 6
+	}
+	return size
+}
+
+// drawHUD draws a single player game's stats stacked in the HUD corner, followed by the snake's
+// statuses and any extra lines, over a tint while poisoned. The full layout also shows power-ups
+// along the bottom.
+func (g *Game) drawHUD(engine *game.Engine, extra ...hudLine) {
+	state := &engine.State
+	g.drawStatusTint(state)
+	lines := []hudLine{{text: fmt.Sprintf("Score: %d", state.Points), color: g.theme.HUD}}
+	if engine.Config.Mode == game.ModeTimed {
+		lines = append(lines, hudLine{text: fmt.Sprintf("Time left: %.0fs", max(0, game.TimedLength-engine.Duration())), color: g.theme.HUD})
+	} else if left, ok := engine.NextRing(); ok {
+		// The countdown to the arena shrinking turns red as its ring starts to flash
+		color := g.theme.HUD
+		if left <= game.ArenaWarning {
+			color = rl.Red
+		}
+		lines = append(lines, hudLine{text: fmt.Sprintf("Shrinks in: %.0fs", math.Ceil(float64(left))), color: color})
+	} else {
+		lines = append(lines, hudLine{text: fmt.Sprintf("Time: %.1fs", engine.Duration()), color: g.theme.HUD})
+	}
+	// Growth still to come from food eaten shows beside the length
+	length := fmt.Sprintf("Length: %d", len(state.Snake.Segments))
+	if state.Growing > 0 {
+		length += fmt.Sprintf(" (+%d)", state.Growing)
+	}
+	lines = append(lines, hudLine{text: length, color: g.theme.HUD})
+	// The boost meter's bar shows the charge left, and it lights up while boosting
+	boost := hudLine{text: "Boost: ready", color: rl.SkyBlue, bar: max(0.01, state.BoostMeter)}
+	if state.Boosting {
+		boost.text, boost.color = "Boost: x2 points", rl.Orange
+	} else if state.BoostMeter < 1 {
+		boost.text, boost.color = "Boost: charging", rl.Fade(rl.SkyBlue, 0.6)
+	}
+	lines = append(lines, boost)
+	if g.hudLayout == HUDFull {
+		lines = append(lines, hudLine{text: fmt.Sprintf("Speed: x%.2f", engine.Speed()), color: g.theme.HUD})
+		if engine.Config.Rewind {
+			lines = append(lines, hudLine{text: fmt.Sprintf("Rewinds: %d", game.RewindUses-state.Rewinds), color: g.theme.HUD})
+		}
+		// The combo bar empties as the time to keep it going runs out
+		if state.Combo >= 2 {
+			lines = append(lines, hudLine{
+				text:  fmt.Sprintf("Combo %d  x%d", state.Combo, state.ComboMultiplier()),
+				color: rl.Yellow,
+				bar:   float32(state.ComboTicks) / game.ComboWindow,
+			})
+		}
+	}
+	if state.Lives > 0 {
+		lines = append(lines, hudLine{lives: state.Lives})
+	}
+	lines = append(lines, statusLines(state)...)
+	lines = append(lines, extra...)
+	g.drawHUDLines(lines)
+
+	if g.hudLayout == HUDFull {
+		g.drawEffectsHUD(state.Effects)
+	}
+}
+
+// drawHUDLines stacks lines out from the HUD corner, aligned to its side of the screen
+func (g *Game) drawHUDLines(lines []hudLine) {
+	sizes := make([]rl.Vector2, len(lines))
+	height := float32(0)
+	for i, line := range lines {
+		sizes[i] = line.size(g.menu.font)
+		height += sizes[i].Y + hudSpacing
+	}
+
+	y := float32(hudMargin)
+	if g.hudCorner.bottom() {
+		y = float32(g.screenHeight) - hudMargin - height + hudSpacing
+	}
+	for i, line := range lines {
+		x := float32(g.screenWidth) - sizes[i].X - hudMargin
+		if g.hudCorner.left() {
+			x = hudMargin
+		}
+
+		switch {
+		case line.lives > 0:
+			// A snake-head icon per life left
+			for life := range line.lives {
+				position := rl.Vector2{X: x + float32(life)*(hudLifeSize+6), Y: y}
+				rl.DrawRectangleV(position, rl.Vector2{X: hudLifeSize, Y: hudLifeSize}, rl.DarkGreen)
+				rl.DrawRectangleLinesEx(rl.NewRectangle(position.X, position.Y, hudLifeSize, hudLifeSize), 2, rl.Green)
+			}
+		default:
+			rl.DrawTextEx(g.menu.font, line.text, rl.Vector2{X: x, Y: y}, hudFont(hudFontSize), 1, line.color)
+			if line.bar > 0 {
+				rl.DrawRectangleV(
+					rl.Vector2{X: x, Y: y + sizes[i].Y - 4},
+					rl.Vector2{X: sizes[i].X * line.bar, Y: 4},
+					line.color,
+				)
+			}
+		}
+		y += sizes[i].Y + hudSpacing
+	}
+}
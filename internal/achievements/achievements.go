@@ -0,0 +1,144 @@
+package achievements
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const achievementsFile = "achievements.json"
+
+// Run is what happened in the run being played, as far as achievements care
+type Run struct {
+	Food     int     // Food eaten
+	PowerUps int     // Power-ups collected
+	Duration float32 // Seconds survived
+	Length   int     // Snake length
+	Wraps    int     // Times the snake crossed an edge
+	Over     bool    // The run has ended
+}
+
+// Progress is what has been unlocked, and the lifetime totals some achievements count towards
+type Progress struct {
+	Unlocked  map[string]time.Time `json:"unlocked"` // Unlock time by achievement ID
+	FoodEaten int                  `json:"foodEaten"`
+	PowerUps  int                  `json:"powerUps"`
+	Runs      int                  `json:"runs"`
+}
+
+// Achievement is one unlockable goal
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	done        func(p *Progress, run Run) bool
+}
+
+// Registry lists every achievement in the order they are shown
+var Registry = []Achievement{
+	{
+		ID:          "first-bite",
+		Name:        "First Bite",
+		Description: "Eat your first piece of food",
+		done:        func(p *Progress, run Run) bool { return p.FoodEaten+run.Food >= 1 },
+	},
+	{
+		ID:          "hungry",
+		Name:        "Hungry",
+		Description: "Eat 100 food in total",
+		done:        func(p *Progress, run Run) bool { return p.FoodEaten+run.Food >= 100 },
+	},
+	{
+		ID:          "survivor",
+		Name:        "Survivor",
+		Description: "Survive 5 minutes in one run",
+		done:        func(p *Progress, run Run) bool { return run.Duration >= 5*60 },
+	},
+	{
+		ID:          "long",
+		Name:        "Long Snake",
+		Description: "Reach a length of 50",
+		done:        func(p *Progress, run Run) bool { return run.Length >= 50 },
+	},
+	{
+		ID:          "inside",
+		Name:        "Inside the Lines",
+		Description: "Eat 20 food in one run without wrapping",
+		done:        func(p *Progress, run Run) bool { return run.Food >= 20 && run.Wraps == 0 },
+	},
+	{
+		ID:          "powered",
+		Name:        "Powered Up",
+		Description: "Collect 25 power-ups in total",
+		done:        func(p *Progress, run Run) bool { return p.PowerUps+run.PowerUps >= 25 },
+	},
+	{
+		ID:          "regular",
+		Name:        "Regular",
+		Description: "Finish 50 runs",
+		done: func(p *Progress, run Run) bool {
+			if run.Over {
+				return p.Runs+1 >= 50
+			}
+			return p.Runs >= 50
+		},
+	},
+}
+
+// Load reads the progress saved in dir, starting fresh if there is none
+func Load(dir string) (Progress, error) {
+	progress := Progress{Unlocked: make(map[string]time.Time)}
+	data, err := os.ReadFile(filepath.Join(dir, achievementsFile))
+	if os.IsNotExist(err) {
+		return progress, nil
+	} else if err != nil {
+		return progress, err
+	}
+
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return Progress{Unlocked: make(map[string]time.Time)}, err
+	}
+	if progress.Unlocked == nil {
+		progress.Unlocked = make(map[string]time.Time)
+	}
+	return progress, nil
+}
+
+// Save writes the progress to dir
+func Save(dir string, progress Progress) error {
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, achievementsFile), data, 0644)
+}
+
+// IsUnlocked reports whether the achievement with the given ID has been unlocked
+func (p *Progress) IsUnlocked(id string) bool {
+	_, ok := p.Unlocked[id]
+	return ok
+}
+
+// Check unlocks every achievement the run in progress has earned, returning the new ones
+func (p *Progress) Check(run Run) []Achievement {
+	var unlocked []Achievement
+	for _, achievement := range Registry {
+		if p.IsUnlocked(achievement.ID) || !achievement.done(p, run) {
+			continue
+		}
+		p.Unlocked[achievement.ID] = time.Now()
+		unlocked = append(unlocked, achievement)
+	}
+	return unlocked
+}
+
+// Finish adds a run to the lifetime totals after a final Check. Runs quit early
+// still count their food and power-ups, but not as a finished run.
+func (p *Progress) Finish(run Run) {
+	p.FoodEaten += run.Food
+	p.PowerUps += run.PowerUps
+	if run.Over {
+		p.Runs++
+	}
+}
@@ -0,0 +1,204 @@
+// Package ai steers a snake. It heads for the nearest food by breadth-first search around walls,
+// bombs and every snake on the board, and falls back to whichever move leaves it the most room.
+package ai
+
+import "github.com/ztkent/snake/internal/game"
+
+var directions = []game.Direction{game.Up, game.Down, game.Left, game.Right}
+
+// blastWarning is how many seconds before a bomb explodes the AI starts avoiding its blast
+const blastWarning = 1.5
+
+// Skill sets how far ahead the AI looks for food and whether it avoids trapping itself
+type Skill int
+
+const (
+	SkillEasy Skill = iota
+	SkillNormal
+	SkillHard
+	SkillCount
+)
+
+// skillSight is how many cells away food can be for the AI to go for it, 0 for anywhere
+var skillSight = [SkillCount]int{
+	SkillEasy:   8,
+	SkillNormal: 20,
+	SkillHard:   0,
+}
+
+func (s Skill) String() string {
+	switch s {
+	case SkillEasy:
+		return "Easy"
+	case SkillHard:
+		return "Hard"
+	default:
+		return "Normal"
+	}
+}
+
+// Next returns the following skill, wrapping back to SkillEasy
+func (s Skill) Next() Skill {
+	return (s + 1) % SkillCount
+}
+
+// ParseSkill returns the skill with the given name, or SkillNormal if there is none
+func ParseSkill(name string) Skill {
+	for s := range SkillCount {
+		if s.String() == name {
+			return s
+		}
+	}
+	return SkillNormal
+}
+
+// NextDirection picks the direction for the player's snake on the engine's next tick
+func NextDirection(e *game.Engine) game.Direction {
+	return Steer(e, e.State.Snake, SkillHard)
+}
+
+// Steer picks the direction for any snake on the board, the player's or a rival's
+func Steer(e *game.Engine, snake game.Snake, skill Skill) game.Direction {
+	state := &e.State
+	blocked := blockedCells(e, snake)
+	head := snake.Head()
+	sight := skillSight[skill]
+
+	// Breadth-first search outward from the head, remembering which first move led to each cell
+	type step struct {
+		cell     game.Point
+		first    game.Direction
+		distance int
+	}
+	food := make(map[game.Point]bool)
+	for _, entity := range state.Entities {
+		if entity.Kind == game.EntityFood {
+			food[entity.Position] = true
+		}
+	}
+	visited := map[game.Point]bool{head: true}
+	queue := make([]step, 0)
+	for _, dir := range safeMoves(e, snake, blocked) {
+		cell := move(e, head, dir)
+		visited[cell] = true
+		queue = append(queue, step{cell: cell, first: dir, distance: 1})
+	}
+	for i := 0; i < len(queue); i++ {
+		current := queue[i]
+		if sight > 0 && current.distance > sight {
+			break
+		}
+		if food[current.cell] {
+			// Easy snakes take any path, the others skip paths into pockets too small for them
+			if skill == SkillEasy || room(e, move(e, head, current.first), blocked) >= len(snake.Segments) {
+				return current.first
+			}
+			break
+		}
+		for _, dir := range directions {
+			cell := move(e, current.cell, dir)
+			if isBlocked(e, blocked, cell) || visited[cell] {
+				continue
+			}
+			visited[cell] = true
+			queue = append(queue, step{cell: cell, first: current.first, distance: current.distance + 1})
+		}
+	}
+
+	// No safe path to food in sight, stall in the biggest open area
+	best, bestRoom := snake.Direction, -1
+	for _, dir := range safeMoves(e, snake, blocked) {
+		if r := room(e, move(e, head, dir), blocked); r > bestRoom {
+			best, bestRoom = dir, r
+		}
+	}
+	return best
+}
+
+// blockedCells returns the cells the snake must not move into. Tails count, collisions are
+// checked before they move away. Only the player can have a shield to get through bombs.
+func blockedCells(e *game.Engine, snake game.Snake) map[game.Point]bool {
+	state := &e.State
+	blocked := make(map[game.Point]bool)
+	for _, wall := range state.Walls {
+		blocked[wall] = true
+	}
+	isPlayer := len(snake.Segments) > 0 && snake.Head() == state.Snake.Head()
+	if !isPlayer || !state.HasEffect(game.PowerUpShield) {
+		for _, bomb := range state.Entities {
+			if bomb.Kind != game.EntityBomb {
+				continue
+			}
+			blocked[bomb.Position] = true
+
+			// Stay out of the blast of bombs about to go off
+			if bomb.Remaining > 0 && bomb.Remaining < blastWarning {
+				radius := e.Config.BlastRadius
+				for dx := -radius; dx <= radius; dx++ {
+					for dy := -radius; dy <= radius; dy++ {
+						blocked[game.Point{X: bomb.Position.X + dx, Y: bomb.Position.Y + dy}] = true
+					}
+				}
+			}
+		}
+	}
+	for _, segment := range state.Snake.Segments {
+		blocked[segment] = true
+	}
+	for _, rival := range state.Rivals {
+		for _, segment := range rival.Snake.Segments {
+			blocked[segment] = true
+		}
+	}
+	return blocked
+}
+
+// safeMoves returns the directions the snake can turn to without crashing on the next tick
+func safeMoves(e *game.Engine, snake game.Snake, blocked map[game.Point]bool) []game.Direction {
+	moves := make([]game.Direction, 0, len(directions))
+	for _, dir := range directions {
+		if dir.Opposite(snake.Direction) || isBlocked(e, blocked, move(e, snake.Head(), dir)) {
+			continue
+		}
+		moves = append(moves, dir)
+	}
+	return moves
+}
+
+// room counts the open cells reachable from start
+func room(e *game.Engine, start game.Point, blocked map[game.Point]bool) int {
+	visited := map[game.Point]bool{start: true}
+	stack := []game.Point{start}
+	for len(stack) > 0 {
+		cell := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, dir := range directions {
+			next := move(e, cell, dir)
+			if isBlocked(e, blocked, next) || visited[next] {
+				continue
+			}
+			visited[next] = true
+			stack = append(stack, next)
+		}
+	}
+	return len(visited)
+}
+
+func move(e *game.Engine, p game.Point, dir game.Direction) game.Point {
+	return e.Next(p, dir)
+}
+
+// isBlocked reports whether a cell is blocked, off a board with solid edges, or outside the
+// arena once its next ring is about to close
+func isBlocked(e *game.Engine, blocked map[game.Point]bool, cell game.Point) bool {
+	return blocked[cell] || !e.InBounds(cell) || !safeArena(e).Contains(cell)
+}
+
+// safeArena is the arena, less the ring about to close
+func safeArena(e *game.Engine) game.Bounds {
+	ring := e.State.Ring
+	if left, ok := e.NextRing(); ok && left <= game.ArenaWarning {
+		ring++
+	}
+	return e.ArenaBounds(ring)
+}
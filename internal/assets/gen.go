@@ -0,0 +1,27 @@
+//go:build ignore
+
+// gen regenerates assets/manifest.json from the files currently in the assets directory.
+// Run with `go generate ./...` from the repository root.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ztkent/snake/internal/assets"
+)
+
+func main() {
+	dir := filepath.Join("..", "..", assets.AssetsDir)
+	manifest, err := assets.GenerateManifest(dir)
+	if err != nil {
+		fmt.Println("Failed to generate asset manifest:", err)
+		os.Exit(1)
+	}
+	if err := assets.SaveManifest(dir, manifest); err != nil {
+		fmt.Println("Failed to save asset manifest:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote manifest with %d assets\n", len(manifest.Files))
+}
@@ -0,0 +1,84 @@
+package assets
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Manager loads the game's fonts, textures and sounds, and keeps them so they can be freed
+// together with UnloadAll. A file that can't be loaded is reported and stood in for: raylib's
+// default font replaces a font, and a texture or sound comes back not ok, which callers treat
+// as nothing to draw or a silent sound.
+type Manager struct {
+	fonts    []rl.Font
+	textures []rl.Texture2D
+	sounds   []rl.Sound
+	music    []rl.Music
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Font loads a font, falling back to raylib's default font
+func (m *Manager) Font(path string) rl.Font {
+	font := rl.LoadFont(path)
+	// raylib hands back its default font itself when the file is missing
+	if !rl.IsFontValid(font) || font.Texture.ID == rl.GetFontDefault().Texture.ID {
+		fmt.Println("Failed to load font, using the default:", path)
+		return rl.GetFontDefault()
+	}
+	m.fonts = append(m.fonts, font)
+	return font
+}
+
+// Texture loads a texture, reporting whether it loaded
+func (m *Manager) Texture(path string) (rl.Texture2D, bool) {
+	texture := rl.LoadTexture(path)
+	if !rl.IsTextureValid(texture) {
+		fmt.Println("Failed to load texture:", path)
+		return texture, false
+	}
+	m.textures = append(m.textures, texture)
+	return texture, true
+}
+
+// Sound loads a sound effect, reporting whether it loaded
+func (m *Manager) Sound(path string) (rl.Sound, bool) {
+	sound := rl.LoadSound(path)
+	if !rl.IsSoundValid(sound) {
+		fmt.Println("Failed to load sound:", path)
+		return sound, false
+	}
+	m.sounds = append(m.sounds, sound)
+	return sound, true
+}
+
+// Music opens a music stream, reporting whether it loaded
+func (m *Manager) Music(path string) (rl.Music, bool) {
+	music := rl.LoadMusicStream(path)
+	if !rl.IsMusicValid(music) {
+		fmt.Println("Failed to load music:", path)
+		return music, false
+	}
+	m.music = append(m.music, music)
+	return music, true
+}
+
+// UnloadAll frees everything loaded. Sounds and music must go before the audio device is closed.
+func (m *Manager) UnloadAll() {
+	for _, font := range m.fonts {
+		rl.UnloadFont(font)
+	}
+	for _, texture := range m.textures {
+		rl.UnloadTexture(texture)
+	}
+	for _, sound := range m.sounds {
+		rl.UnloadSound(sound)
+	}
+	for _, music := range m.music {
+		rl.UnloadMusicStream(music)
+	}
+	m.fonts, m.textures, m.sounds, m.music = nil, nil, nil, nil
+}
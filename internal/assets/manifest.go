@@ -0,0 +1,146 @@
+package assets
+
+//go:generate go run gen.go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	AssetsDir    = "assets"
+	ManifestFile = "manifest.json"
+)
+
+// Manifest lists the files expected in an asset directory and their hashes
+type Manifest struct {
+	Files []ManifestEntry `json:"files"`
+}
+
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+type ProblemKind int
+
+const (
+	ProblemMissing ProblemKind = iota
+	ProblemModified
+)
+
+// Problem describes an asset that doesn't match its manifest entry
+type Problem struct {
+	Path string
+	Kind ProblemKind
+}
+
+func (p Problem) String() string {
+	switch p.Kind {
+	case ProblemMissing:
+		return "missing: " + p.Path
+	case ProblemModified:
+		return "modified: " + p.Path
+	}
+	return p.Path
+}
+
+// GenerateManifest hashes every file under dir, skipping the manifest itself
+func GenerateManifest(dir string) (Manifest, error) {
+	manifest := Manifest{Files: make([]ManifestEntry, 0)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ManifestFile {
+			return nil
+		}
+		sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, ManifestEntry{Path: rel, SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+	return manifest, nil
+}
+
+// LoadManifest reads the manifest stored in dir
+func LoadManifest(dir string) (Manifest, error) {
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(data, &manifest)
+	return manifest, err
+}
+
+// SaveManifest writes the manifest into dir
+func SaveManifest(dir string, manifest Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0644)
+}
+
+// Verify checks the files in dir against the manifest and returns any that are missing or modified
+func Verify(dir string, manifest Manifest) []Problem {
+	problems := make([]Problem, 0)
+	for _, entry := range manifest.Files {
+		sum, err := hashFile(filepath.Join(dir, filepath.FromSlash(entry.Path)))
+		if err != nil {
+			problems = append(problems, Problem{Path: entry.Path, Kind: ProblemMissing})
+			continue
+		}
+		if sum != entry.SHA256 {
+			problems = append(problems, Problem{Path: entry.Path, Kind: ProblemModified})
+		}
+	}
+	return problems
+}
+
+// VerifyDir loads the manifest stored in dir and verifies the directory against it
+func VerifyDir(dir string) ([]Problem, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Verify(dir, manifest), nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
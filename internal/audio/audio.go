@@ -4,16 +4,21 @@ import (
 	"fmt"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/assets"
 )
 
 type AudioManager struct {
 	MenuMusic    Music
 	GameMusic    Music
-	GameOverSFX  Sound
-	CollectSFX   Sound
-	Volume       float32
+	MasterVolume float32 // 0-1, scales everything
+	MusicVolume  float32 // 0-1, scales the music streams
+	SfxVolume    float32 // 0-1, scales the sound effects
+	MusicEnabled bool    // Music plays, sound effects play either way
+	Muted        bool    // Silences everything without touching the volumes
 	CurrentMusic *Music
 	IsPlaying    bool // Add playing status
+
+	sounds [EventCount][]Sound // Samples for each sound effect event
 }
 
 type Music struct {
@@ -24,67 +29,36 @@ type Music struct {
 type Sound struct {
 	sound  rl.Sound
 	loaded bool
+	volume float32 // Level relative to the other effects, before SfxVolume
 }
 
 func NewAudioManager() *AudioManager {
 	rl.InitAudioDevice()
 	return &AudioManager{
-		Volume: 1.0,
+		MasterVolume: 1.0,
+		MusicVolume:  1.0,
+		SfxVolume:    1.0,
+		MusicEnabled: true,
 	}
 }
 
-func (am *AudioManager) LoadResources() {
-	// Load menu music
-	menuStream := rl.LoadMusicStream("assets/mainmenu.mp3")
-	if !rl.IsMusicValid(menuStream) {
-		fmt.Println("Failed to load menu music")
-		am.MenuMusic = Music{stream: menuStream, loaded: false}
-	} else {
-		fmt.Println("Menu music loaded successfully")
-		am.MenuMusic = Music{stream: menuStream, loaded: true}
-
-	}
-
-	// Load game music
-	gameStream := rl.LoadMusicStream("assets/gamemusic.mp3")
-	if !rl.IsMusicValid(gameStream) {
-		fmt.Println("Failed to load game music")
-		am.GameMusic = Music{stream: gameStream, loaded: false}
-	} else {
-		fmt.Println("Game music loaded successfully")
-		am.GameMusic = Music{stream: gameStream, loaded: true}
-	}
-
-	// Load sound effects
-	gameOverSound := rl.LoadSound("assets/gameover.wav")
-	am.GameOverSFX = Sound{sound: gameOverSound, loaded: true}
+// LoadResources loads the music and sound effects through the asset manager, which owns them.
+// Anything missing stays silent.
+func (am *AudioManager) LoadResources(loader *assets.Manager) {
+	am.MenuMusic = loadMusic(loader, "assets/mainmenu.mp3")
+	am.GameMusic = loadMusic(loader, "assets/gamemusic.mp3")
 
-	collectSound := rl.LoadSound("assets/nom.wav")
-	rl.SetSoundVolume(collectSound, am.Volume*0.5)
-	am.CollectSFX = Sound{sound: collectSound, loaded: true}
+	am.loadEvents(loader)
 
 	// Set initial properties
-	rl.SetMusicVolume(gameStream, am.Volume)
-	rl.SetMusicPitch(gameStream, 1.0)
-}
-
-func (am *AudioManager) UnloadResources() {
-	// Unload music
-	if am.MenuMusic.loaded {
-		rl.UnloadMusicStream(am.MenuMusic.stream)
-	}
 	if am.GameMusic.loaded {
-		rl.UnloadMusicStream(am.GameMusic.stream)
-	}
-
-	// Unload sound effects
-	if am.GameOverSFX.loaded {
-		rl.UnloadSound(am.GameOverSFX.sound)
-	}
-	if am.CollectSFX.loaded {
-		rl.UnloadSound(am.CollectSFX.sound)
+		rl.SetMusicVolume(am.GameMusic.stream, am.MusicVolume)
+		rl.SetMusicPitch(am.GameMusic.stream, 1.0)
 	}
+}
 
+// Close shuts the audio device. The asset manager must unload the sounds first.
+func (am *AudioManager) Close() {
 	rl.CloseAudioDevice()
 }
 
@@ -102,12 +76,15 @@ func (am *AudioManager) PlayMusic(music *Music) {
 	}
 
 	am.CurrentMusic = music
+	if !am.MusicEnabled {
+		return
+	}
 	fmt.Printf("Playing new music (loaded: %v)\n", music.loaded)
 
 	if rl.IsMusicValid(music.stream) {
 		rl.SeekMusicStream(music.stream, 0.0)
 		rl.PlayMusicStream(music.stream)
-		rl.SetMusicVolume(music.stream, am.Volume)
+		rl.SetMusicVolume(music.stream, am.MusicVolume)
 		am.IsPlaying = true
 		fmt.Println("Music started successfully")
 	} else {
@@ -129,17 +106,84 @@ func (am *AudioManager) UpdateMusic() {
 	rl.UpdateMusicStream(am.CurrentMusic.stream)
 }
 
-func (am *AudioManager) PlaySound(sound *Sound) {
+func (am *AudioManager) PauseMusic() {
+	if am.CurrentMusic == nil || !am.CurrentMusic.loaded || !am.IsPlaying {
+		return
+	}
+	rl.PauseMusicStream(am.CurrentMusic.stream)
+	am.IsPlaying = false
+}
+
+func (am *AudioManager) ResumeMusic() {
+	if am.CurrentMusic == nil || !am.CurrentMusic.loaded || am.IsPlaying || !am.MusicEnabled {
+		return
+	}
+	rl.ResumeMusicStream(am.CurrentMusic.stream)
+	am.IsPlaying = true
+}
+
+// SetMusicEnabled turns the music on or off, picking up where it left off when turned back on
+func (am *AudioManager) SetMusicEnabled(enabled bool) {
+	if enabled == am.MusicEnabled {
+		return
+	}
+	am.MusicEnabled = enabled
+	if enabled {
+		am.ResumeMusic()
+	} else {
+		am.PauseMusic()
+	}
+}
+
+func loadMusic(loader *assets.Manager, path string) Music {
+	stream, ok := loader.Music(path)
+	return Music{stream: stream, loaded: ok}
+}
+
+// loadSound loads a sound effect at a volume relative to the others
+func loadSound(loader *assets.Manager, path string, volume float32) Sound {
+	sound, ok := loader.Sound(path)
+	return Sound{sound: sound, loaded: ok, volume: volume}
+}
+
+func (am *AudioManager) playSound(sound *Sound) {
 	if sound.loaded {
+		rl.SetSoundVolume(sound.sound, sound.volume*am.SfxVolume)
 		rl.PlaySound(sound.sound)
 	}
 }
 
-func (am *AudioManager) SetVolume(volume float32) {
-	am.Volume = volume / 100.0
-	rl.SetMasterVolume(am.Volume)
+// SetMasterVolume sets the overall volume, from 0 to 100
+func (am *AudioManager) SetMasterVolume(volume float32) {
+	am.MasterVolume = volume / 100.0
+	am.applyMasterVolume()
+}
+
+// SetMuted silences all audio, or brings it back at the volume it had before
+func (am *AudioManager) SetMuted(muted bool) {
+	am.Muted = muted
+	am.applyMasterVolume()
+}
+
+func (am *AudioManager) applyMasterVolume() {
+	if am.Muted {
+		rl.SetMasterVolume(0)
+		return
+	}
+	rl.SetMasterVolume(am.MasterVolume)
+}
+
+// SetMusicVolume sets the music volume, from 0 to 100, under the master volume
+func (am *AudioManager) SetMusicVolume(volume float32) {
+	am.MusicVolume = volume / 100.0
 	// Also update current music volume if playing
 	if am.CurrentMusic != nil && am.CurrentMusic.loaded {
-		rl.SetMusicVolume(am.CurrentMusic.stream, am.Volume)
+		rl.SetMusicVolume(am.CurrentMusic.stream, am.MusicVolume)
 	}
 }
+
+// SetSfxVolume sets the sound effects volume, from 0 to 100, under the master volume.
+// It applies from the next sound played.
+func (am *AudioManager) SetSfxVolume(volume float32) {
+	am.SfxVolume = volume / 100.0
+}
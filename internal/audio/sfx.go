@@ -0,0 +1,108 @@
+package audio
+
+import (
+	"math/rand/v2"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/assets"
+)
+
+// Event is a moment in the game that plays a sound effect
+type Event int
+
+const (
+	EventCollect   Event = iota // Eating food or picking up a power-up
+	EventGolden                 // Eating a golden apple
+	EventExplosion              // A bomb going off
+	EventCrash                  // Running into a wall
+	EventGameOver
+	EventCountdown // Beep for each number of the countdown before play
+	EventGo        // Play starting after the countdown
+	EventTurn
+	EventPowerUp
+	EventFuse      // Tick of a bomb's fuse each second
+	EventHighScore // Jingle for a new high score
+	EventMenuClick
+	EventMenuHover // Moving onto another menu button
+	EventPoison    // Warning that the controls are reversed
+	EventCount
+)
+
+// eventSound is how an event sounds: the files it picks from, how loud it is next to the other
+// effects, and how far its pitch may stray so repeats don't sound identical
+type eventSound struct {
+	files  []string
+	volume float32
+	pitch  float32
+}
+
+// eventSounds lists every event's samples. Only the first file is expected; the others are
+// variations used when they are there.
+var eventSounds = [EventCount]eventSound{
+	// Eating plays so often it is quieter, and varied the most
+	EventCollect:   {files: []string{"assets/nom.wav", "assets/nom2.wav", "assets/nom3.wav"}, volume: 0.5, pitch: 0.08},
+	EventGolden:    {files: []string{"assets/golden.wav"}, volume: 1, pitch: 0.03},
+	EventExplosion: {files: []string{"assets/explosion.wav", "assets/explosion2.wav"}, volume: 1, pitch: 0.05},
+	EventCrash:     {files: []string{"assets/crash.wav"}, volume: 1, pitch: 0.05},
+	EventGameOver:  {files: []string{"assets/gameover.wav"}, volume: 1},
+	EventCountdown: {files: []string{"assets/countdown.wav"}, volume: 1},
+	EventGo:        {files: []string{"assets/go.wav"}, volume: 1},
+	EventTurn:      {files: []string{"assets/turn.wav"}, volume: 0.3, pitch: 0.05},
+	EventPowerUp:   {files: []string{"assets/powerup.wav"}, volume: 1, pitch: 0.03},
+	EventFuse:      {files: []string{"assets/fuse.wav"}, volume: 0.6},
+	EventHighScore: {files: []string{"assets/highscore.wav"}, volume: 1},
+	EventMenuClick: {files: []string{"assets/click.wav"}, volume: 0.7, pitch: 0.03},
+	EventMenuHover: {files: []string{"assets/hover.wav"}, volume: 0.4, pitch: 0.03},
+	EventPoison:    {files: []string{"assets/poison.wav"}, volume: 1},
+}
+
+// loadEvents fills the registry with every event's samples that could be loaded
+func (am *AudioManager) loadEvents(loader *assets.Manager) {
+	for event, sounds := range eventSounds {
+		for i, file := range sounds.files {
+			if i > 0 && !exists(file) {
+				continue
+			}
+			am.register(Event(event), loadSound(loader, file, sounds.volume))
+		}
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// register adds a sample to an event, to be picked from at random when it plays.
+// Samples that didn't load are left out.
+func (am *AudioManager) register(event Event, sound Sound) {
+	if sound.loaded {
+		am.sounds[event] = append(am.sounds[event], sound)
+	}
+}
+
+// Has reports whether an event has any sound to play
+func (am *AudioManager) Has(event Event) bool {
+	return len(am.sounds[event]) > 0
+}
+
+// PlayOr plays the event, or the fallback if the event has no sound
+func (am *AudioManager) PlayOr(event, fallback Event) {
+	if !am.Has(event) {
+		event = fallback
+	}
+	am.Play(event)
+}
+
+// Play plays one of the event's samples at random, nudging its pitch so repeats vary
+func (am *AudioManager) Play(event Event) {
+	samples := am.sounds[event]
+	if len(samples) == 0 {
+		return
+	}
+	sound := samples[rand.IntN(len(samples))]
+	pitch := eventSounds[event].pitch
+	rl.SetSoundPitch(sound.sound, 1+(rand.Float32()*2-1)*pitch)
+	am.playSound(&sound)
+}
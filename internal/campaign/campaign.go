@@ -0,0 +1,108 @@
+package campaign
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+const campaignFile = "campaign.json"
+
+// Goal is what a stage's objective measures
+type Goal int
+
+const (
+	GoalFood    Goal = iota // Eat a number of pieces of food
+	GoalSurvive             // Survive a number of seconds
+	GoalLength              // Grow to a length
+)
+
+// Objective is the goal a stage is won by reaching
+type Objective struct {
+	Goal   Goal
+	Target int
+}
+
+func (o Objective) String() string {
+	switch o.Goal {
+	case GoalSurvive:
+		return fmt.Sprintf("Survive %ds", o.Target)
+	case GoalLength:
+		return fmt.Sprintf("Reach length %d", o.Target)
+	}
+	return fmt.Sprintf("Eat %d food", o.Target)
+}
+
+// Value returns how far the run is towards the objective, in the same units as Target
+func (o Objective) Value(food int, state *game.State) int {
+	switch o.Goal {
+	case GoalSurvive:
+		return int(state.Elapsed)
+	case GoalLength:
+		return len(state.Snake.Segments)
+	}
+	return food
+}
+
+// Met reports whether the run has reached the objective
+func (o Objective) Met(food int, state *game.State) bool {
+	return o.Value(food, state) >= o.Target
+}
+
+// Stage is one step of the campaign: a level layout, the difficulty it is played on and its goal
+type Stage struct {
+	Level      string
+	Difficulty string
+	Objective  Objective
+}
+
+// Stages are played in order, each unlocking the next, with the difficulty rising as they go
+var Stages = []Stage{
+	{Level: "Open", Difficulty: "Easy", Objective: Objective{Goal: GoalFood, Target: 10}},
+	{Level: "Box", Difficulty: "Easy", Objective: Objective{Goal: GoalSurvive, Target: 45}},
+	{Level: "Cross", Difficulty: "Normal", Objective: Objective{Goal: GoalLength, Target: 15}},
+	{Level: "Pillars", Difficulty: "Normal", Objective: Objective{Goal: GoalFood, Target: 20}},
+	{Level: "Tunnels", Difficulty: "Hard", Objective: Objective{Goal: GoalSurvive, Target: 60}},
+	{Level: "Maze", Difficulty: "Hard", Objective: Objective{Goal: GoalLength, Target: 25}},
+}
+
+// Progress is how far through the campaign the player has got
+type Progress struct {
+	Cleared int `json:"cleared"` // Stages cleared, the next one is the furthest playable
+}
+
+// Unlocked reports whether a stage can be played
+func (p *Progress) Unlocked(stage int) bool {
+	return stage <= p.Cleared
+}
+
+// Clear records a stage as won, unlocking the one after it
+func (p *Progress) Clear(stage int) {
+	p.Cleared = max(p.Cleared, min(stage+1, len(Stages)))
+}
+
+// Load reads the saved progress, starting from the first stage if there is none
+func Load() (Progress, error) {
+	progress := Progress{}
+	data, err := os.ReadFile(campaignFile)
+	if os.IsNotExist(err) {
+		return progress, nil
+	} else if err != nil {
+		return progress, err
+	}
+
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return Progress{}, err
+	}
+	return progress, nil
+}
+
+func Save(progress Progress) error {
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(campaignFile, data, 0644)
+}
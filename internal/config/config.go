@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const (
+	appDir       = "snake"
+	settingsFile = "settings.json"
+)
+
+// Settings are the player's choices that persist across sessions
+type Settings struct {
+	Volume       float32          `json:"volume"` // Master volume, 0-100
+	MusicVolume  float32          `json:"musicVolume"`
+	SfxVolume    float32          `json:"sfxVolume"`
+	Music        bool             `json:"music"`
+	Muted        bool             `json:"muted"`
+	Difficulty   string           `json:"difficulty"`
+	Level        string           `json:"level"`
+	Effects      EffectSettings   `json:"effects"`
+	Controls     map[string]int32 `json:"controls,omitempty"` // Key codes by action name
+	Scheme       string           `json:"scheme"`             // Control scheme, absolute or relative turns
+	WindowWidth  int              `json:"windowWidth"`
+	WindowHeight int              `json:"windowHeight"`
+	Fullscreen   bool             `json:"fullscreen"`
+	PlayerName   string           `json:"playerName,omitempty"`
+	HighScores   int              `json:"highScores"`            // Scores kept per difficulty
+	Leaderboard  string           `json:"leaderboard,omitempty"` // Leaderboard server URL, empty for none
+	AISkill      string           `json:"aiSkill"`
+	BoardSize    string           `json:"boardSize"`
+	LivesMode    bool             `json:"livesMode"` // Start games with several lives
+	Mode         string           `json:"mode"`
+	SolidEdges   bool             `json:"solidEdges"`           // Deadly board edges instead of wrapping
+	Growth       int              `json:"growth"`               // Segments grown per food, 1-3
+	ShrinkOnBomb bool             `json:"shrinkOnBomb"`         // Bombs cost segments and points instead of the game
+	Rewind       bool             `json:"rewind"`               // Assist that lets a crash be rewound a few times per game
+	Twist        string           `json:"twist"`                // How Play mirrors or rotates the level each run
+	LANAddress   string           `json:"lanAddress,omitempty"` // Host last joined for a LAN game
+	ScreenShake  float32          `json:"screenShake"`          // 0-1 shake intensity, 0 also turns off hit-stop
+	Skin         string           `json:"skin"`
+	Hat          string           `json:"hat"`
+	Theme        string           `json:"theme"`
+	Stepped      bool             `json:"stepped"` // Classic movement, a cell per tick without gliding
+	HUDLayout    string           `json:"hudLayout"`
+	BoardPattern string           `json:"boardPattern"` // Plain, Grid or Checkered
+	HUDCorner    string           `json:"hudCorner"`
+	Cursor       string           `json:"cursor"`
+	NoParticles  bool             `json:"noParticles"` // No bursts or sparkles on the board
+	NoFlashing   bool             `json:"noFlashing"`  // Steady warnings instead of flashing and blinking ones
+	StaticMenus  bool             `json:"staticMenus"` // A still gradient behind the menus instead of falling sprites
+	GameSpeed    float32          `json:"gameSpeed"`   // 0.5-1.5 scale on the game's speed, scores below 1 are marked
+	UIScale      float32          `json:"uiScale"`     // 0.75-2 scale on text and buttons
+	Speech       bool             `json:"speech"`      // Speak focused menu buttons, score milestones and results
+}
+
+// EffectSettings are the post-processing options
+type EffectSettings struct {
+	Enabled   bool      `json:"enabled"`
+	UseCustom bool      `json:"useCustom"`
+	Intensity []float32 `json:"intensity,omitempty"` // 0-1 per effect, in postfx.Effect order
+}
+
+// Default returns the settings used before anything is saved
+func Default() Settings {
+	return Settings{
+		Volume:       100,
+		MusicVolume:  100,
+		SfxVolume:    100,
+		Music:        true,
+		Difficulty:   "Normal",
+		Scheme:       "Absolute",
+		WindowWidth:  800,
+		WindowHeight: 450,
+		HighScores:   10,
+		AISkill:      "Normal",
+		BoardSize:    "Medium",
+		Mode:         "Endless",
+		Growth:       1,
+		Twist:        "Off",
+		ScreenShake:  1,
+		Skin:         "Classic",
+		Hat:          "None",
+		Theme:        "Classic",
+		HUDLayout:    "Full",
+		BoardPattern: "Plain",
+		HUDCorner:    "Top Right",
+		Cursor:       "System",
+		GameSpeed:    1,
+		UIScale:      1,
+	}
+}
+
+// Path returns where the settings file lives: in dir for a player profile's settings, otherwise
+// in the user config directory or the working directory if there isn't one
+func Path(dir string) string {
+	if dir != "" {
+		return filepath.Join(dir, settingsFile)
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return settingsFile
+	}
+	return filepath.Join(configDir, appDir, settingsFile)
+}
+
+// Load reads the settings file in dir, as for Path. Missing settings, or a missing file, keep
+// their defaults.
+func Load(dir string) (Settings, error) {
+	settings := Default()
+	data, err := os.ReadFile(Path(dir))
+	if os.IsNotExist(err) {
+		return settings, nil
+	} else if err != nil {
+		return settings, err
+	}
+
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return Default(), err
+	}
+	return settings, nil
+}
+
+// Exists reports whether a settings file has been saved in dir, as for Path. Load finds none on
+// the game's first run.
+func Exists(dir string) bool {
+	_, err := os.Stat(Path(dir))
+	return err == nil
+}
+
+// Save writes the settings file in dir, as for Path, creating the directory if needed
+func Save(dir string, settings Settings) error {
+	path := Path(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,258 @@
+// Package cosmetics lists the snake skins, hats and board themes the player can pick in Appearance.
+// They only change how the game looks, never how it plays. Some are locked until an
+// achievement or a lifetime total is reached.
+package cosmetics
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/achievements"
+)
+
+// Requirement is what unlocks a skin, hat or theme. The zero value is always unlocked.
+type Requirement struct {
+	Achievement string // ID of an achievement to unlock first
+	FoodEaten   int    // Lifetime food to eat first
+	Runs        int    // Finished runs first
+}
+
+// Met reports whether the player's progress has reached the requirement
+func (r Requirement) Met(p *achievements.Progress) bool {
+	if r.Achievement != "" && !p.IsUnlocked(r.Achievement) {
+		return false
+	}
+	return p.FoodEaten >= r.FoodEaten && p.Runs >= r.Runs
+}
+
+// String describes what is still needed, for the locked state in Appearance
+func (r Requirement) String() string {
+	switch {
+	case r.Achievement != "":
+		for _, achievement := range achievements.Registry {
+			if achievement.ID == r.Achievement {
+				return "Unlock the " + achievement.Name + " achievement"
+			}
+		}
+		return "Unlock an achievement"
+	case r.FoodEaten > 0:
+		return fmt.Sprintf("Eat %d food in total", r.FoodEaten)
+	case r.Runs > 0:
+		return fmt.Sprintf("Finish %d runs", r.Runs)
+	}
+	return ""
+}
+
+// Style is how a skin draws each segment
+type Style int
+
+const (
+	StyleFlat   Style = iota // Plain squares
+	StyleGlow                // Squares with a soft halo around them
+	StyleSprite              // Cells from a sprite sheet, falling back to flat without it
+)
+
+// Skin is one look for the player's snake
+type Skin struct {
+	Name   string
+	Style  Style
+	Head   rl.Color
+	Body   []rl.Color // Body segments cycle through these, one color for a solid body
+	Glow   rl.Color   // Halo color for StyleGlow
+	Sprite string     // Sprite sheet for StyleSprite: a head cell then a body cell, side by side
+	Unlock Requirement
+}
+
+// BodyColor returns the color of the i-th segment behind the head
+func (s Skin) BodyColor(i int) rl.Color {
+	return s.Body[i%len(s.Body)]
+}
+
+// Theme colors the board and what's on it, and the menus. The colors after Food are optional:
+// those a theme leaves out keep the classic look, and without Head or Body the skin's are used.
+type Theme struct {
+	Name        string
+	Background  rl.Color // Canvas around the board
+	Board       rl.Color
+	Wall        rl.Color
+	Food        rl.Color
+	Grid        rl.Color   // The board's grid lines or checks, when a pattern is picked in Appearance
+	Bomb        rl.Color   // Bombs, which flash white before exploding
+	Head        rl.Color   // The player's snake head, over the skin's
+	Body        []rl.Color // The player's snake body, over the skin's
+	Menu        rl.Color   // Menu screen background
+	Button      rl.Color
+	ButtonHover rl.Color
+	Text        rl.Color // Menu text and button labels
+	HUD         rl.Color // HUD text over the game
+	Unlock      Requirement
+}
+
+// withDefaults fills in the optional colors the theme leaves out
+func (t Theme) withDefaults() Theme {
+	fill := func(color *rl.Color, fallback rl.Color) {
+		if *color == (rl.Color{}) {
+			*color = fallback
+		}
+	}
+	fill(&t.Grid, rl.Color{A: 40})
+	fill(&t.Bomb, rl.Red)
+	fill(&t.Menu, rl.RayWhite)
+	fill(&t.Button, rl.LightGray)
+	fill(&t.ButtonHover, rl.Gray)
+	fill(&t.Text, rl.DarkGray)
+	fill(&t.HUD, rl.White)
+	return t
+}
+
+// Skinned returns the skin with the theme's snake colors over its own
+func (t Theme) Skinned(skin Skin) Skin {
+	if t.Head != (rl.Color{}) {
+		skin.Head = t.Head
+	}
+	if len(t.Body) > 0 {
+		skin.Body = t.Body
+	}
+	return skin
+}
+
+// Skins are the snake skins in Appearance order
+var Skins = []Skin{
+	{Name: "Classic", Head: rl.DarkGreen, Body: []rl.Color{rl.Green}},
+	{
+		Name:   "Neon",
+		Style:  StyleGlow,
+		Head:   rl.Color{R: 255, G: 60, B: 220, A: 255},
+		Body:   []rl.Color{{R: 60, G: 240, B: 255, A: 255}},
+		Glow:   rl.Color{R: 60, G: 240, B: 255, A: 255},
+		Unlock: Requirement{Achievement: "powered"},
+	},
+	{
+		Name: "Striped",
+		Head: rl.Black,
+		Body: []rl.Color{rl.Red, rl.Black, rl.Yellow, rl.Black},
+	},
+	{
+		Name:   "Scales",
+		Style:  StyleSprite,
+		Head:   rl.Color{R: 25, G: 75, B: 35, A: 255},
+		Body:   []rl.Color{{R: 60, G: 140, B: 60, A: 255}},
+		Sprite: "assets/scales.png",
+		Unlock: Requirement{Achievement: "long"},
+	},
+	{
+		Name:   "Gold",
+		Style:  StyleGlow,
+		Head:   rl.Color{R: 255, G: 200, B: 40, A: 255},
+		Body:   []rl.Color{rl.Gold, {R: 230, G: 170, B: 30, A: 255}},
+		Glow:   rl.Color{R: 255, G: 230, B: 120, A: 255},
+		Unlock: Requirement{FoodEaten: 500},
+	},
+}
+
+// Themes are the board themes in Appearance order
+var Themes = []Theme{
+	{
+		Name:       "Classic",
+		Background: rl.DarkGray,
+		Board:      rl.Color{R: 70, G: 70, B: 70, A: 255},
+		Wall:       rl.Gray,
+		Food:       rl.Gold,
+	},
+	{
+		Name:       "Midnight",
+		Background: rl.Color{R: 10, G: 10, B: 30, A: 255},
+		Board:      rl.Color{R: 25, G: 25, B: 60, A: 255},
+		Wall:       rl.Color{R: 80, G: 80, B: 140, A: 255},
+		Food:       rl.Color{R: 250, G: 240, B: 120, A: 255},
+		Unlock:     Requirement{Runs: 25},
+	},
+	{
+		Name:       "Meadow",
+		Background: rl.Color{R: 40, G: 70, B: 40, A: 255},
+		Board:      rl.Color{R: 90, G: 130, B: 70, A: 255},
+		Wall:       rl.Brown,
+		Food:       rl.Red,
+	},
+	{
+		Name:       "Desert",
+		Background: rl.Color{R: 120, G: 90, B: 50, A: 255},
+		Board:      rl.Color{R: 215, G: 180, B: 120, A: 255},
+		Wall:       rl.Color{R: 150, G: 100, B: 60, A: 255},
+		Food:       rl.Maroon,
+		Unlock:     Requirement{Achievement: "survivor"},
+	},
+	{
+		// A colorblind-safe palette, telling the snake, food and bombs apart by more than red and green
+		Name:       "Colorblind",
+		Background: rl.Color{R: 30, G: 30, B: 30, A: 255},
+		Board:      rl.Color{R: 60, G: 60, B: 60, A: 255},
+		Wall:       rl.Color{R: 200, G: 200, B: 200, A: 255},
+		Food:       rl.Color{R: 230, G: 159, B: 0, A: 255},
+		Bomb:       rl.Color{R: 204, G: 121, B: 167, A: 255},
+		Head:       rl.Color{R: 0, G: 114, B: 178, A: 255},
+		Body:       []rl.Color{{R: 86, G: 180, B: 233, A: 255}},
+	},
+}
+
+func init() {
+	for i := range Themes {
+		Themes[i] = Themes[i].withDefaults()
+	}
+}
+
+// FindSkin returns the skin with the given name, defaulting to the first
+func FindSkin(name string) Skin {
+	for _, skin := range Skins {
+		if skin.Name == name {
+			return skin
+		}
+	}
+	return Skins[0]
+}
+
+// HatShape is how a hat is drawn on the snake's head
+type HatShape int
+
+const (
+	HatNone  HatShape = iota // Bare headed
+	HatParty                 // A cone with a bobble on top
+	HatTop                   // A tall hat with a band and brim
+	HatCrown                 // Three points and a jewel
+)
+
+// Hat is worn on the player's snake head. It bobs as the snake moves and flies off when it crashes.
+type Hat struct {
+	Name   string
+	Shape  HatShape
+	Color  rl.Color
+	Unlock Requirement
+}
+
+// Hats are the hats in Appearance order, the first being none
+var Hats = []Hat{
+	{Name: "None"},
+	{Name: "Party Hat", Shape: HatParty, Color: rl.Pink, Unlock: Requirement{Runs: 10}},
+	{Name: "Top Hat", Shape: HatTop, Color: rl.Black, Unlock: Requirement{Achievement: "regular"}},
+	{Name: "Crown", Shape: HatCrown, Color: rl.Gold, Unlock: Requirement{FoodEaten: 1000}},
+}
+
+// FindHat returns the hat with the given name, defaulting to none
+func FindHat(name string) Hat {
+	for _, hat := range Hats {
+		if hat.Name == name {
+			return hat
+		}
+	}
+	return Hats[0]
+}
+
+// FindTheme returns the theme with the given name, defaulting to the first
+func FindTheme(name string) Theme {
+	for _, theme := range Themes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+	return Themes[0]
+}
@@ -0,0 +1,129 @@
+package cosmetics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ThemeDir is the folder theme files are loaded from, one JSON file each
+const ThemeDir = "themes"
+
+// themeFile is a theme as written in its file, each color a hex string like "#1e1e2e" or
+// "#1e1e2e80" with alpha. Only the name and the board colors are needed.
+type themeFile struct {
+	Name        string   `json:"name"`
+	Background  string   `json:"background"`
+	Board       string   `json:"board"`
+	Wall        string   `json:"wall"`
+	Food        string   `json:"food"`
+	Grid        string   `json:"grid"`
+	Bomb        string   `json:"bomb"`
+	Head        string   `json:"snakeHead"`
+	Body        []string `json:"snakeBody"`
+	Menu        string   `json:"menu"`
+	Button      string   `json:"button"`
+	ButtonHover string   `json:"buttonHover"`
+	Text        string   `json:"text"`
+	HUD         string   `json:"hud"`
+}
+
+// LoadThemes adds every theme in ThemeDir to Themes, unlocked. Files that can't be read or aren't
+// a valid theme are skipped, and listed in the error.
+func LoadThemes() error {
+	paths, err := filepath.Glob(filepath.Join(ThemeDir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, path := range paths {
+		theme, err := loadTheme(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		Themes = append(Themes, theme)
+	}
+	return errors.Join(errs...)
+}
+
+// loadTheme reads one theme file, named after the file if it doesn't give a name
+func loadTheme(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	var file themeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Theme{}, err
+	}
+
+	theme := Theme{Name: strings.TrimSpace(file.Name)}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	for _, existing := range Themes {
+		if strings.EqualFold(existing.Name, theme.Name) {
+			return Theme{}, fmt.Errorf("there is already a theme called %s", theme.Name)
+		}
+	}
+
+	colors := []struct {
+		name     string
+		hex      string
+		color    *rl.Color
+		required bool
+	}{
+		{"background", file.Background, &theme.Background, true},
+		{"board", file.Board, &theme.Board, true},
+		{"wall", file.Wall, &theme.Wall, true},
+		{"food", file.Food, &theme.Food, true},
+		{"grid", file.Grid, &theme.Grid, false},
+		{"bomb", file.Bomb, &theme.Bomb, false},
+		{"snakeHead", file.Head, &theme.Head, false},
+		{"menu", file.Menu, &theme.Menu, false},
+		{"button", file.Button, &theme.Button, false},
+		{"buttonHover", file.ButtonHover, &theme.ButtonHover, false},
+		{"text", file.Text, &theme.Text, false},
+		{"hud", file.HUD, &theme.HUD, false},
+	}
+	for _, c := range colors {
+		if c.hex == "" {
+			if c.required {
+				return Theme{}, fmt.Errorf("%s is missing", c.name)
+			}
+			continue
+		}
+		if *c.color, err = parseColor(c.hex); err != nil {
+			return Theme{}, fmt.Errorf("%s: %w", c.name, err)
+		}
+	}
+	for _, hex := range file.Body {
+		color, err := parseColor(hex)
+		if err != nil {
+			return Theme{}, fmt.Errorf("snakeBody: %w", err)
+		}
+		theme.Body = append(theme.Body, color)
+	}
+	return theme.withDefaults(), nil
+}
+
+// parseColor reads a "#rrggbb" or "#rrggbbaa" hex color
+func parseColor(hex string) (rl.Color, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if len(digits) != 8 || err != nil {
+		return rl.Color{}, fmt.Errorf("%q isn't a color like #rrggbb", hex)
+	}
+	return rl.Color{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)}, nil
+}
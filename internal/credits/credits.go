@@ -0,0 +1,34 @@
+package credits
+
+import (
+	"encoding/json"
+	"os"
+)
+
+const creditsFile = "assets/credits.json"
+
+// Credit attributes one asset (or the game itself) to its author and license
+type Credit struct {
+	Asset   string `json:"asset"`
+	Author  string `json:"author"`
+	License string `json:"license"`
+}
+
+// LoadCredits reads the bundled credits file
+func LoadCredits() ([]Credit, error) {
+	return LoadCreditsFile(creditsFile)
+}
+
+// LoadCreditsFile reads a credits file, so content packs can ship their own alongside the bundled one
+func LoadCreditsFile(path string) ([]Credit, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	credits := make([]Credit, 0)
+	if err := json.Unmarshal(data, &credits); err != nil {
+		return nil, err
+	}
+	return credits, nil
+}
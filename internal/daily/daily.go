@@ -0,0 +1,114 @@
+package daily
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/levels"
+)
+
+const (
+	dailyFile  = "daily.json"
+	DateFormat = "2006-01-02"
+)
+
+// difficulties are the difficulty names a challenge can be played on
+var difficulties = []string{"Easy", "Normal", "Hard"}
+
+// Challenge is the game everyone plays on a given day: the same seed, level, difficulty and mode,
+// with the level sometimes mirrored or rotated as the day's twist
+type Challenge struct {
+	Date       string
+	Seed       uint64
+	Level      string
+	Difficulty string
+	Mode       game.Mode
+	Transform  levels.Transform
+}
+
+// For returns the challenge for the day of t, in t's time zone
+func For(t time.Time) Challenge {
+	date := t.Format(DateFormat)
+	hash := fnv.New64a()
+	hash.Write([]byte(date))
+	seed := hash.Sum64()
+
+	// The modifiers come from their own generator so the engine's draws start from the seed
+	rng := rand.New(rand.NewPCG(seed, ^seed))
+	return Challenge{
+		Date:       date,
+		Seed:       seed,
+		Level:      levels.Builtin[rng.IntN(len(levels.Builtin))].Name,
+		Difficulty: difficulties[rng.IntN(len(difficulties))],
+		Mode:       game.Mode(rng.IntN(int(game.ModeCount))),
+		Transform:  levels.Transform(rng.IntN(int(levels.TransformCount))),
+	}
+}
+
+// Result is how a day's attempt went
+type Result struct {
+	Date     string  `json:"date"`
+	Score    int     `json:"score"`
+	Duration float32 `json:"duration"` // Seconds
+	Finished bool    `json:"finished"` // False if the attempt was quit or the game closed
+}
+
+// Record is every daily attempt made on this machine, newest last
+type Record struct {
+	Results []Result `json:"results"`
+}
+
+// Played reports whether the day's one attempt has been used, finished or not
+func (r *Record) Played(date string) bool {
+	_, ok := r.Result(date)
+	return ok
+}
+
+// Result returns the attempt made on a day
+func (r *Record) Result(date string) (Result, bool) {
+	for _, result := range r.Results {
+		if result.Date == date {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// Set records a day's attempt, replacing an earlier one for the same day
+func (r *Record) Set(result Result) {
+	for i := range r.Results {
+		if r.Results[i].Date == result.Date {
+			r.Results[i] = result
+			return
+		}
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Load reads the attempts made so far, starting fresh if there are none
+func Load() (Record, error) {
+	record := Record{Results: make([]Result, 0)}
+	data, err := os.ReadFile(dailyFile)
+	if os.IsNotExist(err) {
+		return record, nil
+	} else if err != nil {
+		return record, err
+	}
+
+	if err := json.Unmarshal(data, &record); err != nil {
+		return Record{Results: make([]Result, 0)}, err
+	}
+	return record, nil
+}
+
+func Save(record Record) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dailyFile, data, 0644)
+}
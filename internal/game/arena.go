@@ -0,0 +1,75 @@
+package game
+
+const (
+	ArenaInterval = 20 // Seconds of play between rings of the arena closing
+	ArenaWarning  = 3  // Seconds before a ring closes that it is shown about to
+	arenaMinSize  = 8  // The arena stops shrinking before its shorter side would be narrower
+)
+
+// InArena reports whether p is inside the arena, the board less the rings closed so far.
+// Outside Arena mode the arena is the whole board.
+func (e *Engine) InArena(p Point) bool {
+	return e.ArenaBounds(e.State.Ring).Contains(p)
+}
+
+// Bounds is a rectangle of cells
+type Bounds struct {
+	Min Point // Top-left cell
+	Max Point // Cell past the bottom-right one
+}
+
+func (b Bounds) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.Y >= b.Min.Y && p.X < b.Max.X && p.Y < b.Max.Y
+}
+
+// ArenaBounds returns the arena with a number of rings closed
+func (e *Engine) ArenaBounds(ring int) Bounds {
+	return Bounds{
+		Min: Point{X: ring, Y: ring},
+		Max: Point{X: e.Config.Width - ring, Y: e.Config.Height - ring},
+	}
+}
+
+// NextRing returns the seconds until the next ring closes, and false outside Arena mode or
+// once the arena is as small as it gets
+func (e *Engine) NextRing() (float32, bool) {
+	maxRing := max(0, (min(e.Config.Width, e.Config.Height)-arenaMinSize)/2)
+	if e.Config.Mode != ModeArena || e.State.Ring >= maxRing {
+		return 0, false
+	}
+	return float32(float32(e.State.Ring+1)*ArenaInterval) - e.State.Elapsed, true
+}
+
+// updateArena closes the next ring when its time comes. Everything on it is cleared, and a snake
+// with its head on it is caught, while the rest of a body there can still crawl out.
+func (e *Engine) updateArena() []Event {
+	if left, ok := e.NextRing(); !ok || left > 0 {
+		return nil
+	}
+	state := &e.State
+	state.Ring++
+	events := []Event{EventArenaShrank}
+
+	e.keepEntities(func(entity Entity) bool {
+		for _, p := range entity.Cells() {
+			if !e.InArena(p) {
+				return false
+			}
+		}
+		return true
+	})
+
+	for i := range state.Rivals {
+		if rival := &state.Rivals[i]; rival.Alive() && !e.InArena(rival.Snake.Head()) {
+			e.killRival(rival)
+			events = append(events, EventRivalDied)
+		}
+	}
+	if !e.InArena(state.Snake.Head()) && state.Invulnerable == 0 {
+		events = append(events, e.crash(CauseWall))
+	}
+	if e.waveEaten() {
+		e.spawn()
+	}
+	return events
+}
@@ -0,0 +1,114 @@
+package game
+
+// ExplosionTime is how long an explosion stays in the state for drawing
+const ExplosionTime = 0.5
+
+const (
+	bombShrinkSegments = 5 // Segments a bomb takes under the ShrinkOnBomb rule
+	bombPenalty        = 5 // Points a bomb takes under the ShrinkOnBomb rule
+)
+
+// Explosion is a bomb that went off, kept briefly so it can be drawn
+type Explosion struct {
+	Position  Point   `json:"position"`
+	Radius    int     `json:"radius"`    // Cells from the center caught in the blast
+	Remaining float32 `json:"remaining"` // Seconds left to draw it
+}
+
+// inBlast reports whether p is within radius cells of center, diagonals included
+func inBlast(p, center Point, radius int) bool {
+	return abs(p.X-center.X) <= radius && abs(p.Y-center.Y) <= radius
+}
+
+// updateExplosions fades the explosions drawn by one tick
+func (s *State) updateExplosions(interval float32) {
+	explosions := s.Explosions[:0]
+	for _, explosion := range s.Explosions {
+		explosion.Remaining -= interval
+		if explosion.Remaining > 0 {
+			explosions = append(explosions, explosion)
+		}
+	}
+	s.Explosions = explosions
+}
+
+// hitBomb handles the snake running into a bomb. A shield absorbs it, destroying the bomb, as does
+// losing segments under ShrinkOnBomb, and an invulnerable snake destroys it unharmed.
+func (e *Engine) hitBomb(bomb Entity) ([]Event, bool) {
+	state := &e.State
+	switch {
+	case state.Invulnerable > 0:
+		e.dropEntity(bomb.Position)
+		return nil, true
+	case state.HasEffect(PowerUpShield):
+		state.removeEffect(PowerUpShield)
+		e.dropEntity(bomb.Position)
+		return []Event{EventShieldUsed}, true
+	}
+	event := e.hitByBomb(CauseBomb)
+	if event != EventShrunk {
+		return []Event{event}, false
+	}
+	e.dropEntity(bomb.Position)
+	return []Event{event}, true
+}
+
+// explode sets off a bomb whose fuse ran out. The blast destroys what is fragile around it and
+// kills any snake with its head inside, though a shield absorbs it for the player or a co-op partner, an
+// invulnerable player is unharmed, and under ShrinkOnBomb the player loses segments instead.
+func (e *Engine) explode(bomb Entity) []Event {
+	state := &e.State
+	radius := e.Config.BlastRadius
+	state.Explosions = append(state.Explosions, Explosion{Position: bomb.Position, Radius: radius, Remaining: ExplosionTime})
+	events := []Event{EventExploded}
+
+	e.keepEntities(func(entity Entity) bool {
+		return !entityBehaviors[entity.Kind].fragile || !inBlast(entity.Position, bomb.Position, radius)
+	})
+
+	for i := range state.Rivals {
+		rival := &state.Rivals[i]
+		if !rival.Alive() || !inBlast(rival.Snake.Head(), bomb.Position, radius) {
+			continue
+		}
+		if e.Config.Coop && state.HasEffect(PowerUpShield) {
+			state.removeEffect(PowerUpShield)
+			events = append(events, EventShieldUsed)
+			continue
+		}
+		events = append(events, e.killRival(rival)...)
+	}
+
+	if !state.Over && !state.Down && state.Invulnerable == 0 && inBlast(state.Snake.Head(), bomb.Position, radius) {
+		if state.HasEffect(PowerUpShield) {
+			state.removeEffect(PowerUpShield)
+			events = append(events, EventShieldUsed)
+		} else {
+			events = append(events, e.hitByBomb(CauseBlast))
+		}
+	}
+	return events
+}
+
+// hitByBomb handles the snake running into a bomb or caught in a blast: a crash, or under the
+// ShrinkOnBomb rule the loss of tail segments and points, unless too few segments would be left
+func (e *Engine) hitByBomb(cause Cause) Event {
+	state := &e.State
+	keep := len(state.Snake.Segments) - bombShrinkSegments
+	if !e.Config.ShrinkOnBomb || keep < minSnakeLength {
+		return e.crash(cause)
+	}
+	for _, segment := range state.Snake.Segments[keep:] {
+		e.grid.Set(segment, CellEmpty)
+	}
+	state.Snake.Segments = state.Snake.Segments[:keep]
+	state.Points = max(0, state.Points-bombPenalty)
+	return EventShrunk
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,26 @@
+package game
+
+const (
+	boostFactor   = 2    // Tick rate multiplier while boosting
+	boostDrain    = 0.3  // Share of the meter a second of boosting uses up
+	boostRecharge = 0.15 // Share of the meter refilled each second without boosting
+	boostRestart  = 0.2  // Charge needed to start boosting again once the meter has run dry
+)
+
+// Boost sets whether the player is holding the boost key. The snake moves twice as fast and
+// food scores double while the meter lasts; an empty meter needs some charge back first.
+func (e *Engine) Boost(held bool) {
+	state := &e.State
+	state.Boosting = held && (state.Boosting && state.BoostMeter > 0 || state.BoostMeter >= boostRestart)
+}
+
+// updateBoost drains the meter for a tick of boosting, stopping the boost when it empties,
+// and refills it otherwise
+func (s *State) updateBoost(interval float32) {
+	if s.Boosting {
+		s.BoostMeter = max(0, s.BoostMeter-float32(boostDrain*interval))
+		s.Boosting = s.BoostMeter > 0
+		return
+	}
+	s.BoostMeter = min(1, s.BoostMeter+float32(boostRecharge*interval))
+}
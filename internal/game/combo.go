@@ -0,0 +1,30 @@
+package game
+
+const (
+	ComboWindow        = 40 // Ticks after a pickup in which the next one keeps the combo going
+	comboStep          = 3  // Pickups in a row per step up in the multiplier
+	maxComboMultiplier = 4
+)
+
+// ComboMultiplier returns what food points are multiplied by for the current combo
+func (s *State) ComboMultiplier() int {
+	return min(1+s.Combo/comboStep, maxComboMultiplier)
+}
+
+// updateCombo runs the combo timer down by one tick, dropping the combo when it runs out
+func (s *State) updateCombo() {
+	if s.ComboTicks == 0 {
+		return
+	}
+	s.ComboTicks--
+	if s.ComboTicks == 0 {
+		s.Combo = 0
+	}
+}
+
+// extendCombo counts a pickup towards the combo and restarts its timer
+func (s *State) extendCombo() {
+	s.Combo++
+	s.MaxCombo = max(s.MaxCombo, s.Combo)
+	s.ComboTicks = ComboWindow
+}
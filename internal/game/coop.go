@@ -0,0 +1,110 @@
+package game
+
+// coopBombInterval is how many seconds of co-op play add another bomb to every wave
+const coopBombInterval = 30
+
+// In co-op the first rival is the player's partner rather than a rival. The two score as a team,
+// power-ups either picks up work for both, and a snake that crashes goes down rather than ending
+// the game: a heart appears, and the partner still alive eats it to bring the other back. The
+// game is over once both are down.
+
+// partner returns the co-op partner, or nil outside co-op
+func (e *Engine) partner() *Rival {
+	if !e.Config.Coop || len(e.State.Rivals) == 0 {
+		return nil
+	}
+	return &e.State.Rivals[0]
+}
+
+// TeamPoints returns the points the player and every rival scored between them
+func (s *State) TeamPoints() int {
+	points := s.Points
+	for _, rival := range s.Rivals {
+		points += rival.Points
+	}
+	return points
+}
+
+// down takes the crashed player off the board to wait for a heart, reporting whether it did. It
+// only does in co-op, while the partner is alive to fetch one.
+func (e *Engine) down(cause Cause) bool {
+	partner := e.partner()
+	if partner == nil || !partner.Alive() {
+		return false
+	}
+	state := &e.State
+	for _, segment := range state.Snake.Segments {
+		e.grid.Set(segment, CellEmpty)
+	}
+	state.Down = true
+	state.Cause = cause
+	state.Queued = nil
+	return true
+}
+
+// revivePlayer brings a downed player back for the partner that ate a heart. With no room to
+// put the snake the heart is wasted, and another spawns.
+func (e *Engine) revivePlayer() {
+	state := &e.State
+	if state.Down && e.placeSnake() {
+		state.Down = false
+		state.Cause = CauseNone
+	}
+}
+
+// revivePartner brings the crashed partner back for the player that ate a heart
+func (e *Engine) revivePartner() []Event {
+	partner := e.partner()
+	if partner == nil || partner.Alive() {
+		return nil
+	}
+	e.placeRival(partner)
+	if !partner.Alive() {
+		return nil
+	}
+	return []Event{EventRevived}
+}
+
+// spawnHeart puts a heart on the board while either co-op snake is down. There is one at a time.
+func (e *Engine) spawnHeart() {
+	partner := e.partner()
+	if partner == nil || (partner.Alive() && !e.State.Down) || e.State.Count(EntityHeart) > 0 {
+		return
+	}
+	if p, ok := e.freePoint(); ok {
+		e.place(Entity{Kind: EntityHeart, Position: p})
+	}
+}
+
+// partnerPickUp shares a power-up the partner ran over with the team: timed effects already
+// cover both snakes, and Shrink trims the partner
+func (e *Engine) partnerPickUp(partner *Rival, kind PowerUpKind) {
+	if kind != PowerUpShrink {
+		e.applyPowerUp(kind)
+		return
+	}
+	keep := max(minSnakeLength, len(partner.Snake.Segments)-shrinkSegments)
+	for _, segment := range partner.Snake.Segments[keep:] {
+		e.grid.Set(segment, CellEmpty)
+	}
+	partner.Snake.Segments = partner.Snake.Segments[:keep]
+}
+
+// rivalHitBomb kills a rival that runs into a bomb, unless it is a co-op partner and the team's
+// shield takes the hit
+func (e *Engine) rivalHitBomb(_ *Rival, bomb Entity) rivalMove {
+	if !e.Config.Coop || !e.State.HasEffect(PowerUpShield) {
+		return rivalDies
+	}
+	e.State.removeEffect(PowerUpShield)
+	e.dropEntity(bomb.Position)
+	return rivalPasses
+}
+
+// coopBombs is how many bombs co-op adds to each wave for the time the team has survived
+func (e *Engine) coopBombs() int {
+	if e.Config.Mode == ModeZen {
+		return 0
+	}
+	return int(e.Duration() / coopBombInterval)
+}
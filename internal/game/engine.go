@@ -0,0 +1,538 @@
+// Package game holds the snake rules: movement, wrapping, collisions and spawning.
+// It has no rendering or input dependencies, the caller feeds it directions and
+// elapsed time and draws whatever State it ends up in.
+//
+// Ticks are deterministic: the same config and seed, with the same turns on the same ticks,
+// play out the same on any machine. Positions are whole cells, spawns come only from the
+// seeded generator, and float timer sums are rounded to float32 as they go so no compiler can
+// fuse them differently. Only how many ticks Update runs depends on the frame times it is fed;
+// lockstep play calls Tick directly. Hash fingerprints a game to compare copies of it, and
+// Snapshot and Restore roll one back.
+package game
+
+import "math/rand/v2"
+
+// maxSpawnAttempts bounds the random search for a free cell, so a full board can't hang a tick
+const maxSpawnAttempts = 1000
+
+// inputQueueSize is how many turns can wait for the next ticks at once
+const inputQueueSize = 2
+
+// Point is a cell on the board
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Direction is a unit step on the board
+type Direction struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+var (
+	Up    = Direction{X: 0, Y: -1}
+	Down  = Direction{X: 0, Y: 1}
+	Left  = Direction{X: -1, Y: 0}
+	Right = Direction{X: 1, Y: 0}
+)
+
+// Opposite reports whether d points the other way to other
+func (d Direction) Opposite(other Direction) bool {
+	return d.X == -other.X && d.Y == -other.Y
+}
+
+// TurnLeft returns the direction a quarter turn anticlockwise of d, as seen on screen
+func (d Direction) TurnLeft() Direction {
+	return Direction{X: d.Y, Y: -d.X}
+}
+
+// TurnRight returns the direction a quarter turn clockwise of d, as seen on screen
+func (d Direction) TurnRight() Direction {
+	return Direction{X: -d.Y, Y: d.X}
+}
+
+type Snake struct {
+	Segments  []Point   `json:"segments"` // Head first
+	Direction Direction `json:"direction"`
+}
+
+// Head returns the snake's first segment
+func (s Snake) Head() Point {
+	return s.Segments[0]
+}
+
+// Config sets the board size and the rules for a game
+type Config struct {
+	Width           int      // Board width in cells
+	Height          int      // Board height in cells
+	TickRate        float32  // Simulation ticks per second at the start
+	SpeedStep       float32  // Ticks per second added per point scored
+	MaxTickRate     float32  // Cap for the speed ramp
+	MaxFood         int      // Most food pieces spawned at once
+	FoodInterval    float32  // Seconds of play per extra food piece
+	BombDivisor     int      // One bomb per this many food pieces, once there is more than one. 0 leaves bombs to the caller
+	ScoreMultiplier int      // Points per food eaten
+	BombFuse        float32  // Seconds before a spawned bomb explodes, 0 for bombs that stay until the next wave
+	BlastRadius     int      // Cells around an exploding bomb that are caught in the blast
+	Walls           []Point  // Static wall cells from the level layout
+	Portals         []Portal // Lasting portal pairs from the level layout
+	PowerUpChance   float32  // Chance per tick of a power-up spawning while none is on the board
+	GoldenChance    float32  // Chance per tick of a golden apple spawning while none is on the board
+	PoisonChance    float32  // Chance per tick of a poison apple spawning while none is on the board
+	PortalChance    float32  // Chance per tick in Endless of a portal pair opening while none is open
+	Lives           int      // Crashes the snake respawns from before the game is over, counting the last. 0 for one life
+	SolidEdges      bool     // The board edges are deadly walls instead of wrapping round
+	Growth          int      // Segments the snake grows per food eaten, 1 when unset
+	ShrinkOnBomb    bool     // Bombs cost segments and points, only crashing a snake too short to lose them
+	Rewind          bool     // Keep the last RewindSeconds of states, so a crash can be rewound
+	Coop            bool     // The first rival is a partner, see coop.go
+	Mode            Mode
+}
+
+// State is everything needed to draw or resume a game
+type State struct {
+	Snake        Snake       `json:"snake"`
+	Queued       []Direction `json:"queued,omitempty"` // Turns waiting for the next ticks, oldest first
+	Entities     []Entity    `json:"entities"`         // Food, bombs, power-ups and portals
+	Walls        []Point     `json:"walls"`
+	Effects      []Effect    `json:"effects"`            // Active power-up effects
+	Statuses     []Status    `json:"statuses,omitempty"` // Conditions from food eaten, such as poison
+	Points       int         `json:"points"`
+	Eaten        int         `json:"eaten"`             // Food eaten, golden apples included
+	Growing      int         `json:"growing,omitempty"` // Segments still to grow, one per tick
+	Ticks        int         `json:"ticks"`
+	Elapsed      float32     `json:"elapsed"`        // Seconds of play, summed per tick since the tick rate varies
+	Wraps        int         `json:"wraps"`          // Times the snake went off one edge and came back on the other
+	Ring         int         `json:"ring,omitempty"` // Rings of the arena closed, in Arena mode
+	Combo        int         `json:"combo"`          // Food eaten in quick succession
+	ComboTicks   int         `json:"comboTicks"`     // Ticks left to eat again before the combo drops
+	MaxCombo     int         `json:"maxCombo"`
+	Lives        int         `json:"lives,omitempty"`        // Lives left, the current one included. 0 without lives
+	Invulnerable float32     `json:"invulnerable,omitempty"` // Seconds left that the snake can't die, after respawning
+	Rewinds      int         `json:"rewinds,omitempty"`      // Crashes rewound so far
+	Boosting     bool        `json:"boosting,omitempty"`     // Boost held and the meter not empty
+	BoostMeter   float32     `json:"boostMeter"`             // Boost charge left, from 0 to 1
+	Rivals       []Rival     `json:"rivals,omitempty"`
+	Explosions   []Explosion `json:"explosions,omitempty"` // Recent explosions, for drawing
+	Down         bool        `json:"down,omitempty"`       // In co-op, the snake crashed and waits for its partner to revive it
+	Over         bool        `json:"over"`
+	Cause        Cause       `json:"cause,omitempty"` // What the snake crashed into to end the game
+}
+
+// Event is something that happened during a tick that the caller may want to react to
+type Event int
+
+const (
+	EventAte Event = iota
+	EventDied
+	EventPowerUp
+	EventShieldUsed
+	EventRivalAte
+	EventRivalDied
+	EventExploded
+	EventAteGolden
+	EventLifeLost    // The snake crashed and respawned
+	EventTimeUp      // A Timed game ran out of time
+	EventTurned      // The snake took a queued turn
+	EventFuseTick    // A bomb's fuse burned past another whole second
+	EventShrunk      // A bomb took segments and points instead of ending the game
+	EventPoisoned    // The snake ate poison, reversing its controls. EventAte is raised with it.
+	EventArenaShrank // Another ring of the arena closed
+	EventDowned      // In co-op, the snake crashed and is off the board until its partner revives it
+	EventRevived     // In co-op, a heart brought a crashed snake back
+)
+
+// Engine advances a State at a tick rate that ramps up with the score
+type Engine struct {
+	Config      Config
+	State       State
+	rng         *rand.Rand
+	source      *rand.PCG  // Behind rng, kept to save and restore where the spawns are up to
+	accumulator float32    // Seconds of elapsed time not yet spent on ticks
+	grid        Grid       // What occupies each cell, kept in step with State
+	previous    []Point    // Snake segments before the last tick, for interpolated drawing
+	history     []Snapshot // The game before each of the last ticks, oldest first, for rewinding
+	mods        []Mod
+	seed        uint64
+}
+
+// NewEngine starts a game with a two segment snake in the middle of the board heading right
+func NewEngine(config Config, seed uint64) *Engine {
+	center := Point{X: config.Width / 2, Y: config.Height / 2}
+	e := &Engine{
+		Config: config,
+		State: State{
+			Snake: Snake{
+				Segments:  []Point{center, {X: center.X - 1, Y: center.Y}},
+				Direction: Right,
+			},
+			Walls:      config.Walls,
+			Lives:      config.Lives,
+			BoostMeter: 1,
+		},
+		seed: seed,
+	}
+	e.source = rand.NewPCG(seed, seed)
+	e.rng = rand.New(e.source)
+	for _, portal := range config.Portals {
+		e.State.Entities = append(e.State.Entities, Entity{Kind: EntityPortal, Position: portal.A, Link: portal.B})
+	}
+	e.index()
+	e.spawn()
+	return e
+}
+
+// Resume rebuilds a game saved part way through from its config, state, seed and Random, so
+// it carries on exactly as it would have
+func Resume(config Config, state State, seed uint64, random []byte) (*Engine, error) {
+	e := &Engine{Config: config, seed: seed, source: rand.NewPCG(seed, seed)}
+	if err := e.source.UnmarshalBinary(random); err != nil {
+		return nil, err
+	}
+	e.rng = rand.New(e.source)
+	e.Load(state)
+	return e, nil
+}
+
+// Random returns the state of the spawn generator, for saving a game with its state
+func (e *Engine) Random() ([]byte, error) {
+	return e.source.MarshalBinary()
+}
+
+// Load replaces the current state, such as one restored from a save. State changed
+// from outside the engine must go through Load so the grid is rebuilt.
+func (e *Engine) Load(state State) {
+	e.State = state
+	e.accumulator = 0
+	e.previous = nil
+	e.history = nil
+	e.index()
+}
+
+// At returns what occupies p. Off the board is a wall, for solid edges, as are the rings closed
+// in Arena mode.
+func (e *Engine) At(p Point) Cell {
+	if !e.InBounds(p) || !e.InArena(p) {
+		return CellWall
+	}
+	return e.grid.At(p)
+}
+
+// IsWall reports whether p is a wall cell
+func (e *Engine) IsWall(p Point) bool {
+	return e.At(p) == CellWall
+}
+
+// Input queues a turn for the snake, taken one per tick so two quick turns between ticks both
+// happen. Turns are checked against the one queued before them: repeats and reversals onto
+// the snake are ignored, as are turns past a full queue. While poisoned, the turn is inverted.
+func (e *Engine) Input(dir Direction) {
+	if e.State.HasStatus(StatusReversed) {
+		dir = reverse(dir)
+	}
+	e.queue(dir)
+}
+
+// queue adds a turn to the queue, if it is a turn from the one queued before it
+func (e *Engine) queue(dir Direction) {
+	queue := e.State.Queued
+	last := e.Heading()
+	if len(queue) == inputQueueSize || dir == last || dir.Opposite(last) {
+		return
+	}
+	e.State.Queued = append(queue, dir)
+}
+
+// Heading is the direction the snake will be going once its queued turns are taken
+func (e *Engine) Heading() Direction {
+	if queue := e.State.Queued; len(queue) > 0 {
+		return queue[len(queue)-1]
+	}
+	return e.State.Snake.Direction
+}
+
+// Steer sets the direction for the next tick outright, dropping any queued turns. It suits
+// players that decide afresh every frame, such as the AI, and isn't inverted by poison.
+func (e *Engine) Steer(dir Direction) {
+	e.State.Queued = e.State.Queued[:0]
+	e.queue(dir)
+}
+
+// Update adds dt seconds to the accumulator and runs every tick it now holds, a fixed timestep
+// independent of the frame rate. It returns the events the ticks raised.
+func (e *Engine) Update(dt float32) []Event {
+	if e.State.Over {
+		return nil
+	}
+
+	var events []Event
+	e.accumulator += dt
+	for !e.State.Over {
+		// The interval shrinks as the score ramps the speed up
+		interval := 1 / e.TickRate()
+		if e.accumulator < interval {
+			break
+		}
+		e.accumulator -= interval
+		events = append(events, e.Tick()...)
+	}
+	return events
+}
+
+// Tick advances the game by one step
+func (e *Engine) Tick() []Event {
+	state := &e.State
+	interval := 1 / e.TickRate()
+	e.recordHistory()
+	e.previous = append(e.previous[:0], state.Snake.Segments...)
+	state.Ticks++
+	state.Elapsed += interval
+	if e.Config.Mode == ModeTimed && state.Elapsed >= TimedLength {
+		state.Over = true
+		return []Event{EventTimeUp}
+	}
+	e.modTick()
+	state.updateCombo()
+	state.Invulnerable = max(0, state.Invulnerable-interval)
+	state.updateStatuses(interval)
+	state.updateBoost(interval)
+	state.updateEffects(interval)
+	state.updateExplosions(interval)
+	// Entities run down and spawn before the arena closes, and the ones that ran out, such as
+	// bombs going off, finish once it has
+	expired, events := e.countDown(interval)
+	for _, spawn := range spawners {
+		spawn(e)
+	}
+	events = append(events, e.updateArena()...)
+	events = append(events, e.expire(expired)...)
+	if state.Over {
+		return events
+	}
+	// A downed snake sits out, leaving the board to its partner
+	if state.Down {
+		events = append(events, e.tickRivals(interval)...)
+		if e.waveEaten() {
+			e.spawn()
+		}
+		return events
+	}
+	if len(state.Queued) > 0 {
+		if state.Queued[0] != state.Snake.Direction {
+			events = append(events, EventTurned)
+		}
+		state.Snake.Direction = state.Queued[0]
+		state.Queued = state.Queued[1:]
+	}
+	next := Point{
+		X: state.Snake.Head().X + state.Snake.Direction.X,
+		Y: state.Snake.Head().Y + state.Snake.Direction.Y,
+	}
+	stepped := e.Step(state.Snake.Head(), state.Snake.Direction)
+	head := e.Next(state.Snake.Head(), state.Snake.Direction)
+
+	// Check wall and snake collisions. The tail is still in place, so it counts. An invulnerable
+	// snake waits for a turn instead of crashing. In Zen the snake bites through its own body.
+	if e.Config.Mode == ModeZen && e.grid.At(head) == CellSnake {
+		e.biteTail(head)
+	}
+	switch cell := e.At(head); cell {
+	case CellWall, CellSnake, CellRival:
+		if state.Invulnerable > 0 {
+			return append(events, e.tickRivals(interval)...)
+		}
+		return append(events, e.crash(cellCause(cell)))
+	}
+
+	// Run into whatever is on the board there, such as food to eat or a bomb, which may stop
+	// the snake
+	entity, found := e.EntityAt(head)
+	if hit := entityBehaviors[entity.Kind].hit; found && hit != nil {
+		hitEvents, moves := hit(e, entity)
+		events = append(events, hitEvents...)
+		if !moves {
+			return events
+		}
+	}
+	if stepped != next {
+		state.Wraps++
+	}
+
+	// Move, keeping the tail while there is growth to come from food eaten
+	if state.Growing > 0 {
+		state.Growing--
+		state.Snake.Segments = append([]Point{head}, state.Snake.Segments...)
+	} else {
+		e.grid.Set(state.Snake.Segments[len(state.Snake.Segments)-1], CellEmpty)
+		state.Snake.Segments = append([]Point{head}, state.Snake.Segments[:len(state.Snake.Segments)-1]...)
+	}
+	e.grid.Set(head, CellSnake)
+
+	// Reach what is there after moving, so a Shrink power-up trims the moved snake
+	if reached := entityBehaviors[entity.Kind].reached; found && reached != nil {
+		events = append(events, reached(e, entity)...)
+	}
+
+	// Rivals move after the player, so running into the player's new head crashes them
+	events = append(events, e.tickRivals(interval)...)
+
+	// Spawn a new wave once every regular piece of food is gone
+	if e.waveEaten() {
+		e.spawn()
+	}
+	return events
+}
+
+// Alpha returns how far the accumulator is towards the next tick, from 0 to 1
+func (e *Engine) Alpha() float32 {
+	return min(1, e.accumulator*e.TickRate())
+}
+
+// Previous returns the snake segments before the last tick, or the current ones before the first.
+// It may be one shorter than the current snake after growing.
+func (e *Engine) Previous() []Point {
+	if e.previous == nil {
+		return e.State.Snake.Segments
+	}
+	return e.previous
+}
+
+// Duration returns the seconds of play, counted in ticks so pauses and time scaling don't skew it
+func (e *Engine) Duration() float32 {
+	return e.State.Elapsed
+}
+
+// TickRate returns the current ticks per second, ramping up with the score until MaxTickRate,
+// and reduced while the Slow power-up is active
+func (e *Engine) TickRate() float32 {
+	points := e.State.Points
+	if e.Config.Coop {
+		points = e.State.TeamPoints()
+	}
+	rate := e.Config.TickRate + float32(float32(points)*e.Config.SpeedStep)
+	if e.Config.MaxTickRate > 0 {
+		rate = min(rate, e.Config.MaxTickRate)
+	}
+	if e.State.HasEffect(PowerUpSlow) {
+		rate *= slowFactor
+	}
+	if e.State.Boosting {
+		rate *= boostFactor
+	}
+	return rate
+}
+
+// Speed returns the current tick rate relative to the starting one
+func (e *Engine) Speed() float32 {
+	return e.TickRate() / e.Config.TickRate
+}
+
+// Wrap moves a point that has left the board to the opposite edge
+func (e *Engine) Wrap(p Point) Point {
+	p.X = (p.X%e.Config.Width + e.Config.Width) % e.Config.Width
+	p.Y = (p.Y%e.Config.Height + e.Config.Height) % e.Config.Height
+	return p
+}
+
+// Step returns the cell one move from p in direction d, wrapping across the board edge. With
+// solid edges it doesn't wrap, and the cell can be off the board.
+func (e *Engine) Step(p Point, d Direction) Point {
+	next := Point{X: p.X + d.X, Y: p.Y + d.Y}
+	if e.Config.SolidEdges {
+		return next
+	}
+	return e.Wrap(next)
+}
+
+// InBounds reports whether p is on the board
+func (e *Engine) InBounds(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < e.Config.Width && p.Y < e.Config.Height
+}
+
+// Seed returns the seed the game was started with, which replays its spawns
+func (e *Engine) Seed() uint64 {
+	return e.seed
+}
+
+// AddFood places a piece of food on a free cell, reporting whether it did
+func (e *Engine) AddFood(p Point) bool {
+	if e.At(p) != CellEmpty {
+		return false
+	}
+	e.place(Entity{Kind: EntityFood, Position: p})
+	return true
+}
+
+// AddBomb places a bomb, for modes where bombs are not spawned by the engine
+func (e *Engine) AddBomb(p Point) {
+	e.place(Entity{Kind: EntityBomb, Position: p})
+}
+
+// spawn replaces the food, and the bombs if the engine owns them, with a new wave
+// sized by the time played
+func (e *Engine) spawn() {
+	foodCount := min(int(e.Duration()/e.Config.FoodInterval)+1, e.Config.MaxFood)
+
+	bombCount := 0
+	if e.Config.BombDivisor > 0 && foodCount > 1 && e.Config.Mode != ModeZen {
+		bombCount = foodCount / e.Config.BombDivisor
+	}
+	if e.Config.BombDivisor > 0 && e.Config.Coop {
+		bombCount += e.coopBombs()
+	}
+
+	// Clear the old wave, leaving any golden or poison apple to run out. Bombs placed by the caller stay
+	// and food is kept off them, and bombs with a fuse stay until they go off.
+	clearBombs := e.Config.BombDivisor > 0 && e.Config.BombFuse == 0
+	e.keepEntities(func(entity Entity) bool {
+		switch entity.Kind {
+		case EntityFood:
+			return entity.FoodKind() != FoodRegular
+		case EntityBomb:
+			return !clearBombs
+		}
+		return true
+	})
+
+	// Spawn food first, spaced out from other food
+	for attempts, placed := 0, 0; placed < foodCount && attempts < maxSpawnAttempts; attempts++ {
+		p := e.randomPoint()
+		if e.grid.At(p) != CellEmpty || e.nearFood(p) {
+			continue
+		}
+		e.place(Entity{Kind: EntityFood, Position: p})
+		placed++
+	}
+
+	// Then spawn bombs, never next to food
+	for attempts, placed := 0, 0; placed < bombCount && attempts < maxSpawnAttempts; attempts++ {
+		p := e.randomPoint()
+		if e.grid.At(p) != CellEmpty || e.nearFood(p) {
+			continue
+		}
+		e.place(Entity{Kind: EntityBomb, Position: p, Remaining: e.Config.BombFuse})
+		placed++
+	}
+}
+
+// nearFood reports whether any of the eight cells around p holds food
+func (e *Engine) nearFood(p Point) bool {
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			if e.grid.At(Point{X: p.X + dx, Y: p.Y + dy}) == CellFood {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// randomPoint picks a cell in the arena, which is the whole board outside Arena mode
+func (e *Engine) randomPoint() Point {
+	arena := e.ArenaBounds(e.State.Ring)
+	return Point{
+		X: arena.Min.X + e.rng.IntN(arena.Max.X-arena.Min.X),
+		Y: arena.Min.Y + e.rng.IntN(arena.Max.Y-arena.Min.Y),
+	}
+}
@@ -0,0 +1,248 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+// testConfig is a small board with no food or bombs spawned, so tests place their own
+func testConfig() Config {
+	return Config{Width: 10, Height: 6, TickRate: 10, FoodInterval: 10, ScoreMultiplier: 1, BlastRadius: 1}
+}
+
+// newTestEngine starts a game with the snake laid out as segments, head first, heading right
+func newTestEngine(config Config, segments ...Point) *Engine {
+	e := NewEngine(config, 1)
+	state := e.State
+	state.Snake = Snake{Segments: segments, Direction: Right}
+	e.Load(state)
+	return e
+}
+
+func TestTick(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   func(c *Config)
+		segments []Point
+		setup    func(e *Engine)
+		inputs   []Direction
+		ticks    int
+		over     bool
+		cause    Cause
+		head     Point
+		length   int
+	}{
+		{
+			name:     "wraps round the edge",
+			segments: []Point{{9, 3}, {8, 3}},
+			ticks:    1,
+			head:     Point{0, 3},
+			length:   2,
+		},
+		{
+			name:     "crashes into a solid edge",
+			config:   func(c *Config) { c.SolidEdges = true },
+			segments: []Point{{9, 3}, {8, 3}},
+			ticks:    1,
+			over:     true,
+			cause:    CauseWall,
+		},
+		{
+			name:     "crashes into a wall",
+			config:   func(c *Config) { c.Walls = []Point{{6, 3}} },
+			segments: []Point{{5, 3}, {4, 3}},
+			ticks:    1,
+			over:     true,
+			cause:    CauseWall,
+		},
+		{
+			name:     "crashes into itself",
+			segments: []Point{{5, 3}, {4, 3}, {4, 4}, {5, 4}, {6, 4}},
+			inputs:   []Direction{Down},
+			ticks:    1,
+			over:     true,
+			cause:    CauseSelf,
+		},
+		{
+			name:     "crashes into a bomb",
+			segments: []Point{{5, 3}, {4, 3}},
+			setup:    func(e *Engine) { e.AddBomb(Point{6, 3}) },
+			ticks:    1,
+			over:     true,
+			cause:    CauseBomb,
+		},
+		{
+			name:     "grows one segment a tick from food",
+			config:   func(c *Config) { c.Growth = 3 },
+			segments: []Point{{5, 3}, {4, 3}},
+			setup:    func(e *Engine) { e.AddFood(Point{6, 3}) },
+			ticks:    4,
+			head:     Point{9, 3},
+			length:   5,
+		},
+		{
+			name:     "takes queued turns a tick apart",
+			segments: []Point{{5, 3}, {4, 3}},
+			inputs:   []Direction{Up, Left},
+			ticks:    2,
+			head:     Point{4, 2},
+			length:   2,
+		},
+		{
+			name:     "ignores a reversal onto the snake",
+			segments: []Point{{5, 3}, {4, 3}},
+			inputs:   []Direction{Left},
+			ticks:    1,
+			head:     Point{6, 3},
+			length:   2,
+		},
+		{
+			name:     "ignores turns past a full queue",
+			segments: []Point{{5, 3}, {4, 3}},
+			inputs:   []Direction{Up, Left, Down},
+			ticks:    3,
+			head:     Point{3, 2},
+			length:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig()
+			if test.config != nil {
+				test.config(&config)
+			}
+			e := newTestEngine(config, test.segments...)
+			if test.setup != nil {
+				test.setup(e)
+			}
+			for _, dir := range test.inputs {
+				e.Input(dir)
+			}
+			var events []Event
+			for range test.ticks {
+				events = append(events, e.Tick()...)
+			}
+
+			state := e.State
+			if state.Over != test.over {
+				t.Fatalf("over = %v, want %v", state.Over, test.over)
+			}
+			if test.over {
+				if state.Cause != test.cause {
+					t.Errorf("cause = %v, want %v", state.Cause, test.cause)
+				}
+				if !slices.Contains(events, EventDied) {
+					t.Errorf("events = %v, want EventDied", events)
+				}
+				return
+			}
+			if head := state.Snake.Head(); head != test.head {
+				t.Errorf("head = %v, want %v", head, test.head)
+			}
+			if length := len(state.Snake.Segments); length != test.length {
+				t.Errorf("length = %d, want %d", length, test.length)
+			}
+		})
+	}
+}
+
+func TestTickScoresFood(t *testing.T) {
+	e := newTestEngine(testConfig(), Point{5, 3}, Point{4, 3})
+	e.AddFood(Point{6, 3})
+	events := e.Tick()
+	if !slices.Contains(events, EventAte) {
+		t.Errorf("events = %v, want EventAte", events)
+	}
+	if e.State.Points != 1 || e.State.Eaten != 1 {
+		t.Errorf("points, eaten = %d, %d, want 1, 1", e.State.Points, e.State.Eaten)
+	}
+	if _, ok := e.EntityAt(Point{6, 3}); ok {
+		t.Error("food is still on the board once eaten")
+	}
+}
+
+func TestSpawnsFollowSeed(t *testing.T) {
+	config := Config{
+		Width: 40, Height: 22, TickRate: 10, MaxFood: 5, FoodInterval: 1, BombDivisor: 2, ScoreMultiplier: 1,
+		BlastRadius: 1, BombFuse: 3, PowerUpChance: 0.1, GoldenChance: 0.05, PoisonChance: 0.05,
+	}
+	run := func(seed uint64) []uint64 {
+		e := NewEngine(config, seed)
+		var hashes []uint64
+		for tick := range 200 {
+			// Circle, so the run lasts while the spawns come and go around the snake
+			if tick%7 == 0 {
+				e.Input(e.Heading().TurnLeft())
+			}
+			e.Tick()
+			hashes = append(hashes, e.Hash())
+		}
+		if e.State.Over {
+			t.Fatal("the snake crashed, cutting the run short")
+		}
+		return hashes
+	}
+
+	first, again := run(42), run(42)
+	if !slices.Equal(first, again) {
+		t.Error("games with the same seed drifted apart")
+	}
+	if other := run(43); slices.Equal(first, other) {
+		t.Error("games with different seeds spawned the same")
+	}
+}
+
+// newCoopEngine starts a co-op game with the player heading right at a wall, and the partner at
+// the top of the board heading right
+func newCoopEngine(walls ...Point) *Engine {
+	config := testConfig()
+	config.Coop = true
+	config.Walls = append([]Point{{6, 3}}, walls...)
+	e := newTestEngine(config, Point{5, 3}, Point{4, 3})
+	state := e.State
+	state.Rivals = []Rival{{Snake: Snake{Segments: []Point{{2, 0}, {1, 0}}, Direction: Right}, Pending: Right}}
+	e.Load(state)
+	return e
+}
+
+func TestCoopRevive(t *testing.T) {
+	e := newCoopEngine()
+	if events := e.Tick(); !slices.Contains(events, EventDowned) {
+		t.Fatalf("events = %v, want EventDowned", events)
+	}
+	if !e.State.Down || e.State.Over {
+		t.Fatalf("down, over = %v, %v, want the player down with the game going on", e.State.Down, e.State.Over)
+	}
+	if e.At(Point{5, 3}) != CellEmpty {
+		t.Error("the downed snake is still on the board")
+	}
+
+	// Put the heart in the partner's way rather than wherever it spawns
+	e.keepEntities(func(entity Entity) bool { return entity.Kind != EntityHeart })
+	e.place(Entity{Kind: EntityHeart, Position: Point{3, 0}})
+	if events := e.Tick(); !slices.Contains(events, EventRevived) {
+		t.Errorf("events = %v, want EventRevived", events)
+	}
+	if e.State.Down || e.State.Cause != CauseNone {
+		t.Errorf("down, cause = %v, %v, want the player revived", e.State.Down, e.State.Cause)
+	}
+	if e.At(e.State.Snake.Head()) != CellSnake {
+		t.Error("the revived snake isn't on the board")
+	}
+	if e.State.Count(EntityHeart) != 0 {
+		t.Error("the heart is still on the board once eaten")
+	}
+}
+
+func TestCoopBothDown(t *testing.T) {
+	e := newCoopEngine(Point{3, 0})
+	e.Tick()
+	events := e.Tick()
+	if !slices.Contains(events, EventDied) || !e.State.Over {
+		t.Errorf("events = %v, over = %v, want the game over once the partner crashes too", events, e.State.Over)
+	}
+	if e.State.Cause != CauseWall {
+		t.Errorf("cause = %v, want the player's crash", e.State.Cause)
+	}
+}
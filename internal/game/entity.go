@@ -0,0 +1,258 @@
+package game
+
+import (
+	"math"
+	"slices"
+)
+
+// EntityKind is what sort of thing an entity on the board is
+type EntityKind int
+
+const (
+	EntityFood    EntityKind = iota // Eaten for points and growth, see FoodKind
+	EntityBomb                      // Deadly to run into, and explodes if it has a fuse
+	EntityPowerUp                   // Grants an effect when picked up, see PowerUpKind
+	EntityPortal                    // Two linked cells a snake passes through
+	EntityHeart                     // In co-op, revives the crashed partner of the snake that eats it
+	EntityKindCount
+)
+
+var entityNames = [EntityKindCount]string{"Food", "Bomb", "Power-up", "Portal", "Heart"}
+
+func (k EntityKind) String() string {
+	return entityNames[k]
+}
+
+// Entity is anything on the board besides the snakes and walls. Every kind is built from the same
+// parts, and what an entity does when run into or when its time is up comes from its kind's
+// behavior, so a new pickup is a new kind with a behavior rather than changes to the spawning,
+// collision and drawing of every other.
+type Entity struct {
+	Kind      EntityKind `json:"kind"`
+	Position  Point      `json:"position"`
+	Variant   int        `json:"variant,omitempty"`   // Which food or power-up, see FoodKind and PowerUpKind
+	Remaining float32    `json:"remaining,omitempty"` // Seconds until it despawns, or a bomb explodes. 0 for one that stays
+	Link      Point      `json:"link"`                // A portal's far end
+}
+
+// FoodKind returns which food the entity is
+func (en Entity) FoodKind() FoodKind {
+	return FoodKind(en.Variant)
+}
+
+// PowerUpKind returns which power-up the entity is
+func (en Entity) PowerUpKind() PowerUpKind {
+	return PowerUpKind(en.Variant)
+}
+
+// Cells returns the cells the entity takes up, both ends for a portal
+func (en Entity) Cells() []Point {
+	if entityBehaviors[en.Kind].linked {
+		return []Point{en.Position, en.Link}
+	}
+	return []Point{en.Position}
+}
+
+// rivalMove is what becomes of a rival that runs into an entity
+type rivalMove int
+
+const (
+	rivalPasses rivalMove = iota // Moves on as if the cell were empty
+	rivalGrows                   // Moves on, keeping its tail
+	rivalDies
+)
+
+// entityBehavior is how the entities of a kind play. Hooks left nil do nothing.
+type entityBehavior struct {
+	cell    Cell // What its cells are marked as in the grid
+	linked  bool // Takes up its Link cell as well as its position
+	fragile bool // Destroyed by blasts
+	fuse    bool // Raises EventFuseTick as its countdown passes each whole second
+
+	// hit runs when the snake's head runs into it, before the snake moves, and reports whether the
+	// snake still moves this tick
+	hit func(e *Engine, entity Entity) ([]Event, bool)
+	// reached runs once the snake has moved onto it
+	reached func(e *Engine, entity Entity) []Event
+	// rivalHit runs when a rival's head runs into it
+	rivalHit func(e *Engine, rival *Rival, entity Entity) rivalMove
+	// expire runs when its countdown runs out, once it is off the board
+	expire func(e *Engine, entity Entity) []Event
+}
+
+// entityBehaviors are set in init, as their hooks look behaviors up in turn
+var entityBehaviors [EntityKindCount]entityBehavior
+
+func init() {
+	entityBehaviors = [EntityKindCount]entityBehavior{
+		EntityFood: {
+			cell:     CellFood,
+			fragile:  true,
+			hit:      (*Engine).eat,
+			rivalHit: (*Engine).rivalEat,
+		},
+		EntityBomb: {
+			cell:     CellBomb,
+			fuse:     true,
+			hit:      (*Engine).hitBomb,
+			rivalHit: (*Engine).rivalHitBomb,
+			expire:   (*Engine).explode,
+		},
+		EntityPowerUp: {
+			cell:    CellPowerUp,
+			reached: (*Engine).pickUp,
+			rivalHit: func(e *Engine, rival *Rival, entity Entity) rivalMove {
+				e.dropEntity(entity.Position)
+				if e.Config.Coop {
+					e.partnerPickUp(rival, entity.PowerUpKind())
+				}
+				return rivalPasses
+			},
+		},
+		EntityPortal: {
+			cell:   CellPortal,
+			linked: true,
+		},
+		EntityHeart: {
+			cell: CellHeart,
+			reached: func(e *Engine, heart Entity) []Event {
+				e.dropEntity(heart.Position)
+				return e.revivePartner()
+			},
+			rivalHit: func(e *Engine, _ *Rival, heart Entity) rivalMove {
+				e.dropEntity(heart.Position)
+				e.revivePlayer()
+				return rivalPasses
+			},
+		},
+	}
+}
+
+// spawners each tick maybe put a new entity on the board, in this order
+var spawners = []func(e *Engine){
+	(*Engine).spawnPowerUp,
+	func(e *Engine) { e.spawnTimedFood(FoodGolden, e.Config.GoldenChance, GoldenLifetime) },
+	func(e *Engine) { e.spawnTimedFood(FoodPoison, e.Config.PoisonChance, PoisonLifetime) },
+	(*Engine).spawnPortal,
+	(*Engine).spawnHeart,
+}
+
+// EntityAt returns the entity taking up p
+func (e *Engine) EntityAt(p Point) (Entity, bool) {
+	i := e.grid.entity(p)
+	if i < 0 {
+		return Entity{}, false
+	}
+	return e.State.Entities[i], true
+}
+
+// Count returns how many entities of a kind are on the board
+func (s *State) Count(kind EntityKind) int {
+	count := 0
+	for _, entity := range s.Entities {
+		if entity.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// place puts an entity on the board
+func (e *Engine) place(entity Entity) {
+	e.State.Entities = append(e.State.Entities, entity)
+	for _, p := range entity.Cells() {
+		e.grid.Set(p, entityBehaviors[entity.Kind].cell)
+		e.grid.setEntity(p, len(e.State.Entities)-1)
+	}
+}
+
+// moveEntity records that the entity at index from in State.Entities is now at index to, as
+// entities before it are taken out
+func (e *Engine) moveEntity(from, to int) {
+	if from == to {
+		return
+	}
+	for _, p := range e.State.Entities[to].Cells() {
+		e.grid.setEntity(p, to)
+	}
+}
+
+// dropEntity takes the entity taking up p out of the state, leaving its cells to the snake moving
+// in
+func (e *Engine) dropEntity(p Point) {
+	i := e.grid.entity(p)
+	if i < 0 {
+		return
+	}
+	for _, cell := range e.State.Entities[i].Cells() {
+		e.grid.setEntity(cell, -1)
+	}
+	e.State.Entities = slices.Delete(e.State.Entities, i, i+1)
+	for j := i; j < len(e.State.Entities); j++ {
+		e.moveEntity(j+1, j)
+	}
+}
+
+// keepEntities takes every entity keep rejects off the board, emptying its cells
+func (e *Engine) keepEntities(keep func(Entity) bool) {
+	entities := e.State.Entities[:0]
+	for i, entity := range e.State.Entities {
+		if keep(entity) {
+			entities = append(entities, entity)
+			e.moveEntity(i, len(entities)-1)
+			continue
+		}
+		for _, p := range entity.Cells() {
+			e.grid.Set(p, CellEmpty)
+			e.grid.setEntity(p, -1)
+		}
+	}
+	e.State.Entities = entities
+}
+
+// countDown counts down every entity with a countdown by one tick and takes the ones that run
+// out off the board, returning them for expire once the tick's spawns are done
+func (e *Engine) countDown(interval float32) ([]Entity, []Event) {
+	var events []Event
+	var expired []Entity
+	ticked := false
+	entities := e.State.Entities[:0]
+	for i, entity := range e.State.Entities {
+		if entity.Remaining <= 0 {
+			entities = append(entities, entity)
+			e.moveEntity(i, len(entities)-1)
+			continue
+		}
+		before := entity.Remaining
+		entity.Remaining -= interval
+		if entity.Remaining <= 0 {
+			for _, p := range entity.Cells() {
+				e.grid.Set(p, CellEmpty)
+				e.grid.setEntity(p, -1)
+			}
+			expired = append(expired, entity)
+			continue
+		}
+		// One tick however many fuses pass a second together
+		if entityBehaviors[entity.Kind].fuse && !ticked && math.Ceil(float64(before)) != math.Ceil(float64(entity.Remaining)) {
+			events = append(events, EventFuseTick)
+			ticked = true
+		}
+		entities = append(entities, entity)
+		e.moveEntity(i, len(entities)-1)
+	}
+	e.State.Entities = entities
+	return expired, events
+}
+
+// expire runs the expire hooks of the entities that ran out, such as bombs going off, in board
+// order
+func (e *Engine) expire(expired []Entity) []Event {
+	var events []Event
+	for _, entity := range expired {
+		if expire := entityBehaviors[entity.Kind].expire; expire != nil {
+			events = append(events, expire(e, entity)...)
+		}
+	}
+	return events
+}
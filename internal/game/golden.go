@@ -0,0 +1,93 @@
+package game
+
+// FoodKind is what a piece of food is worth and how long it stays
+type FoodKind int
+
+const (
+	FoodRegular FoodKind = iota // Part of a wave, stays until eaten
+	FoodGolden                  // A rare bonus apple that despawns if not eaten in time
+	FoodPoison                  // Reverses the controls for a while, and despawns if not eaten in time
+)
+
+const (
+	GoldenLifetime = 6 // Seconds a golden apple stays on the board
+	PoisonLifetime = 8 // Seconds a poison apple stays on the board
+	goldenValue    = 5 // Golden apples are worth this many regular pieces
+)
+
+// spawnTimedFood maybe spawns a piece of food of a kind that despawns, while there is none of
+// it on the board. There is one of each kind at a time.
+func (e *Engine) spawnTimedFood(kind FoodKind, chance, lifetime float32) {
+	if e.hasFood(kind) || e.rng.Float32() >= chance {
+		return
+	}
+	if p, ok := e.freePoint(); ok {
+		e.place(Entity{Kind: EntityFood, Position: p, Variant: int(kind), Remaining: lifetime})
+	}
+}
+
+// eat scores and grows the snake for the food it ran into, with combos, power-ups and boost
+// multiplying the points
+func (e *Engine) eat(food Entity) ([]Event, bool) {
+	state := &e.State
+	state.extendCombo()
+	points := e.foodValue(food) * state.ComboMultiplier()
+	if state.HasEffect(PowerUpDouble) {
+		points *= 2
+	}
+	if state.Boosting {
+		points *= boostFactor
+	}
+	state.Points += e.modFoodPoints(food, points)
+	state.Eaten++
+	e.dropEntity(food.Position)
+	state.Growing += max(1, e.Config.Growth)
+
+	var events []Event
+	switch food.FoodKind() {
+	case FoodGolden:
+		events = append(events, EventAteGolden)
+	case FoodPoison:
+		state.addStatus(StatusReversed)
+		events = append(events, EventAte, EventPoisoned)
+	default:
+		events = append(events, EventAte)
+	}
+	e.modFoodEaten(food)
+	return events, true
+}
+
+// rivalEat scores and grows a rival for the food it ran into, with none of the player's
+// multipliers
+func (e *Engine) rivalEat(rival *Rival, food Entity) rivalMove {
+	points := e.foodValue(food)
+	if e.Config.Coop && e.State.HasEffect(PowerUpDouble) {
+		points *= 2
+	}
+	rival.Points += points
+	e.dropEntity(food.Position)
+	return rivalGrows
+}
+
+// foodValue returns the points a piece of food is worth before power-ups
+func (e *Engine) foodValue(food Entity) int {
+	if food.FoodKind() == FoodGolden {
+		return e.Config.ScoreMultiplier * goldenValue
+	}
+	return e.Config.ScoreMultiplier
+}
+
+// hasFood reports whether there is food of a kind on the board
+func (e *Engine) hasFood(kind FoodKind) bool {
+	for _, entity := range e.State.Entities {
+		if entity.Kind == EntityFood && entity.FoodKind() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// waveEaten reports whether the regular food of the current wave is all gone
+func (e *Engine) waveEaten() bool {
+	return !e.hasFood(FoodRegular)
+}
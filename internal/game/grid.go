@@ -0,0 +1,102 @@
+package game
+
+// Cell is what occupies a board cell
+type Cell uint8
+
+const (
+	CellEmpty Cell = iota
+	CellWall
+	CellSnake // The player's snake, head included
+	CellRival
+	CellFood
+	CellBomb
+	CellPowerUp
+	CellPortal
+	CellHeart
+)
+
+// Grid indexes what occupies each cell of the board, stored flat in row order, and which entity
+// takes it up. The engine keeps it in step with its State so collisions and spawning are a lookup
+// rather than a scan.
+type Grid struct {
+	Width    int
+	Height   int
+	cells    []Cell
+	entities []int32 // Index into State.Entities plus one, 0 for a cell no entity takes up
+}
+
+func NewGrid(width, height int) Grid {
+	return Grid{Width: width, Height: height, cells: make([]Cell, width*height), entities: make([]int32, width*height)}
+}
+
+// InBounds reports whether p is on the grid
+func (g *Grid) InBounds(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// At returns what occupies p, CellEmpty for points off the grid
+func (g *Grid) At(p Point) Cell {
+	if !g.InBounds(p) {
+		return CellEmpty
+	}
+	return g.cells[p.Y*g.Width+p.X]
+}
+
+// Set records what occupies p, points off the grid are ignored
+func (g *Grid) Set(p Point, cell Cell) {
+	if g.InBounds(p) {
+		g.cells[p.Y*g.Width+p.X] = cell
+	}
+}
+
+// entity returns the index in State.Entities of the entity taking up p, -1 for none
+func (g *Grid) entity(p Point) int {
+	if !g.InBounds(p) {
+		return -1
+	}
+	return int(g.entities[p.Y*g.Width+p.X]) - 1
+}
+
+// setEntity records the entity taking up p by its index, -1 for none
+func (g *Grid) setEntity(p Point, index int) {
+	if g.InBounds(p) {
+		g.entities[p.Y*g.Width+p.X] = int32(index + 1)
+	}
+}
+
+// Reset empties every cell
+func (g *Grid) Reset() {
+	clear(g.cells)
+	clear(g.entities)
+}
+
+// index rebuilds the grid from scratch, for new or loaded states
+func (e *Engine) index() {
+	if e.grid.Width != e.Config.Width || e.grid.Height != e.Config.Height {
+		e.grid = NewGrid(e.Config.Width, e.Config.Height)
+	}
+	e.grid.Reset()
+
+	state := &e.State
+	for _, wall := range state.Walls {
+		e.grid.Set(wall, CellWall)
+	}
+	for i, entity := range state.Entities {
+		for _, p := range entity.Cells() {
+			e.grid.Set(p, entityBehaviors[entity.Kind].cell)
+			e.grid.setEntity(p, i)
+		}
+	}
+	for _, rival := range state.Rivals {
+		for _, segment := range rival.Snake.Segments {
+			e.grid.Set(segment, CellRival)
+		}
+	}
+	// A downed co-op snake is off the board until it is revived
+	if state.Down {
+		return
+	}
+	for _, segment := range state.Snake.Segments {
+		e.grid.Set(segment, CellSnake)
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+const (
+	invulnerableTime   = 2 // Seconds a respawned snake can't die
+	respawnClearance   = 3 // Free cells needed ahead of a respawned snake
+	minRespawnSegments = 2
+)
+
+// Cause is what the snake crashed into
+type Cause int
+
+const (
+	CauseNone Cause = iota
+	CauseWall
+	CauseSelf
+	CauseRival
+	CauseBomb
+	CauseBlast // Caught in a bomb's explosion
+	CauseCount
+)
+
+var causeNames = [CauseCount]string{"None", "Wall", "Self", "Rival", "Bomb", "Blast"}
+
+func (c Cause) String() string {
+	return causeNames[c]
+}
+
+// cellCause returns the cause of crashing into a cell
+func cellCause(cell Cell) Cause {
+	switch cell {
+	case CellWall:
+		return CauseWall
+	case CellSnake:
+		return CauseSelf
+	case CellRival:
+		return CauseRival
+	case CellBomb:
+		return CauseBomb
+	}
+	return CauseNone
+}
+
+// crash ends the game, or with lives left takes one and respawns the snake. In co-op the snake
+// goes down instead while its partner is alive.
+func (e *Engine) crash(cause Cause) Event {
+	state := &e.State
+	if e.down(cause) {
+		return EventDowned
+	}
+	if state.Lives > 1 && e.respawn() {
+		state.Lives--
+		return EventLifeLost
+	}
+	state.Lives = 0
+	state.Over = true
+	state.Cause = cause
+	e.modDeath()
+	return EventDied
+}
+
+// respawn puts the snake back at half its length on a free stretch of row heading right,
+// invulnerable for a moment. It fails if the board is too full to find one.
+func (e *Engine) respawn() bool {
+	state := &e.State
+	for _, segment := range state.Snake.Segments {
+		e.grid.Set(segment, CellEmpty)
+	}
+	if e.placeSnake() {
+		return true
+	}
+
+	// Put the snake back where it was so the final state still shows the crash
+	for _, segment := range state.Snake.Segments {
+		e.grid.Set(segment, CellSnake)
+	}
+	return false
+}
+
+// placeSnake lays the snake, at half its length, on a free stretch of row heading right and
+// invulnerable for a moment, reporting whether there was room. Its old cells must be clear already.
+func (e *Engine) placeSnake() bool {
+	state := &e.State
+	length := max(minRespawnSegments, len(state.Snake.Segments)/2)
+	for attempts := 0; attempts < maxSpawnAttempts; attempts++ {
+		head := e.randomPoint()
+		if !e.freeRow(head, -length+1, respawnClearance) {
+			continue
+		}
+		segments := make([]Point, length)
+		for i := range segments {
+			segments[i] = e.Wrap(Point{X: head.X - i, Y: head.Y})
+		}
+		for _, segment := range segments {
+			e.grid.Set(segment, CellSnake)
+		}
+		state.Snake = Snake{Segments: segments, Direction: Right}
+		state.Queued = nil
+		state.Growing = 0
+		state.Statuses = nil
+		state.Invulnerable = invulnerableTime
+		state.Combo, state.ComboTicks = 0, 0
+		return true
+	}
+	return false
+}
+
+// freeRow reports whether the cells from p.X+from to p.X+to on p's row are all empty
+func (e *Engine) freeRow(p Point, from, to int) bool {
+	for dx := from; dx <= to; dx++ {
+		if e.At(e.Wrap(Point{X: p.X + dx, Y: p.Y})) != CellEmpty {
+			return false
+		}
+	}
+	return true
+}
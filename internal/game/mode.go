@@ -0,0 +1,51 @@
+package game
+
+// Mode is the set of rules a game is played under
+type Mode int
+
+const (
+	ModeEndless Mode = iota // Play until the snake crashes
+	ModeTimed               // Score as much as possible before TimedLength runs out
+	ModeZen                 // No bombs, and running into the snake's own body bites its tail off
+	ModeArena               // The board's outer ring becomes deadly every ArenaInterval seconds
+	ModeCount
+)
+
+// TimedLength is how many seconds a Timed game lasts
+const TimedLength = 120
+
+var modeNames = [ModeCount]string{"Endless", "Timed", "Zen", "Arena"}
+
+func (m Mode) String() string {
+	return modeNames[m]
+}
+
+// ParseMode returns the mode with the given name, defaulting to Endless
+func ParseMode(name string) Mode {
+	for i, modeName := range modeNames {
+		if modeName == name {
+			return Mode(i)
+		}
+	}
+	return ModeEndless
+}
+
+// Next cycles to the following mode, wrapping back to Endless
+func (m Mode) Next() Mode {
+	return (m + 1) % ModeCount
+}
+
+// biteTail cuts the snake off at p, one of its own segments, instead of it crashing there
+func (e *Engine) biteTail(p Point) {
+	segments := e.State.Snake.Segments
+	for i, segment := range segments {
+		if segment != p {
+			continue
+		}
+		for _, bitten := range segments[i:] {
+			e.grid.Set(bitten, CellEmpty)
+		}
+		e.State.Snake.Segments = segments[:i]
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package game
+
+// Mod changes the rules of a game from outside the engine. Its hooks are called from Tick, so a
+// mod that only works from the engine it is given, without clocks or randomness of its own, keeps
+// the game deterministic, and one that keeps no state of its own rewinds and restores with it.
+type Mod interface {
+	// FoodPoints returns the points for food the snake ate, given the points the rules score it
+	FoodPoints(e *Engine, food Entity, points int) int
+	// OnFoodEaten runs after the snake eats, once the points and growth are added
+	OnFoodEaten(e *Engine, food Entity)
+	// OnTick runs at the start of every tick, before anything moves
+	OnTick(e *Engine)
+	// OnDeath runs when the game ends in a crash, with the cause set
+	OnDeath(e *Engine)
+}
+
+// AddMod hooks a mod into the game. Mods run in the order they were added.
+func (e *Engine) AddMod(mod Mod) {
+	e.mods = append(e.mods, mod)
+}
+
+// modFoodPoints passes the points for food eaten through each mod
+func (e *Engine) modFoodPoints(food Entity, points int) int {
+	for _, mod := range e.mods {
+		points = mod.FoodPoints(e, food, points)
+	}
+	return max(0, points)
+}
+
+func (e *Engine) modFoodEaten(food Entity) {
+	for _, mod := range e.mods {
+		mod.OnFoodEaten(e, food)
+	}
+}
+
+func (e *Engine) modTick() {
+	for _, mod := range e.mods {
+		mod.OnTick(e)
+	}
+}
+
+func (e *Engine) modDeath() {
+	for _, mod := range e.mods {
+		mod.OnDeath(e)
+	}
+}
@@ -0,0 +1,64 @@
+package game
+
+const (
+	portalLifetime    = 15 // Seconds a portal pair opened in Endless stays open
+	portalMinDistance = 8  // Fewest cells between the two ends of an opened pair, across and down
+)
+
+// Portal is a pair of linked cells laid out by a level, which lasts all game. On the board it is
+// an EntityPortal at A linked to B. A snake whose head runs into either end comes out of the cell
+// past the other, still heading the same way, and its body follows through.
+type Portal struct {
+	A Point `json:"a"`
+	B Point `json:"b"`
+}
+
+// exit returns the far end of the portal at p
+func (e *Engine) exit(p Point) (Point, bool) {
+	entity, ok := e.EntityAt(p)
+	if !ok || entity.Kind != EntityPortal {
+		return Point{}, false
+	}
+	if p == entity.Link {
+		return entity.Position, true
+	}
+	return entity.Link, true
+}
+
+// Next returns the cell a snake at p moving in d goes to: the neighbouring cell, or the one past
+// the far end of a portal it runs into
+func (e *Engine) Next(p Point, d Direction) Point {
+	next := e.Step(p, d)
+	if e.grid.At(next) != CellPortal {
+		return next
+	}
+	if exit, ok := e.exit(next); ok {
+		return e.Step(exit, d)
+	}
+	return next
+}
+
+// spawnPortal maybe opens a portal pair in Endless while none are open, which closes once its
+// time is up. A level's lasting portals don't count.
+func (e *Engine) spawnPortal() {
+	for _, entity := range e.State.Entities {
+		if entity.Kind == EntityPortal && entity.Remaining > 0 {
+			return
+		}
+	}
+	if e.Config.Mode != ModeEndless || e.rng.Float32() >= e.Config.PortalChance {
+		return
+	}
+	a, ok := e.freePoint()
+	if !ok {
+		return
+	}
+	for attempts := 0; attempts < maxSpawnAttempts; attempts++ {
+		b := e.randomPoint()
+		if e.grid.At(b) != CellEmpty || abs(a.X-b.X)+abs(a.Y-b.Y) < portalMinDistance {
+			continue
+		}
+		e.place(Entity{Kind: EntityPortal, Position: a, Link: b, Remaining: portalLifetime})
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package game
+
+// PowerUpKind is the effect a power-up grants when eaten
+type PowerUpKind int
+
+const (
+	PowerUpSlow   PowerUpKind = iota // Slows the snake down
+	PowerUpShield                    // Survives one bomb hit
+	PowerUpDouble                    // Doubles points from food
+	PowerUpShrink                    // Removes tail segments, applied at once
+	PowerUpKindCount
+)
+
+var powerUpNames = [PowerUpKindCount]string{"Slow", "Shield", "2x Points", "Shrink"}
+
+func (k PowerUpKind) String() string {
+	return powerUpNames[k]
+}
+
+const (
+	powerUpLifetime = 8   // Seconds a power-up stays on the board before despawning
+	slowFactor      = 0.6 // Tick rate multiplier while slowed
+	shrinkSegments  = 3   // Tail segments removed by Shrink
+	minSnakeLength  = 2   // Shrink never goes below this
+)
+
+// powerUpDurations are how long each timed effect lasts, in seconds of play
+var powerUpDurations = [PowerUpKindCount]float32{
+	PowerUpSlow:   5,
+	PowerUpShield: 15,
+	PowerUpDouble: 8,
+}
+
+// Effect is a timed power-up effect on the snake
+type Effect struct {
+	Kind      PowerUpKind `json:"kind"`
+	Remaining float32     `json:"remaining"` // Seconds left
+	Duration  float32     `json:"duration"`  // Seconds it started with, for HUD timers
+}
+
+// HasEffect reports whether the effect is active
+func (s *State) HasEffect(kind PowerUpKind) bool {
+	for _, effect := range s.Effects {
+		if effect.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// removeEffect ends an effect early, such as a shield used up by a bomb
+func (s *State) removeEffect(kind PowerUpKind) {
+	for i, effect := range s.Effects {
+		if effect.Kind == kind {
+			s.Effects = append(s.Effects[:i], s.Effects[i+1:]...)
+			return
+		}
+	}
+}
+
+// applyPowerUp grants a power-up's effect. Timed effects restart if already active.
+func (e *Engine) applyPowerUp(kind PowerUpKind) {
+	state := &e.State
+	if kind == PowerUpShrink {
+		keep := max(minSnakeLength, len(state.Snake.Segments)-shrinkSegments)
+		for _, segment := range state.Snake.Segments[keep:] {
+			e.grid.Set(segment, CellEmpty)
+		}
+		state.Snake.Segments = state.Snake.Segments[:keep]
+		return
+	}
+
+	state.removeEffect(kind)
+	state.Effects = append(state.Effects, Effect{
+		Kind:      kind,
+		Remaining: powerUpDurations[kind],
+		Duration:  powerUpDurations[kind],
+	})
+}
+
+// pickUp grants the power-up the snake moved onto
+func (e *Engine) pickUp(powerUp Entity) []Event {
+	e.dropEntity(powerUp.Position)
+	e.applyPowerUp(powerUp.PowerUpKind())
+	return []Event{EventPowerUp}
+}
+
+// updateEffects counts down the active effects by one tick
+func (s *State) updateEffects(interval float32) {
+	effects := s.Effects[:0]
+	for _, effect := range s.Effects {
+		effect.Remaining -= interval
+		if effect.Remaining > 0 {
+			effects = append(effects, effect)
+		}
+	}
+	s.Effects = effects
+}
+
+// spawnPowerUp maybe spawns a power-up of a random kind. There is one on the board at a time.
+func (e *Engine) spawnPowerUp() {
+	if e.State.Count(EntityPowerUp) > 0 || e.rng.Float32() >= e.Config.PowerUpChance {
+		return
+	}
+	if p, ok := e.freePoint(); ok {
+		e.place(Entity{
+			Kind:      EntityPowerUp,
+			Position:  p,
+			Variant:   e.rng.IntN(int(PowerUpKindCount)),
+			Remaining: powerUpLifetime,
+		})
+	}
+}
+
+// freePoint picks a random empty cell
+func (e *Engine) freePoint() (Point, bool) {
+	for attempts := 0; attempts < maxSpawnAttempts; attempts++ {
+		if p := e.randomPoint(); e.grid.At(p) == CellEmpty {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
@@ -0,0 +1,45 @@
+package game
+
+import "slices"
+
+const (
+	RewindSeconds = 3  // How far back a rewind goes
+	RewindUses    = 3  // Rewinds allowed per game
+	RewindPenalty = 10 // Points taken off for each rewind
+)
+
+// recordHistory keeps a snapshot of the game before each tick, dropping those from further back
+// than a rewind goes
+func (e *Engine) recordHistory() {
+	if !e.Config.Rewind {
+		return
+	}
+	e.history = append(e.history, e.Snapshot())
+	oldest := 0
+	for oldest+1 < len(e.history) && e.history[oldest+1].State.Elapsed <= e.State.Elapsed-RewindSeconds {
+		oldest++
+	}
+	e.history = slices.Delete(e.history, 0, oldest)
+}
+
+// CanRewind reports whether the game just ended in a crash that can still be rewound
+func (e *Engine) CanRewind() bool {
+	state := &e.State
+	return e.Config.Rewind && state.Over && state.Cause != CauseNone && state.Rewinds < RewindUses && len(e.history) > 0
+}
+
+// Rewind undoes the crash that ended the game, going back RewindSeconds (or to the start) for
+// RewindPenalty points. The spawns go back too, so they come again as they did. It reports
+// whether the game was rewound.
+func (e *Engine) Rewind() bool {
+	if !e.CanRewind() {
+		return false
+	}
+	rewinds := e.State.Rewinds + 1
+	oldest := e.history[0]
+	e.Restore(oldest)
+	e.history = []Snapshot{oldest}
+	e.State.Rewinds = rewinds
+	e.State.Points = max(0, e.State.Points-RewindPenalty)
+	return true
+}
@@ -0,0 +1,122 @@
+package game
+
+// rivalRespawnTime is how long a crashed rival stays off the board
+const rivalRespawnTime = 3
+
+// Rival is a computer-controlled snake sharing the board. It eats food like the player and
+// kills the player if they run into it. Power-ups it runs over are used up without effect.
+type Rival struct {
+	Snake   Snake     `json:"snake"`
+	Pending Direction `json:"pending"`
+	Points  int       `json:"points"`
+	Respawn float32   `json:"respawn"` // Seconds until it returns after crashing, 0 while alive
+}
+
+// Alive reports whether the rival is on the board
+func (r *Rival) Alive() bool {
+	return len(r.Snake.Segments) > 0
+}
+
+// AddRival puts a new rival snake on a free spot, returning its index
+func (e *Engine) AddRival() int {
+	e.State.Rivals = append(e.State.Rivals, Rival{})
+	i := len(e.State.Rivals) - 1
+	e.placeRival(&e.State.Rivals[i])
+	return i
+}
+
+// InputRival queues the direction a rival turns on the next tick, like Input for the player
+func (e *Engine) InputRival(i int, dir Direction) {
+	rival := &e.State.Rivals[i]
+	if !rival.Alive() || dir.Opposite(rival.Snake.Direction) {
+		return
+	}
+	rival.Pending = dir
+}
+
+// placeRival starts a two segment rival heading right, with room for its tail behind it.
+// If the board is too full it tries again after another respawn delay.
+func (e *Engine) placeRival(rival *Rival) {
+	for attempts := 0; attempts < maxSpawnAttempts; attempts++ {
+		head := e.randomPoint()
+		tail := e.Wrap(Point{X: head.X - 1, Y: head.Y})
+		ahead := e.Wrap(Point{X: head.X + 1, Y: head.Y})
+		if e.grid.At(head) != CellEmpty || e.grid.At(tail) != CellEmpty || e.grid.At(ahead) != CellEmpty {
+			continue
+		}
+		e.grid.Set(head, CellRival)
+		e.grid.Set(tail, CellRival)
+		rival.Snake = Snake{Segments: []Point{head, tail}, Direction: Right}
+		rival.Pending = Right
+		rival.Respawn = 0
+		return
+	}
+	rival.Respawn = rivalRespawnTime
+}
+
+// killRival takes a crashed rival off the board until it respawns, or in co-op until a heart
+// revives it, ending the game if the player is already down
+func (e *Engine) killRival(rival *Rival) []Event {
+	for _, segment := range rival.Snake.Segments {
+		e.grid.Set(segment, CellEmpty)
+	}
+	rival.Snake.Segments = nil
+	rival.Respawn = rivalRespawnTime
+	if e.Config.Coop && e.State.Down && !e.State.Over {
+		e.State.Over = true
+		return []Event{EventRivalDied, EventDied}
+	}
+	return []Event{EventRivalDied}
+}
+
+// tickRivals moves every rival one step after the player has moved. A rival crashes into walls,
+// bombs, the player and other snakes, and comes back after rivalRespawnTime.
+func (e *Engine) tickRivals(interval float32) []Event {
+	state := &e.State
+	var events []Event
+	down := state.Down
+	for i := range state.Rivals {
+		rival := &state.Rivals[i]
+		if !rival.Alive() {
+			// A co-op partner only comes back with a heart
+			if e.Config.Coop {
+				continue
+			}
+			rival.Respawn -= interval
+			if rival.Respawn <= 0 {
+				e.placeRival(rival)
+			}
+			continue
+		}
+
+		rival.Snake.Direction = rival.Pending
+		head := e.Next(rival.Snake.Head(), rival.Snake.Direction)
+
+		move := rivalPasses
+		switch e.At(head) {
+		case CellWall, CellSnake, CellRival:
+			move = rivalDies
+		default:
+			if entity, ok := e.EntityAt(head); ok && entityBehaviors[entity.Kind].rivalHit != nil {
+				move = entityBehaviors[entity.Kind].rivalHit(e, rival, entity)
+			}
+		}
+
+		switch move {
+		case rivalDies:
+			events = append(events, e.killRival(rival)...)
+			continue
+		case rivalGrows:
+			rival.Snake.Segments = append([]Point{head}, rival.Snake.Segments...)
+			events = append(events, EventRivalAte)
+		default:
+			e.grid.Set(rival.Snake.Segments[len(rival.Snake.Segments)-1], CellEmpty)
+			rival.Snake.Segments = append([]Point{head}, rival.Snake.Segments[:len(rival.Snake.Segments)-1]...)
+		}
+		e.grid.Set(head, CellRival)
+	}
+	if down && !state.Down {
+		events = append(events, EventRevived)
+	}
+	return events
+}
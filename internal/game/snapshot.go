@@ -0,0 +1,58 @@
+package game
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"slices"
+)
+
+// Snapshot is the whole game at one tick, the spawn generator included, to roll back to
+type Snapshot struct {
+	State  State
+	Random []byte // The spawn generator's state, as from Random
+}
+
+// clone returns a copy of the state sharing no slices with it
+func (s *State) clone() State {
+	state := *s
+	state.Snake.Segments = slices.Clone(s.Snake.Segments)
+	state.Queued = slices.Clone(s.Queued)
+	state.Entities = slices.Clone(s.Entities)
+	state.Walls = slices.Clone(s.Walls)
+	state.Effects = slices.Clone(s.Effects)
+	state.Statuses = slices.Clone(s.Statuses)
+	state.Explosions = slices.Clone(s.Explosions)
+	state.Rivals = slices.Clone(s.Rivals)
+	for i := range state.Rivals {
+		state.Rivals[i].Snake.Segments = slices.Clone(s.Rivals[i].Snake.Segments)
+	}
+	return state
+}
+
+// Snapshot copies the game as it stands
+func (e *Engine) Snapshot() Snapshot {
+	// Marshalling a PCG can't fail
+	random, _ := e.source.MarshalBinary()
+	return Snapshot{State: e.State.clone(), Random: random}
+}
+
+// Restore puts the game back as it was when the snapshot was taken, which can be restored again
+func (e *Engine) Restore(snapshot Snapshot) {
+	// Only a malformed generator state fails, and a snapshot's came from MarshalBinary
+	_ = e.source.UnmarshalBinary(snapshot.Random)
+	e.Load(snapshot.State.clone())
+}
+
+// Hash fingerprints the game: engines with the same hash are in the same state and will spawn
+// the same things from here. Comparing hashes shows when copies of a game, such as a replay
+// and the run it came from, have drifted apart.
+func (e *Engine) Hash() uint64 {
+	hash := fnv.New64a()
+	// Struct fields encode in a fixed order and State has no maps, so the encoding is stable
+	if err := json.NewEncoder(hash).Encode(&e.State); err != nil {
+		panic(err)
+	}
+	random, _ := e.source.MarshalBinary()
+	hash.Write(random)
+	return hash.Sum64()
+}
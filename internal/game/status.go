@@ -0,0 +1,66 @@
+package game
+
+// StatusKind is a timed condition on the snake from something it ate, as opposed to the effect
+// of a power-up it picked up
+type StatusKind int
+
+const (
+	StatusReversed StatusKind = iota // Directional input is inverted
+	StatusKindCount
+)
+
+var statusNames = [StatusKindCount]string{"Reversed"}
+
+func (k StatusKind) String() string {
+	return statusNames[k]
+}
+
+// statusDurations are how long each status lasts, in seconds of play
+var statusDurations = [StatusKindCount]float32{
+	StatusReversed: 4,
+}
+
+// Status is a timed condition on the snake
+type Status struct {
+	Kind      StatusKind `json:"kind"`
+	Remaining float32    `json:"remaining"` // Seconds left
+	Duration  float32    `json:"duration"`  // Seconds it started with, for HUD timers
+}
+
+// HasStatus reports whether the status is active
+func (s *State) HasStatus(kind StatusKind) bool {
+	for _, status := range s.Statuses {
+		if status.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// addStatus starts a status, restarting it if already active
+func (s *State) addStatus(kind StatusKind) {
+	statuses := s.Statuses[:0]
+	for _, status := range s.Statuses {
+		if status.Kind != kind {
+			statuses = append(statuses, status)
+		}
+	}
+	s.Statuses = append(statuses, Status{Kind: kind, Remaining: statusDurations[kind], Duration: statusDurations[kind]})
+}
+
+// updateStatuses counts statuses down by one tick, ending those that run out
+func (s *State) updateStatuses(interval float32) {
+	statuses := s.Statuses[:0]
+	for _, status := range s.Statuses {
+		status.Remaining -= interval
+		if status.Remaining > 0 {
+			statuses = append(statuses, status)
+		}
+	}
+	s.Statuses = statuses
+}
+
+// reverse returns the direction pointing the other way
+func reverse(d Direction) Direction {
+	return Direction{X: -d.X, Y: -d.Y}
+}
@@ -0,0 +1,108 @@
+package ghost
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+const ghostsDir = "ghosts"
+
+// Frame is the snake after one tick: where its head moved and how long it was
+type Frame struct {
+	Time   float32    `json:"t"` // Seconds into the run
+	Head   game.Point `json:"h"`
+	Length int        `json:"l"`
+}
+
+// Run is a recorded run, kept per level, difficulty and board size so the best one can be raced
+type Run struct {
+	Level      string  `json:"level"`
+	Difficulty string  `json:"difficulty"`
+	Board      string  `json:"board"`
+	Score      int     `json:"score"`
+	Frames     []Frame `json:"frames"`
+
+	ticks int // Engine ticks recorded so far
+	index int // Frame shown at the current playback time
+}
+
+// NewRun starts an empty recording
+func NewRun(level, difficulty, board string) *Run {
+	return &Run{Level: level, Difficulty: difficulty, Board: board, Frames: make([]Frame, 0)}
+}
+
+// Record adds a frame for each tick the engine ran since the last call. A frame can run several
+// ticks, their heads are the snake's newest segments. A rewound game drops the frames after the
+// state it went back to.
+func (r *Run) Record(state *game.State, elapsed float32) {
+	if state.Ticks < r.ticks {
+		r.Frames = slices.DeleteFunc(r.Frames, func(frame Frame) bool { return frame.Time > elapsed })
+	}
+	ticks := state.Ticks - r.ticks
+	r.ticks = state.Ticks
+	segments := state.Snake.Segments
+	for i := min(ticks, len(segments)) - 1; i >= 0; i-- {
+		r.Frames = append(r.Frames, Frame{Time: elapsed, Head: segments[i], Length: len(segments) - i})
+	}
+}
+
+// Segments returns the ghost snake at the given time into the run, head first, or nil once
+// the run is over. Times going backwards, after a rewind, play it back from there.
+func (r *Run) Segments(elapsed float32) []game.Point {
+	for r.index > 0 && r.Frames[r.index].Time > elapsed {
+		r.index--
+	}
+	for r.index+1 < len(r.Frames) && r.Frames[r.index+1].Time <= elapsed {
+		r.index++
+	}
+	if r.index+1 >= len(r.Frames) || len(r.Frames) == 0 {
+		return nil
+	}
+
+	// The body is where the head was over the last Length frames
+	frame := r.Frames[r.index]
+	segments := make([]game.Point, 0, frame.Length)
+	for i := r.index; i >= 0 && len(segments) < frame.Length; i-- {
+		segments = append(segments, r.Frames[i].Head)
+	}
+	return segments
+}
+
+// Path returns the file the best run for a level, difficulty and board size is kept in
+func Path(level, difficulty, board string) string {
+	name := strings.ToLower(strings.ReplaceAll(level+"-"+difficulty+"-"+board, " ", "_"))
+	return filepath.Join(ghostsDir, name+".json")
+}
+
+// Load reads the best run for a level, difficulty and board size, or nil if there isn't one yet
+func Load(level, difficulty, board string) (*Run, error) {
+	data, err := os.ReadFile(Path(level, difficulty, board))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	run := &Run{}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Save writes the run as the best for its level, difficulty and board size
+func Save(run *Run) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(ghostsDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(Path(run.Level, run.Difficulty, run.Board), data, 0644)
+}
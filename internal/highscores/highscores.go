@@ -2,45 +2,103 @@ package highscores
 
 import (
 	"encoding/csv"
-	"fmt"
+	"encoding/json"
 	"os"
 	"sort"
 	"strconv"
+	"time"
 )
 
 const (
-	highScoresFile = "highscores.csv"
-	maxHighScores  = 3
+	highScoresFile = "highscores.json"
+	legacyFile     = "highscores.csv" // Imported once if there is no JSON file yet
+)
+
+// DefaultLimit is how many scores each difficulty's board keeps unless the settings say otherwise
+const DefaultLimit = 10
+
+const (
+	MinNameLength = 3
+	MaxNameLength = 10
 )
 
 type HighScore struct {
-	Score    int
-	Duration float32
-	Date     string
+	Name       string    `json:"name"`
+	Score      int       `json:"score"`
+	Duration   float32   `json:"duration"` // Seconds
+	Difficulty string    `json:"difficulty"`
+	Level      string    `json:"level,omitempty"`
+	Board      string    `json:"board,omitempty"`  // Board size
+	Length     int       `json:"length,omitempty"` // Snake length at the end of the run
+	MaxCombo   int       `json:"max_combo,omitempty"`
+	Mode       string    `json:"mode,omitempty"`        // Game mode, empty for scores set before modes existed
+	SolidEdges bool      `json:"solid_edges,omitempty"` // Set with deadly board edges instead of wrapping
+	Cause      string    `json:"cause,omitempty"`       // What the snake died to, empty if the run ended otherwise
+	Daily      string    `json:"daily,omitempty"`       // Date of the daily challenge the score was set in
+	Transform  string    `json:"transform,omitempty"`   // How the level was mirrored or rotated, empty if it wasn't
+	Thumbnail  string    `json:"thumbnail,omitempty"`   // Path of a picture of the run's last frame
+	Date       time.Time `json:"date"`
+	Version    string    `json:"version,omitempty"` // Game version the score was set on
+	Speed      int       `json:"speed,omitempty"`   // Game speed percent the score was set at, empty at 100%
+	Profile    string    `json:"profile,omitempty"` // Local profile that set the score
 }
 
+// defaultDifficulty is recorded for scores saved before difficulties existed
+const defaultDifficulty = "Normal"
+
+// LoadHighScores reads the high scores file. On the first run after the move from CSV,
+// scores from the old CSV file are imported and saved as JSON.
 func LoadHighScores() ([]HighScore, error) {
+	data, err := os.ReadFile(highScoresFile)
+	if os.IsNotExist(err) {
+		scores, err := loadLegacy()
+		if err != nil || len(scores) == 0 {
+			return scores, err
+		}
+		return scores, SaveHighScores(scores)
+	} else if err != nil {
+		return nil, err
+	}
+
 	scores := make([]HighScore, 0)
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
 
-	// Create file if it doesn't exist
-	if _, err := os.Stat(highScoresFile); os.IsNotExist(err) {
+func SaveHighScores(scores []HighScore) error {
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(highScoresFile, data, 0644)
+}
+
+// loadLegacy reads the CSV format: score, duration, date, and later difficulty and name columns
+func loadLegacy() ([]HighScore, error) {
+	scores := make([]HighScore, 0)
+
+	if _, err := os.Stat(legacyFile); os.IsNotExist(err) {
 		return scores, nil
 	}
 
-	file, err := os.Open(highScoresFile)
+	file, err := os.Open(legacyFile)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, err
 	}
 
 	for _, record := range records {
-		if len(record) != 3 {
+		// Older files have no difficulty or name columns
+		if len(record) < 3 || len(record) > 5 {
 			continue
 		}
 		score, err := strconv.Atoi(record[0])
@@ -51,58 +109,124 @@ func LoadHighScores() ([]HighScore, error) {
 		if err != nil {
 			continue
 		}
+		date, err := time.ParseInLocation("2006-01-02", record[2], time.Local)
+		if err != nil {
+			continue
+		}
+		difficulty := defaultDifficulty
+		if len(record) >= 4 {
+			difficulty = record[3]
+		}
+		name := ""
+		if len(record) == 5 {
+			name = record[4]
+		}
 		scores = append(scores, HighScore{
-			Score:    score,
-			Duration: float32(duration),
-			Date:     record[2],
+			Name:       name,
+			Score:      score,
+			Duration:   float32(duration),
+			Difficulty: difficulty,
+			Date:       date,
 		})
 	}
 
 	return scores, nil
 }
 
-func SaveHighScores(scores []HighScore) error {
-	file, err := os.Create(highScoresFile)
-	if err != nil {
-		return err
+// FilterByDifficulty returns the scores recorded on the given difficulty, keeping their order
+func FilterByDifficulty(scores []HighScore, difficulty string) []HighScore {
+	filtered := make([]HighScore, 0, len(scores))
+	for _, score := range scores {
+		if score.Difficulty == difficulty {
+			filtered = append(filtered, score)
+		}
 	}
-	defer file.Close()
+	return filtered
+}
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+// SortOrder is how a board of scores is listed
+type SortOrder int
 
-	for _, score := range scores {
-		record := []string{
-			strconv.Itoa(score.Score),
-			fmt.Sprintf("%.1f", score.Duration),
-			score.Date,
-		}
-		if err := writer.Write(record); err != nil {
-			return err
-		}
+const (
+	SortByScore    SortOrder = iota
+	SortByDuration           // Longest runs first
+	SortByDate               // Newest first
+	SortOrderCount
+)
+
+func (o SortOrder) String() string {
+	switch o {
+	case SortByDuration:
+		return "Time"
+	case SortByDate:
+		return "Date"
+	default:
+		return "Score"
 	}
+}
+
+// Next returns the following sort order, wrapping back to SortByScore
+func (o SortOrder) Next() SortOrder {
+	return (o + 1) % SortOrderCount
+}
 
-	return nil
+// Sorted returns the indexes of scores in the given order. Boards are stored by score,
+// so an index plus one is still the score's rank.
+func Sorted(scores []HighScore, order SortOrder) []int {
+	indexes := make([]int, len(scores))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := scores[indexes[i]], scores[indexes[j]]
+		switch order {
+		case SortByDuration:
+			return a.Duration > b.Duration
+		case SortByDate:
+			return a.Date.After(b.Date)
+		default:
+			return false // Already in score order
+		}
+	})
+	return indexes
 }
 
-func IsHighScore(score int, scores []HighScore) bool {
-	if len(scores) < maxHighScores {
+// IsHighScore reports whether the score makes its difficulty's board of limit scores
+func IsHighScore(score int, difficulty string, scores []HighScore, limit int) bool {
+	limit = boardLimit(limit)
+	board := FilterByDifficulty(scores, difficulty)
+	if len(board) < limit {
 		return true
 	}
-	return score > scores[len(scores)-1].Score
+	return score > board[limit-1].Score
 }
 
-func UpdateHighScores(scores []HighScore, newScore HighScore) []HighScore {
+// UpdateHighScores adds the new score and trims each difficulty's board to limit scores
+func UpdateHighScores(scores []HighScore, newScore HighScore, limit int) []HighScore {
+	limit = boardLimit(limit)
 	scores = append(scores, newScore)
-	sort.Slice(scores, func(i, j int) bool {
+	sort.SliceStable(scores, func(i, j int) bool {
 		if scores[i].Score == scores[j].Score {
 			return scores[i].Duration < scores[j].Duration
 		}
 		return scores[i].Score > scores[j].Score
 	})
 
-	if len(scores) > maxHighScores {
-		scores = scores[:maxHighScores]
+	kept := make([]HighScore, 0, len(scores))
+	counts := make(map[string]int)
+	for _, score := range scores {
+		if counts[score.Difficulty] < limit {
+			kept = append(kept, score)
+			counts[score.Difficulty]++
+		}
+	}
+	return kept
+}
+
+// boardLimit falls back to DefaultLimit for unset or invalid limits
+func boardLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
 	}
-	return scores
+	return limit
 }
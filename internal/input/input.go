@@ -0,0 +1,247 @@
+package input
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	gamepad       = 0   // Only the first gamepad is polled
+	stickDeadzone = 0.5 // Left stick deflection needed to count as a direction
+)
+
+// Action is something the player can do in game, bound to a key
+type Action int
+
+const (
+	ActionUp Action = iota
+	ActionDown
+	ActionLeft
+	ActionRight
+	ActionPause
+	ActionBoost
+	ActionCount
+)
+
+var actionNames = [ActionCount]string{"Up", "Down", "Left", "Right", "Pause", "Boost"}
+
+func (a Action) String() string {
+	return actionNames[a]
+}
+
+// InputMap binds each action to a key. WASD, Right Shift and the first gamepad (d-pad, left
+// stick, Start and the right trigger) work alongside the bound keys.
+type InputMap struct {
+	Keys [ActionCount]int32
+
+	stick     Action // Direction the left stick points this frame, ActionCount when centered
+	lastStick Action
+}
+
+// alternateKeys are WASD and Right Shift, used as long as they aren't bound to another action
+var alternateKeys = map[Action]int32{
+	ActionUp:    rl.KeyW,
+	ActionDown:  rl.KeyS,
+	ActionLeft:  rl.KeyA,
+	ActionRight: rl.KeyD,
+	ActionBoost: rl.KeyRightShift,
+}
+
+var gamepadButtons = [ActionCount]int32{
+	ActionUp:    rl.GamepadButtonLeftFaceUp,
+	ActionDown:  rl.GamepadButtonLeftFaceDown,
+	ActionLeft:  rl.GamepadButtonLeftFaceLeft,
+	ActionRight: rl.GamepadButtonLeftFaceRight,
+	ActionPause: rl.GamepadButtonMiddleRight,
+	ActionBoost: rl.GamepadButtonRightTrigger2,
+}
+
+// Layout is a ready-made set of bindings, so players can put the keys under whichever hand
+// suits them
+type Layout struct {
+	Name string
+	Keys [ActionCount]int32
+}
+
+// Layouts are the ready-made bindings, the default first: the arrow keys for the right hand,
+// WASD for the left, and the keypad for the right hand on a full keyboard
+var Layouts = []Layout{
+	{Name: "Arrows", Keys: [ActionCount]int32{
+		ActionUp:    rl.KeyUp,
+		ActionDown:  rl.KeyDown,
+		ActionLeft:  rl.KeyLeft,
+		ActionRight: rl.KeyRight,
+		ActionPause: rl.KeyEscape,
+		ActionBoost: rl.KeyLeftShift,
+	}},
+	{Name: "WASD", Keys: [ActionCount]int32{
+		ActionUp:    rl.KeyW,
+		ActionDown:  rl.KeyS,
+		ActionLeft:  rl.KeyA,
+		ActionRight: rl.KeyD,
+		ActionPause: rl.KeyEscape,
+		ActionBoost: rl.KeySpace,
+	}},
+	{Name: "Keypad", Keys: [ActionCount]int32{
+		ActionUp:    rl.KeyKp8,
+		ActionDown:  rl.KeyKp2,
+		ActionLeft:  rl.KeyKp4,
+		ActionRight: rl.KeyKp6,
+		ActionPause: rl.KeyEscape,
+		ActionBoost: rl.KeyKp0,
+	}},
+}
+
+// DefaultInputMap returns the arrow keys, Escape to pause and Left Shift to boost
+func DefaultInputMap() *InputMap {
+	return &InputMap{
+		stick:     ActionCount,
+		lastStick: ActionCount,
+		Keys:      Layouts[0].Keys,
+	}
+}
+
+// Layout returns the index in Layouts of the layout the keys match, -1 for custom bindings
+func (m *InputMap) Layout() int {
+	for i, layout := range Layouts {
+		if layout.Keys == m.Keys {
+			return i
+		}
+	}
+	return -1
+}
+
+// Bindings returns the bound keys by action name, for saving
+func (m *InputMap) Bindings() map[string]int32 {
+	keys := make(map[string]int32, ActionCount)
+	for action, name := range actionNames {
+		keys[name] = m.Keys[action]
+	}
+	return keys
+}
+
+// SetBindings applies keys saved by action name, keeping the current key for any action missing
+func (m *InputMap) SetBindings(keys map[string]int32) {
+	for action, name := range actionNames {
+		if key, ok := keys[name]; ok && key > 0 {
+			m.Keys[action] = key
+		}
+	}
+}
+
+// Update polls the gamepad stick, call it once per frame before Pressed
+func (m *InputMap) Update() {
+	m.lastStick = m.stick
+	m.stick = ActionCount
+	if !rl.IsGamepadAvailable(gamepad) {
+		return
+	}
+
+	x := rl.GetGamepadAxisMovement(gamepad, rl.GamepadAxisLeftX)
+	y := rl.GetGamepadAxisMovement(gamepad, rl.GamepadAxisLeftY)
+	if max(abs(x), abs(y)) < stickDeadzone {
+		return
+	}
+
+	// Use the dominant axis so diagonals pick one direction
+	switch {
+	case abs(x) > abs(y) && x > 0:
+		m.stick = ActionRight
+	case abs(x) > abs(y):
+		m.stick = ActionLeft
+	case y > 0:
+		m.stick = ActionDown
+	default:
+		m.stick = ActionUp
+	}
+}
+
+// Pressed reports whether the action was triggered this frame by its key, its WASD alternate,
+// the gamepad, or the left stick moving into its direction
+func (m *InputMap) Pressed(action Action) bool {
+	if rl.IsKeyPressed(m.Keys[action]) {
+		return true
+	}
+	if key, ok := alternateKeys[action]; ok && !m.IsBound(key) && rl.IsKeyPressed(key) {
+		return true
+	}
+	if rl.IsGamepadAvailable(gamepad) && rl.IsGamepadButtonPressed(gamepad, gamepadButtons[action]) {
+		return true
+	}
+	return m.stick == action && m.lastStick != action
+}
+
+// Down reports whether the action's key, its alternate or its gamepad button is held
+func (m *InputMap) Down(action Action) bool {
+	if rl.IsKeyDown(m.Keys[action]) {
+		return true
+	}
+	if key, ok := alternateKeys[action]; ok && !m.IsBound(key) && rl.IsKeyDown(key) {
+		return true
+	}
+	return rl.IsGamepadAvailable(gamepad) && rl.IsGamepadButtonDown(gamepad, gamepadButtons[action])
+}
+
+// IsBound reports whether an action uses the key
+func (m *InputMap) IsBound(key int32) bool {
+	for _, bound := range m.Keys {
+		if bound == key {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Bind sets the key for an action. An action already using the key takes this action's old key,
+// so no key is ever bound twice.
+func (m *InputMap) Bind(action Action, key int32) {
+	for other := range m.Keys {
+		if m.Keys[other] == key {
+			m.Keys[other] = m.Keys[action]
+		}
+	}
+	m.Keys[action] = key
+}
+
+var keyNames = map[int32]string{
+	rl.KeyUp:           "Up Arrow",
+	rl.KeyDown:         "Down Arrow",
+	rl.KeyLeft:         "Left Arrow",
+	rl.KeyRight:        "Right Arrow",
+	rl.KeyEscape:       "Escape",
+	rl.KeyEnter:        "Enter",
+	rl.KeySpace:        "Space",
+	rl.KeyTab:          "Tab",
+	rl.KeyBackspace:    "Backspace",
+	rl.KeyLeftShift:    "Left Shift",
+	rl.KeyRightShift:   "Right Shift",
+	rl.KeyLeftControl:  "Left Ctrl",
+	rl.KeyRightControl: "Right Ctrl",
+	rl.KeyLeftAlt:      "Left Alt",
+	rl.KeyRightAlt:     "Right Alt",
+	rl.KeyKp8:          "Keypad 8",
+	rl.KeyKp2:          "Keypad 2",
+	rl.KeyKp4:          "Keypad 4",
+	rl.KeyKp6:          "Keypad 6",
+	rl.KeyKp0:          "Keypad 0",
+}
+
+// KeyName returns a display name for a key
+func KeyName(key int32) string {
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+	// Printable keys use their ASCII code
+	if key >= rl.KeyApostrophe && key <= rl.KeyGrave {
+		return string(rune(key))
+	}
+	return fmt.Sprintf("Key %d", key)
+}
@@ -0,0 +1,159 @@
+package leaderboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ztkent/snake/internal/highscores"
+)
+
+const (
+	queueFile      = "leaderboard_queue.json" // Scores waiting for the server to be reachable
+	requestTimeout = 5 * time.Second
+)
+
+// Client talks to a leaderboard server:
+//
+//	POST {URL}/scores                             submits one score as JSON
+//	GET  {URL}/scores?difficulty=Normal&limit=10   returns the top scores as a JSON array
+//	GET  {URL}/scores?daily=2025-01-31&limit=10    returns the top scores of a day's challenge
+type Client struct {
+	URL  string
+	http *http.Client
+	mu   sync.Mutex // Guards the queue file
+}
+
+func NewClient(serverURL string) *Client {
+	return &Client{
+		URL:  serverURL,
+		http: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Submit sends a score, queuing it to send later if the server can't be reached
+func (c *Client) Submit(score highscores.HighScore) error {
+	if err := c.post(score); err != nil {
+		if queueErr := c.enqueue(score); queueErr != nil {
+			return queueErr
+		}
+		return err
+	}
+	return nil
+}
+
+// Flush sends any queued scores, keeping the ones that still fail
+func (c *Client) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queued, err := loadQueue()
+	if err != nil || len(queued) == 0 {
+		return err
+	}
+
+	remaining := make([]highscores.HighScore, 0)
+	var lastErr error
+	for _, score := range queued {
+		if err := c.post(score); err != nil {
+			remaining = append(remaining, score)
+			lastErr = err
+		}
+	}
+	if err := saveQueue(remaining); err != nil {
+		return err
+	}
+	return lastErr
+}
+
+// Top returns the best scores on the server for a difficulty, best first
+func (c *Client) Top(difficulty string, limit int) ([]highscores.HighScore, error) {
+	return c.top(url.Values{"difficulty": {difficulty}}, limit)
+}
+
+// TopDaily returns the best scores on the server for the daily challenge of a date, best first
+func (c *Client) TopDaily(date string, limit int) ([]highscores.HighScore, error) {
+	return c.top(url.Values{"daily": {date}}, limit)
+}
+
+func (c *Client) top(query url.Values, limit int) ([]highscores.HighScore, error) {
+	query.Set("limit", strconv.Itoa(limit))
+
+	resp, err := c.http.Get(c.URL + "/scores?" + query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("leaderboard server returned %s", resp.Status)
+	}
+
+	scores := make([]highscores.HighScore, 0)
+	if err := json.NewDecoder(resp.Body).Decode(&scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+func (c *Client) post(score highscores.HighScore) error {
+	data, err := json.Marshal(score)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Post(c.URL+"/scores", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("leaderboard server returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (c *Client) enqueue(score highscores.HighScore) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queued, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	return saveQueue(append(queued, score))
+}
+
+func loadQueue() ([]highscores.HighScore, error) {
+	data, err := os.ReadFile(queueFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	queued := make([]highscores.HighScore, 0)
+	if err := json.Unmarshal(data, &queued); err != nil {
+		return nil, err
+	}
+	return queued, nil
+}
+
+func saveQueue(queued []highscores.HighScore) error {
+	if len(queued) == 0 {
+		err := os.Remove(queueFile)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := json.MarshalIndent(queued, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(queueFile, data, 0644)
+}
@@ -0,0 +1,157 @@
+package levels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+// Dir is the folder custom levels are loaded from, one JSON file each
+const Dir = "levels"
+
+// Custom are the levels loaded from Dir, after the built-in ones in Level Select
+var Custom []Level
+
+// customFile is a custom level as written in its file. The map is rows of cells: '#' is a wall,
+// a digit is one end of a portal and the other end is the same digit, and anything else is open.
+type customFile struct {
+	Name   string   `json:"name"`
+	Author string   `json:"author"`
+	Par    int      `json:"par"`
+	Map    []string `json:"map"`
+}
+
+// LoadCustom loads every level in Dir into Custom. Files that can't be read or aren't a valid
+// level are skipped, and listed in the error.
+func LoadCustom() error {
+	Custom = nil
+	paths, err := filepath.Glob(filepath.Join(Dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, path := range paths {
+		level, err := loadCustom(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		Custom = append(Custom, level)
+	}
+	return errors.Join(errs...)
+}
+
+// loadCustom reads one level file, named after the file if it doesn't give a name
+func loadCustom(path string) (Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Level{}, err
+	}
+	var file customFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Level{}, err
+	}
+
+	name := strings.TrimSpace(file.Name)
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	for _, level := range All() {
+		if strings.EqualFold(level.Name, name) {
+			return Level{}, fmt.Errorf("there is already a level called %s", name)
+		}
+	}
+	if len(file.Map) == 0 {
+		return Level{}, errors.New("the map is empty")
+	}
+
+	walls, ends := parseMap(file.Map)
+	for digit, cells := range ends {
+		if len(cells) != 2 {
+			return Level{}, fmt.Errorf("portal %c has %d ends, it needs 2", digit, len(cells))
+		}
+	}
+	return Level{
+		Name:   name,
+		Author: file.Author,
+		Par:    file.Par,
+		build: func(width, height int) []game.Point {
+			return placeWalls(walls, mapOffset(file.Map, width, height), width, height)
+		},
+		portals: func(width, height int) []game.Portal {
+			return placePortals(ends, mapOffset(file.Map, width, height), width, height)
+		},
+	}, nil
+}
+
+// parseMap returns the map's wall cells and each portal digit's ends, relative to its top left
+func parseMap(rows []string) ([]game.Point, map[rune][]game.Point) {
+	var walls []game.Point
+	ends := make(map[rune][]game.Point)
+	for y, row := range rows {
+		for x, char := range []rune(row) {
+			switch {
+			case char == '#':
+				walls = append(walls, game.Point{X: x, Y: y})
+			case char >= '0' && char <= '9':
+				ends[char] = append(ends[char], game.Point{X: x, Y: y})
+			}
+		}
+	}
+	return walls, ends
+}
+
+// mapOffset centers the map on the board, so a map made for one board size still fits the others
+func mapOffset(rows []string, width, height int) game.Point {
+	mapWidth := 0
+	for _, row := range rows {
+		mapWidth = max(mapWidth, len([]rune(row)))
+	}
+	return game.Point{X: (width - mapWidth) / 2, Y: (height - len(rows)) / 2}
+}
+
+// placeWalls moves the map's walls onto the board. Walls off the board are dropped, as are those
+// where the snake starts and just ahead of it.
+func placeWalls(walls []game.Point, offset game.Point, width, height int) []game.Point {
+	placed := make([]game.Point, 0, len(walls))
+	for _, wall := range walls {
+		cell := game.Point{X: wall.X + offset.X, Y: wall.Y + offset.Y}
+		if onBoard(cell, width, height) && !onStart(cell, width, height) {
+			placed = append(placed, cell)
+		}
+	}
+	return placed
+}
+
+// placePortals moves the map's portals onto the board, dropping any with an end off the board or
+// where the snake starts
+func placePortals(ends map[rune][]game.Point, offset game.Point, width, height int) []game.Portal {
+	var portals []game.Portal
+	for digit := '0'; digit <= '9'; digit++ {
+		cells, ok := ends[digit]
+		if !ok {
+			continue
+		}
+		a := game.Point{X: cells[0].X + offset.X, Y: cells[0].Y + offset.Y}
+		b := game.Point{X: cells[1].X + offset.X, Y: cells[1].Y + offset.Y}
+		if onBoard(a, width, height) && onBoard(b, width, height) && !onStart(a, width, height) && !onStart(b, width, height) {
+			portals = append(portals, game.Portal{A: a, B: b})
+		}
+	}
+	return portals
+}
+
+func onBoard(cell game.Point, width, height int) bool {
+	return cell.X >= 0 && cell.X < width && cell.Y >= 0 && cell.Y < height
+}
+
+// onStart reports whether the cell is the snake's starting cells or the few in front of them
+func onStart(cell game.Point, width, height int) bool {
+	return cell.Y == height/2 && cell.X >= width/2-1 && cell.X <= width/2+4
+}
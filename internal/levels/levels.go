@@ -0,0 +1,169 @@
+package levels
+
+import "github.com/ztkent/snake/internal/game"
+
+// Level is a static wall layout, some with portal pairs. Layouts are built for the board size,
+// so they fit any board, and keep the row through the middle of the board clear where the snake starts.
+type Level struct {
+	Name    string
+	Author  string // Who made a custom level, empty for the built-in ones
+	Par     int    // Score to beat that a custom level suggests, 0 for none
+	build   func(width, height int) []game.Point
+	portals func(width, height int) []game.Portal
+}
+
+// Walls returns the wall cells for a board of the given size in cells
+func (l Level) Walls(width, height int) []game.Point {
+	if l.build == nil {
+		return nil
+	}
+	return l.build(width, height)
+}
+
+// Portals returns the level's portal pairs for a board of the given size in cells
+func (l Level) Portals(width, height int) []game.Portal {
+	if l.portals == nil {
+		return nil
+	}
+	return l.portals(width, height)
+}
+
+// Builtin are the layouts shipped with the game, in Level Select order
+var Builtin = []Level{
+	{Name: "Open"},
+	{Name: "Box", build: box, portals: boxPortals},
+	{Name: "Cross", build: cross},
+	{Name: "Pillars", build: pillars},
+	{Name: "Tunnels", build: tunnels, portals: tunnelPortals},
+	{Name: "Maze", build: maze},
+}
+
+// All returns the built-in levels followed by the custom ones
+func All() []Level {
+	return append(append([]Level(nil), Builtin...), Custom...)
+}
+
+// Find returns the level with the given name, defaulting to the first built-in one
+func Find(name string) Level {
+	for _, level := range All() {
+		if level.Name == name {
+			return level
+		}
+	}
+	return Builtin[0]
+}
+
+// box walls off the board edges, so wrapping is impossible
+func box(width, height int) []game.Point {
+	walls := make([]game.Point, 0, 2*(width+height))
+	walls = append(walls, hline(0, width-1, 0)...)
+	walls = append(walls, hline(0, width-1, height-1)...)
+	walls = append(walls, vline(0, 1, height-2)...)
+	walls = append(walls, vline(width-1, 1, height-2)...)
+	return walls
+}
+
+// boxPortals links opposite inside corners of the box, standing in for the wrapping it walls off
+func boxPortals(width, height int) []game.Portal {
+	return []game.Portal{
+		{A: game.Point{X: 3, Y: 3}, B: game.Point{X: width - 4, Y: height - 4}},
+		{A: game.Point{X: width - 4, Y: 3}, B: game.Point{X: 3, Y: height - 4}},
+	}
+}
+
+// cross puts a plus sign in the middle, open at its center so the snake can start there
+func cross(width, height int) []game.Point {
+	cx, cy := width/2, height/2
+	armX, armY := width/4, height/3
+	gap := 4
+
+	walls := make([]game.Point, 0)
+	walls = append(walls, hline(cx-armX, cx-gap, cy-2)...)
+	walls = append(walls, hline(cx+gap, cx+armX, cy-2)...)
+	walls = append(walls, hline(cx-armX, cx-gap, cy+2)...)
+	walls = append(walls, hline(cx+gap, cx+armX, cy+2)...)
+	walls = append(walls, vline(cx, cy-armY, cy-3)...)
+	walls = append(walls, vline(cx, cy+3, cy+armY)...)
+	return walls
+}
+
+// pillars scatters 2x2 blocks on a regular grid, away from the starting row
+func pillars(width, height int) []game.Point {
+	walls := make([]game.Point, 0)
+	for x := 4; x+1 < width-2; x += 7 {
+		for y := 3; y+1 < height-2; y += 6 {
+			if y <= height/2 && y+1 >= height/2 {
+				continue
+			}
+			walls = append(walls,
+				game.Point{X: x, Y: y}, game.Point{X: x + 1, Y: y},
+				game.Point{X: x, Y: y + 1}, game.Point{X: x + 1, Y: y + 1},
+			)
+		}
+	}
+	return walls
+}
+
+// tunnels lays long horizontal bars with openings at alternating ends
+func tunnels(width, height int) []game.Point {
+	walls := make([]game.Point, 0)
+	for i, y := 0, 3; y < height-2; i, y = i+1, y+4 {
+		if y == height/2 {
+			continue
+		}
+		if i%2 == 0 {
+			walls = append(walls, hline(0, width-6, y)...)
+		} else {
+			walls = append(walls, hline(5, width-1, y)...)
+		}
+	}
+	return walls
+}
+
+// tunnelPortals links the top row to the bottom one, a shortcut past the tunnels
+func tunnelPortals(width, height int) []game.Portal {
+	return []game.Portal{{A: game.Point{X: 2, Y: 1}, B: game.Point{X: width - 3, Y: height - 2}}}
+}
+
+// maze combines a broken border with staggered inner walls
+func maze(width, height int) []game.Point {
+	walls := make([]game.Point, 0)
+
+	// Border with a gap in the middle of each side, so the snake can still wrap there
+	walls = append(walls, hline(0, width/2-3, 0)...)
+	walls = append(walls, hline(width/2+3, width-1, 0)...)
+	walls = append(walls, hline(0, width/2-3, height-1)...)
+	walls = append(walls, hline(width/2+3, width-1, height-1)...)
+	walls = append(walls, vline(0, 1, height/2-3)...)
+	walls = append(walls, vline(0, height/2+3, height-2)...)
+	walls = append(walls, vline(width-1, 1, height/2-3)...)
+	walls = append(walls, vline(width-1, height/2+3, height-2)...)
+
+	// Inner walls alternate between hanging from the top and standing on the bottom
+	for i, x := 0, 6; x < width-4; i, x = i+1, x+6 {
+		if i%2 == 0 {
+			walls = append(walls, vline(x, 1, height/2-2)...)
+		} else {
+			walls = append(walls, vline(x, height/2+2, height-2)...)
+		}
+	}
+	return walls
+}
+
+// hline returns the cells from x0 to x1 inclusive on row y
+func hline(x0, x1, y int) []game.Point {
+	points := make([]game.Point, 0, x1-x0+1)
+	for x := x0; x <= x1; x++ {
+		points = append(points, game.Point{X: x, Y: y})
+	}
+	return points
+}
+
+// vline returns the cells from y0 to y1 inclusive on column x
+func vline(x, y0, y1 int) []game.Point {
+	points := make([]game.Point, 0, y1-y0+1)
+	for y := y0; y <= y1; y++ {
+		points = append(points, game.Point{X: x, Y: y})
+	}
+	return points
+}
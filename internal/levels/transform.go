@@ -0,0 +1,73 @@
+package levels
+
+import (
+	"slices"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+// Transform mirrors or turns a level's layout, so a run can play a familiar level a new way round
+type Transform int
+
+const (
+	TransformNone   Transform = iota
+	TransformMirror           // Left to right
+	TransformFlip             // Top to bottom
+	TransformRotate           // A half turn, both mirrored and flipped
+	TransformCount
+)
+
+var transformNames = [TransformCount]string{"None", "Mirror", "Flip", "Rotate"}
+
+func (t Transform) String() string {
+	return transformNames[t]
+}
+
+// ParseTransform returns the transform with the given name, defaulting to None
+func ParseTransform(name string) Transform {
+	for i, transformName := range transformNames {
+		if transformName == name {
+			return Transform(i)
+		}
+	}
+	return TransformNone
+}
+
+// Point moves a cell of a board of the given size in cells
+func (t Transform) Point(p game.Point, width, height int) game.Point {
+	if t == TransformMirror || t == TransformRotate {
+		p.X = width - 1 - p.X
+	}
+	if t == TransformFlip || t == TransformRotate {
+		p.Y = height - 1 - p.Y
+	}
+	return p
+}
+
+// Transformed returns the level with its walls and portals moved by t. Walls moved to where the
+// snake starts, or just ahead of it, are dropped as they are for custom levels.
+func (l Level) Transformed(t Transform) Level {
+	if t == TransformNone {
+		return l
+	}
+	build, portals := l.build, l.portals
+	if build != nil {
+		l.build = func(width, height int) []game.Point {
+			walls := build(width, height)
+			for i, wall := range walls {
+				walls[i] = t.Point(wall, width, height)
+			}
+			return slices.DeleteFunc(walls, func(wall game.Point) bool { return onStart(wall, width, height) })
+		}
+	}
+	if portals != nil {
+		l.portals = func(width, height int) []game.Portal {
+			pairs := portals(width, height)
+			for i, pair := range pairs {
+				pairs[i] = game.Portal{A: t.Point(pair.A, width, height), B: t.Point(pair.B, width, height)}
+			}
+			return pairs
+		}
+	}
+	return l
+}
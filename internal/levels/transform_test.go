@@ -0,0 +1,53 @@
+package levels
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+// boardSizes are the Small, Medium and Large boards in cells
+var boardSizes = []game.Point{{X: 28, Y: 16}, {X: 40, Y: 22}, {X: 56, Y: 31}}
+
+// samePair reports whether two portals link the same cells, either way round
+func samePair(a, b game.Portal) bool {
+	return (a.A == b.A && a.B == b.B) || (a.A == b.B && a.B == b.A)
+}
+
+func TestTransformedBox(t *testing.T) {
+	box := Find("Box")
+	for _, size := range boardSizes {
+		width, height := size.X, size.Y
+		want := box.Portals(width, height)
+		for transform := TransformNone; transform < TransformCount; transform++ {
+			level := box.Transformed(transform)
+			walls := level.Walls(width, height)
+			for x := range width {
+				for _, y := range []int{0, height - 1} {
+					if !slices.Contains(walls, game.Point{X: x, Y: y}) {
+						t.Errorf("%dx%d %v: no wall at %d,%d on the top or bottom edge", width, height, transform, x, y)
+					}
+				}
+			}
+			for y := range height {
+				for _, x := range []int{0, width - 1} {
+					if !slices.Contains(walls, game.Point{X: x, Y: y}) {
+						t.Errorf("%dx%d %v: no wall at %d,%d on the left or right edge", width, height, transform, x, y)
+					}
+				}
+			}
+
+			// The box is symmetric, so its portals only swap round
+			portals := level.Portals(width, height)
+			if len(portals) != len(want) {
+				t.Fatalf("%dx%d %v: %d portals, want %d", width, height, transform, len(portals), len(want))
+			}
+			for _, portal := range portals {
+				if !slices.ContainsFunc(want, func(p game.Portal) bool { return samePair(p, portal) }) {
+					t.Errorf("%dx%d %v: portal %v isn't one of %v", width, height, transform, portal, want)
+				}
+			}
+		}
+	}
+}
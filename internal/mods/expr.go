@@ -0,0 +1,299 @@
+package mods
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// An expression is a formula over named numbers, such as "points * 2 + (combo > 3 ? 5 : 0)".
+// It has numbers, variables, the arithmetic operators + - * / %, comparisons, && || and !, the
+// conditional ?: and the functions min, max, abs and floor. True is 1 and false is 0, and any
+// value other than 0 counts as true. Dividing by zero gives 0, so every formula has a value.
+
+// expr is a compiled expression, reading its variables by index
+type expr func(vars []float64) float64
+
+// functions are the functions expressions can call, by how many arguments they take
+var functions = map[string]struct {
+	args int
+	call func(args []float64) float64
+}{
+	"min":   {2, func(args []float64) float64 { return min(args[0], args[1]) }},
+	"max":   {2, func(args []float64) float64 { return max(args[0], args[1]) }},
+	"abs":   {1, func(args []float64) float64 { return math.Abs(args[0]) }},
+	"floor": {1, func(args []float64) float64 { return math.Floor(args[0]) }},
+}
+
+// compile parses source into an expression over the named variables
+func compile(source string, names []string) (expr, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, names: names}
+	e, err := p.conditional()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return e, nil
+}
+
+// tokenize splits source into numbers, names and operators
+func tokenize(source string) ([]string, error) {
+	var tokens []string
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case unicode.IsDigit(r) || r == '.':
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+		case strings.ContainsRune("<>=!&|", r) && i+1 < len(runes) && slices.Contains([]string{"<=", ">=", "==", "!=", "&&", "||"}, string(runes[i:i+2])):
+			i += 2
+		case strings.ContainsRune("+-*/%<>!?:(),", r):
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q", r)
+		}
+		tokens = append(tokens, string(runes[start:i]))
+	}
+	return tokens, nil
+}
+
+// parser builds an expression from tokens by recursive descent, one method per precedence level
+// from lowest to highest
+type parser struct {
+	tokens []string
+	pos    int
+	names  []string
+}
+
+// peek returns the next token, empty at the end
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// accept consumes the next token if it is one of ops, returning it
+func (p *parser) accept(ops ...string) (string, bool) {
+	token := p.peek()
+	if token != "" && slices.Contains(ops, token) {
+		p.pos++
+		return token, true
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		if p.peek() == "" {
+			return fmt.Errorf("expected %q at the end", op)
+		}
+		return fmt.Errorf("expected %q, found %q", op, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) conditional() (expr, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.conditional()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.conditional()
+	if err != nil {
+		return nil, err
+	}
+	return func(vars []float64) float64 {
+		if cond(vars) != 0 {
+			return then(vars)
+		}
+		return otherwise(vars)
+	}, nil
+}
+
+// levels are the binary operators, loosest binding first
+var levels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// binary parses operators of the given level and tighter, left to right
+func (p *parser) binary(level int) (expr, error) {
+	if level == len(levels) {
+		return p.unary()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(levels[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOp(op, left, right)
+	}
+}
+
+func binaryOp(op string, a, b expr) expr {
+	switch op {
+	case "||":
+		return func(v []float64) float64 { return truth(a(v) != 0 || b(v) != 0) }
+	case "&&":
+		return func(v []float64) float64 { return truth(a(v) != 0 && b(v) != 0) }
+	case "==":
+		return func(v []float64) float64 { return truth(a(v) == b(v)) }
+	case "!=":
+		return func(v []float64) float64 { return truth(a(v) != b(v)) }
+	case "<":
+		return func(v []float64) float64 { return truth(a(v) < b(v)) }
+	case "<=":
+		return func(v []float64) float64 { return truth(a(v) <= b(v)) }
+	case ">":
+		return func(v []float64) float64 { return truth(a(v) > b(v)) }
+	case ">=":
+		return func(v []float64) float64 { return truth(a(v) >= b(v)) }
+	case "+":
+		return func(v []float64) float64 { return a(v) + b(v) }
+	case "-":
+		return func(v []float64) float64 { return a(v) - b(v) }
+	case "*":
+		return func(v []float64) float64 { return a(v) * b(v) }
+	case "/":
+		return func(v []float64) float64 {
+			if divisor := b(v); divisor != 0 {
+				return a(v) / divisor
+			}
+			return 0
+		}
+	}
+	return func(v []float64) float64 {
+		if divisor := b(v); divisor != 0 {
+			return math.Mod(a(v), divisor)
+		}
+		return 0
+	}
+}
+
+func (p *parser) unary() (expr, error) {
+	op, ok := p.accept("-", "!")
+	if !ok {
+		return p.primary()
+	}
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if op == "-" {
+		return func(v []float64) float64 { return -operand(v) }, nil
+	}
+	return func(v []float64) float64 { return truth(operand(v) == 0) }, nil
+}
+
+// primary parses a number, a variable, a function call or a bracketed expression
+func (p *parser) primary() (expr, error) {
+	token := p.peek()
+	if token == "" {
+		return nil, fmt.Errorf("unexpected end")
+	}
+	p.pos++
+	first := []rune(token)[0]
+	switch {
+	case token == "(":
+		inner, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	case unicode.IsDigit(first) || first == '.':
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", token)
+		}
+		return func([]float64) float64 { return value }, nil
+	case unicode.IsLetter(first) || first == '_':
+		if _, ok := p.accept("("); ok {
+			return p.call(token)
+		}
+		index := slices.Index(p.names, token)
+		if index < 0 {
+			return nil, fmt.Errorf("unknown name %q, can use %s", token, strings.Join(p.names, ", "))
+		}
+		return func(vars []float64) float64 { return vars[index] }, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", token)
+}
+
+// call parses the arguments of a function call, its opening bracket already read
+func (p *parser) call(name string) (expr, error) {
+	function, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	var args []expr
+	for len(args) == 0 || p.peek() == "," {
+		if len(args) > 0 {
+			p.pos++
+		}
+		arg, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if len(args) != function.args {
+		return nil, fmt.Errorf("%s takes %d arguments, not %d", name, function.args, len(args))
+	}
+	return func(vars []float64) float64 {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			values[i] = arg(vars)
+		}
+		return function.call(values)
+	}, nil
+}
+
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
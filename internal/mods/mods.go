@@ -0,0 +1,276 @@
+// Package mods loads community rule variants from JSON files, so new rules need no changes to
+// the game. A mod adjusts the game's config before it starts, such as spawn rates, and reacts to
+// the engine's hooks with expressions over the game's numbers:
+//
+//	{
+//	  "name": "Feast",
+//	  "config": {"maxFood": "maxFood * 2", "bombDivisor": "0"},
+//	  "foodPoints": "food == 1 ? value * 3 : value",
+//	  "onFoodEaten": {"growing": "growing + 1"},
+//	  "onTick": {"points": "ticks % 300 == 0 ? points + 1 : points"},
+//	  "onDeath": {"points": "points + eaten"}
+//	}
+//
+// foodPoints gives the points for food eaten, and the others set state from expressions that are
+// all worked out before any is set. Expressions only see the game, so modded games stay
+// deterministic and rewind like any other.
+package mods
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+// Dir is the folder mods are loaded from, one JSON file each
+const Dir = "mods"
+
+// configVar is a config setting a mod can read and change
+type configVar struct {
+	name string
+	get  func(c *game.Config) float64
+	set  func(c *game.Config, value float64)
+}
+
+var configVars = []configVar{
+	{"width", func(c *game.Config) float64 { return float64(c.Width) }, nil},
+	{"height", func(c *game.Config) float64 { return float64(c.Height) }, nil},
+	{"tickRate", func(c *game.Config) float64 { return float64(c.TickRate) }, func(c *game.Config, v float64) { c.TickRate = float32(max(1, v)) }},
+	{"speedStep", func(c *game.Config) float64 { return float64(c.SpeedStep) }, func(c *game.Config, v float64) { c.SpeedStep = float32(max(0, v)) }},
+	{"maxTickRate", func(c *game.Config) float64 { return float64(c.MaxTickRate) }, func(c *game.Config, v float64) { c.MaxTickRate = float32(max(0, v)) }},
+	{"maxFood", func(c *game.Config) float64 { return float64(c.MaxFood) }, func(c *game.Config, v float64) { c.MaxFood = max(1, int(v)) }},
+	{"foodInterval", func(c *game.Config) float64 { return float64(c.FoodInterval) }, func(c *game.Config, v float64) { c.FoodInterval = float32(max(0, v)) }},
+	{"bombDivisor", func(c *game.Config) float64 { return float64(c.BombDivisor) }, func(c *game.Config, v float64) { c.BombDivisor = max(0, int(v)) }},
+	{"scoreMultiplier", func(c *game.Config) float64 { return float64(c.ScoreMultiplier) }, func(c *game.Config, v float64) { c.ScoreMultiplier = max(0, int(v)) }},
+	{"bombFuse", func(c *game.Config) float64 { return float64(c.BombFuse) }, func(c *game.Config, v float64) { c.BombFuse = float32(max(0, v)) }},
+	{"blastRadius", func(c *game.Config) float64 { return float64(c.BlastRadius) }, func(c *game.Config, v float64) { c.BlastRadius = max(0, int(v)) }},
+	{"powerUpChance", func(c *game.Config) float64 { return float64(c.PowerUpChance) }, func(c *game.Config, v float64) { c.PowerUpChance = chance(v) }},
+	{"goldenChance", func(c *game.Config) float64 { return float64(c.GoldenChance) }, func(c *game.Config, v float64) { c.GoldenChance = chance(v) }},
+	{"poisonChance", func(c *game.Config) float64 { return float64(c.PoisonChance) }, func(c *game.Config, v float64) { c.PoisonChance = chance(v) }},
+	{"portalChance", func(c *game.Config) float64 { return float64(c.PortalChance) }, func(c *game.Config, v float64) { c.PortalChance = chance(v) }},
+	{"growth", func(c *game.Config) float64 { return float64(max(1, c.Growth)) }, func(c *game.Config, v float64) { c.Growth = max(1, int(v)) }},
+}
+
+// stateVar is a number from the game a hook can read, and set if it has a setter
+type stateVar struct {
+	name string
+	get  func(e *game.Engine) float64
+	set  func(e *game.Engine, value float64)
+}
+
+var stateVars = []stateVar{
+	{"points", func(e *game.Engine) float64 { return float64(e.State.Points) }, func(e *game.Engine, v float64) { e.State.Points = max(0, int(v)) }},
+	{"growing", func(e *game.Engine) float64 { return float64(e.State.Growing) }, func(e *game.Engine, v float64) { e.State.Growing = max(0, int(v)) }},
+	{"lives", func(e *game.Engine) float64 { return float64(e.State.Lives) }, func(e *game.Engine, v float64) { e.State.Lives = max(0, int(v)) }},
+	{"length", func(e *game.Engine) float64 { return float64(len(e.State.Snake.Segments)) }, nil},
+	{"eaten", func(e *game.Engine) float64 { return float64(e.State.Eaten) }, nil},
+	{"ticks", func(e *game.Engine) float64 { return float64(e.State.Ticks) }, nil},
+	{"elapsed", func(e *game.Engine) float64 { return float64(e.State.Elapsed) }, nil},
+	{"combo", func(e *game.Engine) float64 { return float64(e.State.Combo) }, nil},
+	{"wraps", func(e *game.Engine) float64 { return float64(e.State.Wraps) }, nil},
+	{"width", func(e *game.Engine) float64 { return float64(e.Config.Width) }, nil},
+	{"height", func(e *game.Engine) float64 { return float64(e.Config.Height) }, nil},
+}
+
+// Names the food hooks see after the state's: the food's kind (0 regular, 1 golden, 2 poison)
+// and, for foodPoints, the points the rules score it
+const (
+	foodName  = "food"
+	valueName = "value"
+)
+
+// assignment sets one config setting or state variable, by index, to an expression's value
+type assignment struct {
+	index int
+	value expr
+}
+
+// Mod is a loaded mod, ready to configure games and hook into their engines
+type Mod struct {
+	Name        string
+	config      []assignment
+	foodPoints  expr
+	onFoodEaten []assignment
+	onTick      []assignment
+	onDeath     []assignment
+}
+
+// modFile is a mod as written in its file, every rule an expression
+type modFile struct {
+	Name        string            `json:"name"`
+	Config      map[string]string `json:"config"`
+	FoodPoints  string            `json:"foodPoints"`
+	OnFoodEaten map[string]string `json:"onFoodEaten"`
+	OnTick      map[string]string `json:"onTick"`
+	OnDeath     map[string]string `json:"onDeath"`
+}
+
+// Load loads every mod in Dir, in file name order. Files that can't be read or have a rule that
+// doesn't compile are skipped, and listed in the error.
+func Load() ([]*Mod, error) {
+	paths, err := filepath.Glob(filepath.Join(Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []*Mod
+	var errs []error
+	for _, path := range paths {
+		mod, err := load(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		loaded = append(loaded, mod)
+	}
+	return loaded, errors.Join(errs...)
+}
+
+// load reads one mod file, named after the file if it doesn't give a name
+func load(path string) (*Mod, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file modFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	mod := &Mod{Name: strings.TrimSpace(file.Name)}
+	if mod.Name == "" {
+		mod.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	configNames := make([]string, len(configVars))
+	for i, v := range configVars {
+		configNames[i] = v.name
+	}
+	settable := func(i int) bool { return configVars[i].set != nil }
+	if mod.config, err = compileAssignments("config", file.Config, configNames, settable); err != nil {
+		return nil, err
+	}
+
+	names := stateNames()
+	settable = func(i int) bool { return i < len(stateVars) && stateVars[i].set != nil }
+	if file.FoodPoints != "" {
+		if mod.foodPoints, err = compile(file.FoodPoints, append(names, foodName, valueName)); err != nil {
+			return nil, fmt.Errorf("foodPoints: %w", err)
+		}
+	}
+	if mod.onFoodEaten, err = compileAssignments("onFoodEaten", file.OnFoodEaten, append(names, foodName), settable); err != nil {
+		return nil, err
+	}
+	if mod.onTick, err = compileAssignments("onTick", file.OnTick, names, settable); err != nil {
+		return nil, err
+	}
+	if mod.onDeath, err = compileAssignments("onDeath", file.OnDeath, names, settable); err != nil {
+		return nil, err
+	}
+	return mod, nil
+}
+
+func stateNames() []string {
+	names := make([]string, len(stateVars))
+	for i, v := range stateVars {
+		names[i] = v.name
+	}
+	return names
+}
+
+// compileAssignments compiles a hook's rules, in name order so they always run the same way
+func compileAssignments(hook string, rules map[string]string, names []string, settable func(int) bool) ([]assignment, error) {
+	targets := make([]string, 0, len(rules))
+	for target := range rules {
+		targets = append(targets, target)
+	}
+	slices.Sort(targets)
+
+	assignments := make([]assignment, 0, len(rules))
+	for _, target := range targets {
+		index := slices.Index(names, target)
+		if index < 0 || !settable(index) {
+			return nil, fmt.Errorf("%s: %q can't be set", hook, target)
+		}
+		value, err := compile(rules[target], names)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", hook, target, err)
+		}
+		assignments = append(assignments, assignment{index: index, value: value})
+	}
+	return assignments, nil
+}
+
+// Configure applies the mod's config rules to a game's config before it starts
+func (m *Mod) Configure(config *game.Config) {
+	vars := make([]float64, len(configVars))
+	for i, v := range configVars {
+		vars[i] = v.get(config)
+	}
+	values := evaluate(m.config, vars)
+	for i, a := range m.config {
+		configVars[a.index].set(config, values[i])
+	}
+}
+
+// FoodPoints implements game.Mod
+func (m *Mod) FoodPoints(e *game.Engine, food game.Entity, points int) int {
+	if m.foodPoints == nil {
+		return points
+	}
+	return int(m.foodPoints(append(readState(e), float64(food.FoodKind()), float64(points))))
+}
+
+// OnFoodEaten implements game.Mod
+func (m *Mod) OnFoodEaten(e *game.Engine, food game.Entity) {
+	apply(e, m.onFoodEaten, append(readState(e), float64(food.FoodKind())))
+}
+
+// OnTick implements game.Mod
+func (m *Mod) OnTick(e *game.Engine) {
+	apply(e, m.onTick, readState(e))
+}
+
+// OnDeath implements game.Mod
+func (m *Mod) OnDeath(e *game.Engine) {
+	apply(e, m.onDeath, readState(e))
+}
+
+// readState reads every state variable, in stateVars order
+func readState(e *game.Engine) []float64 {
+	vars := make([]float64, len(stateVars), len(stateVars)+2)
+	for i, v := range stateVars {
+		vars[i] = v.get(e)
+	}
+	return vars
+}
+
+// apply sets the state from a hook's rules, all worked out before any is set
+func apply(e *game.Engine, assignments []assignment, vars []float64) {
+	if len(assignments) == 0 {
+		return
+	}
+	values := evaluate(assignments, vars)
+	for i, a := range assignments {
+		stateVars[a.index].set(e, values[i])
+	}
+}
+
+func evaluate(assignments []assignment, vars []float64) []float64 {
+	values := make([]float64, len(assignments))
+	for i, a := range assignments {
+		values[i] = a.value(vars)
+	}
+	return values
+}
+
+// chance keeps a per-tick chance between 0 and 1
+func chance(v float64) float32 {
+	return float32(min(1, max(0, v)))
+}
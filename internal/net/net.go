@@ -0,0 +1,198 @@
+// Package net links two games over the local network for versus play. The host runs the game and
+// sends the state after every tick; the guest only sends its turns and draws what it is sent.
+//
+// Messages are gob encoded over one TCP connection. Gob sends each type's layout once and leaves
+// out empty fields, so a state is little more than its snakes, food and bombs.
+package net
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+const (
+	DefaultPort  = 7373
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 2 * time.Second
+	inboxSize    = 64 // Messages buffered from the peer before its reader waits
+)
+
+// Kind is what a message carries
+type Kind int
+
+const (
+	KindWelcome Kind = iota // Host to guest once it joins: the game's config, with the countdown starting
+	KindState               // Host to guest at the start and after each tick
+	KindInput               // Guest to host: a turn
+	KindEnd                 // Host to guest: the match is over, with the final scores
+)
+
+// Message is one message on the wire. Only the fields its kind uses are set.
+type Message struct {
+	Kind      Kind
+	Config    *game.Config   // KindWelcome
+	State     *game.State    // KindState, without the walls already sent in the config
+	Direction game.Direction // KindInput
+	Scores    [2]int         // KindEnd: the host's points, then the guest's
+}
+
+// Conn is a connection to the other player. Messages are read in the background and picked up
+// with Receive, so the game loop never waits on the network.
+type Conn struct {
+	conn    net.Conn
+	encoder *gob.Encoder
+	inbox   chan Message
+	closed  bool          // The peer left or the connection failed, seen by Receive
+	done    chan struct{} // Closed by Close, so the reader stops waiting on a full inbox
+	once    sync.Once
+
+	mu  sync.Mutex
+	err error // Why the reader stopped
+}
+
+func newConn(conn net.Conn) *Conn {
+	c := &Conn{conn: conn, encoder: gob.NewEncoder(conn), inbox: make(chan Message, inboxSize), done: make(chan struct{})}
+	go c.read()
+	return c
+}
+
+// read decodes messages into the inbox until the connection ends
+func (c *Conn) read() {
+	decoder := gob.NewDecoder(c.conn)
+	for {
+		// Decode into a fresh message, since gob leaves fields missing from the wire untouched
+		var message Message
+		if err := decoder.Decode(&message); err != nil {
+			c.mu.Lock()
+			c.err = err
+			c.mu.Unlock()
+			close(c.inbox)
+			return
+		}
+		select {
+		case c.inbox <- message:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Send writes a message to the peer
+func (c *Conn) Send(message Message) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return c.encoder.Encode(message)
+}
+
+// Receive returns the next message from the peer if one has arrived
+func (c *Conn) Receive() (Message, bool) {
+	select {
+	case message, ok := <-c.inbox:
+		if !ok {
+			c.closed = true
+		}
+		return message, ok
+	default:
+		return Message{}, false
+	}
+}
+
+// Closed reports whether the connection has ended, once Receive has used up its messages
+func (c *Conn) Closed() bool {
+	return c.closed
+}
+
+// Err returns why the connection ended, nil while it is open
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close ends the connection and stops the reader. It is safe to call more than once.
+func (c *Conn) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return c.conn.Close()
+}
+
+// RemoteAddr returns the peer's address
+func (c *Conn) RemoteAddr() string {
+	return c.conn.RemoteAddr().String()
+}
+
+// Host waits for a guest to join. Only the first one to connect is taken.
+type Host struct {
+	listener net.Listener
+	joined   chan *Conn
+}
+
+// Listen starts hosting on the port, on every network interface
+func Listen(port int) (*Host, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, err
+	}
+	h := &Host{listener: listener, joined: make(chan *Conn, 1)}
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			h.joined <- newConn(conn)
+		}
+		listener.Close()
+	}()
+	return h, nil
+}
+
+// Joined returns the guest's connection once one has joined, nil until then
+func (h *Host) Joined() *Conn {
+	select {
+	case conn := <-h.joined:
+		return conn
+	default:
+		return nil
+	}
+}
+
+// Close stops waiting for a guest
+func (h *Host) Close() error {
+	err := h.listener.Close()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// Dial joins the game hosted at address, a host name or IP with an optional port
+func Dial(address string) (*Conn, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, fmt.Sprint(DefaultPort))
+	}
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(conn), nil
+}
+
+// LocalAddresses lists this machine's IPv4 addresses on the local network, for the guest to
+// join with
+func LocalAddresses() []string {
+	var addresses []string
+	interfaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	for _, addr := range interfaceAddrs {
+		if ip, ok := addr.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+			addresses = append(addresses, ip.IP.String())
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,188 @@
+// Package particles draws short-lived sparks for effects such as eating food or an explosion.
+// A System keeps its particles in a fixed pool so effects don't allocate while playing, along with
+// any bigger bodies thrown across the board, such as a hat knocked off a snake.
+package particles
+
+import (
+	"math"
+	"math/rand/v2"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Effect tunes how the particles of one kind of effect are thrown out and how they fade
+type Effect struct {
+	Count    int        // Particles per emit
+	Speed    float32    // Fastest starting speed, in pixels per second
+	MinSpeed float32    // Slowest starting speed as a fraction of Speed
+	Spread   float32    // Range of directions around Angle, in degrees. 360 for all round
+	Angle    float32    // Direction the spread is centered on, in degrees
+	Life     float32    // Seconds a particle lasts
+	Drag     float32    // How quickly particles slow down
+	Gravity  float32    // Downwards pull, in pixels per second squared
+	Size     float32    // Side of a particle's square, shrinking to half as it fades
+	Colors   []rl.Color // Each particle takes one at random
+}
+
+var (
+	// FoodBurst is a small pop where food was eaten
+	FoodBurst = Effect{Count: 8, Speed: 80, MinSpeed: 0.4, Spread: 360, Life: 0.4, Drag: 4, Size: 3, Colors: []rl.Color{rl.Gold, rl.Yellow}}
+
+	// GoldenBurst is a big shower of gold for a golden apple
+	GoldenBurst = Effect{Count: 24, Speed: 120, MinSpeed: 0.3, Spread: 360, Life: 0.8, Drag: 3, Size: 4, Colors: []rl.Color{{R: 255, G: 215, B: 60, A: 255}, rl.Orange, rl.White}}
+
+	// Explosion is fire and smoke thrown out of a bomb
+	Explosion = Effect{Count: 30, Speed: 200, MinSpeed: 0.2, Spread: 360, Life: 0.6, Drag: 5, Size: 5, Colors: []rl.Color{rl.Orange, rl.Red, rl.Yellow, rl.DarkGray}}
+
+	// Dissolve crumbles one snake segment, emitted per segment when a snake dies
+	Dissolve = Effect{Count: 4, Speed: 40, MinSpeed: 0.2, Spread: 360, Life: 0.9, Drag: 1, Gravity: 60, Size: 4, Colors: []rl.Color{rl.Green, rl.DarkGreen}}
+
+	// Sparkle is a single twinkle left behind a moving snake
+	Sparkle = Effect{Count: 1, Speed: 20, MinSpeed: 0, Spread: 360, Life: 0.5, Drag: 2, Size: 2, Colors: []rl.Color{rl.White}}
+)
+
+// Toss tunes how a body is thrown: up, to a random side, and spinning the way it goes
+type Toss struct {
+	Speed   float32 // Starting speed, in pixels per second
+	Spread  float32 // Range of directions around straight up, in degrees
+	Spin    float32 // Degrees per second
+	Gravity float32 // Downwards pull, in pixels per second squared
+}
+
+// HatToss knocks a hat up off a crashed snake's head, to tumble off the board
+var HatToss = Toss{Speed: 260, Spread: 60, Spin: 540, Gravity: 900}
+
+// Body is one bigger piece flying across the board. The caller draws it at its position and angle.
+type Body struct {
+	Position rl.Vector2
+	Velocity rl.Vector2
+	Angle    float32 // Degrees
+	spin     float32
+	gravity  float32
+	floor    float32
+}
+
+// Tinted returns a copy of the effect with its particles in one color
+func (e Effect) Tinted(color rl.Color) Effect {
+	e.Colors = []rl.Color{color}
+	return e
+}
+
+type particle struct {
+	position  rl.Vector2
+	velocity  rl.Vector2
+	remaining float32
+	life      float32
+	drag      float32
+	gravity   float32
+	size      float32
+	color     rl.Color
+}
+
+// System owns a pool of particles. The live ones are kept at the front of the pool.
+type System struct {
+	pool   []particle
+	live   int
+	bodies []Body
+}
+
+// New makes a system that can show up to capacity particles at once. Emits past that are dropped.
+func New(capacity int) *System {
+	return &System{pool: make([]particle, capacity)}
+}
+
+// Emit throws out an effect's particles from a point
+func (s *System) Emit(effect Effect, position rl.Vector2) {
+	for range effect.Count {
+		if s.live == len(s.pool) {
+			return
+		}
+		angle := float64(effect.Angle+(rand.Float32()-0.5)*effect.Spread) * math.Pi / 180
+		speed := effect.Speed * (effect.MinSpeed + (1-effect.MinSpeed)*rand.Float32())
+		color := rl.White
+		if len(effect.Colors) > 0 {
+			color = effect.Colors[rand.IntN(len(effect.Colors))]
+		}
+		s.pool[s.live] = particle{
+			position:  position,
+			velocity:  rl.Vector2{X: float32(math.Cos(angle)) * speed, Y: float32(math.Sin(angle)) * speed},
+			remaining: effect.Life,
+			life:      effect.Life,
+			drag:      effect.Drag,
+			gravity:   effect.Gravity,
+			size:      effect.Size,
+			color:     color,
+		}
+		s.live++
+	}
+}
+
+// Throw tosses a body from a point. It flies until it falls below floor.
+func (s *System) Throw(toss Toss, position rl.Vector2, floor float32) {
+	angle := float64(-90+(rand.Float32()-0.5)*toss.Spread) * math.Pi / 180
+	velocity := rl.Vector2{X: float32(math.Cos(angle)) * toss.Speed, Y: float32(math.Sin(angle)) * toss.Speed}
+	spin := toss.Spin
+	if velocity.X < 0 {
+		spin = -spin
+	}
+	s.bodies = append(s.bodies, Body{Position: position, Velocity: velocity, spin: spin, gravity: toss.Gravity, floor: floor})
+}
+
+// Bodies returns the bodies still flying
+func (s *System) Bodies() []Body {
+	return s.bodies
+}
+
+// Update moves every particle and body on by dt seconds, returning expired particles to the pool
+// and dropping bodies that have fallen out of sight
+func (s *System) Update(dt float32) {
+	bodies := s.bodies[:0]
+	for _, b := range s.bodies {
+		b.Velocity.Y += b.gravity * dt
+		b.Position.X += b.Velocity.X * dt
+		b.Position.Y += b.Velocity.Y * dt
+		b.Angle += b.spin * dt
+		if b.Position.Y < b.floor {
+			bodies = append(bodies, b)
+		}
+	}
+	s.bodies = bodies
+
+	for i := 0; i < s.live; {
+		p := &s.pool[i]
+		p.remaining -= dt
+		if p.remaining <= 0 {
+			// Swap the last live particle into the freed slot
+			s.live--
+			s.pool[i] = s.pool[s.live]
+			continue
+		}
+		p.position.X += p.velocity.X * dt
+		p.position.Y += p.velocity.Y * dt
+		p.velocity.X -= p.velocity.X * p.drag * dt
+		p.velocity.Y -= p.velocity.Y*p.drag*dt - p.gravity*dt
+		i++
+	}
+}
+
+// Draw draws each particle as a square, shrinking and fading out with age
+func (s *System) Draw() {
+	for _, p := range s.pool[:s.live] {
+		age := p.remaining / p.life
+		size := p.size * (0.5 + 0.5*age)
+		color := p.color
+		color.A = uint8(float32(color.A) * age)
+		rl.DrawRectangleV(rl.Vector2{X: p.position.X - size/2, Y: p.position.Y - size/2}, rl.Vector2{X: size, Y: size}, color)
+	}
+}
+
+// Clear removes every particle and body
+func (s *System) Clear() {
+	s.live = 0
+	s.bodies = s.bodies[:0]
+}
+
+// Live returns how many particles are showing
+func (s *System) Live() int {
+	return s.live
+}
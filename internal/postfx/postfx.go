@@ -0,0 +1,277 @@
+package postfx
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// CustomShaderFile is an optional user fragment shader used in place of the built-in effects.
+// It is given the same inputs as the built-in shader, plus these uniforms:
+//
+//	uniform float time;       // Seconds since the window opened
+//	uniform vec2 resolution;  // Render texture size in pixels
+//	uniform float score;      // Current score
+const CustomShaderFile = "shaders/custom.fs"
+
+// Effect identifies one of the built-in post-processing effects
+type Effect int
+
+const (
+	EffectScanlines Effect = iota
+	EffectVignette
+	EffectBloom
+	EffectCurvature // Bulges the picture like a tube screen
+	EffectPixelate  // Draws the frame in bigger pixels
+	EffectCount
+)
+
+var effectNames = [EffectCount]string{"Scanlines", "Vignette", "Bloom", "Curvature", "Pixelate"}
+
+func (e Effect) String() string {
+	return effectNames[e]
+}
+
+var effectUniforms = [EffectCount]string{"scanlines", "vignette", "bloom", "curvature", "pixelate"}
+
+// Pipeline renders the game into a texture and draws it back through the post-processing shader
+type Pipeline struct {
+	Enabled   bool
+	Supported bool
+	UseCustom bool                 // Draw through the custom shader instead of the built-in effects
+	Intensity [EffectCount]float32 // 0-1 per effect
+	Output    *rl.RenderTexture2D  // Where End draws the processed frame, the screen when nil
+
+	target        rl.RenderTexture2D
+	shader        rl.Shader
+	resolutionLoc int32
+	intensityLocs [EffectCount]int32
+	width         int32
+	height        int32
+	score         float32
+
+	custom          rl.Shader
+	customLoaded    bool
+	customModTime   time.Time
+	customCheckTime float64
+}
+
+// NewPipeline creates a pipeline for the given screen size.
+// Effects are left unsupported on GL 1.1/ES 2.0 hardware, where the 330 shader can't compile.
+func NewPipeline(width, height int32) *Pipeline {
+	p := &Pipeline{
+		Intensity: [EffectCount]float32{0.5, 0.5, 0.5, 0, 0},
+		width:     width,
+		height:    height,
+	}
+
+	version := rl.GetVersion()
+	if version != rl.Opengl33 && version != rl.Opengl43 {
+		return p
+	}
+
+	p.shader = rl.LoadShaderFromMemory("", effectsShader)
+	if !rl.IsShaderValid(p.shader) {
+		return p
+	}
+	p.target = rl.LoadRenderTexture(width, height)
+	p.resolutionLoc = rl.GetShaderLocation(p.shader, "resolution")
+	for i := range p.intensityLocs {
+		p.intensityLocs[i] = rl.GetShaderLocation(p.shader, effectUniforms[i])
+	}
+	p.Supported = true
+
+	p.loadCustomShader()
+	return p
+}
+
+// HasCustom reports whether a valid custom shader is loaded
+func (p *Pipeline) HasCustom() bool {
+	return p.customLoaded
+}
+
+// SetScore updates the score passed to the custom shader
+func (p *Pipeline) SetScore(score int) {
+	p.score = float32(score)
+}
+
+// ReloadCustomIfChanged reloads the custom shader when its file changes, checked at most once a second.
+// A shader that fails to compile is skipped and the previous one is kept.
+func (p *Pipeline) ReloadCustomIfChanged() {
+	if !p.Supported || rl.GetTime()-p.customCheckTime < 1 {
+		return
+	}
+	p.customCheckTime = rl.GetTime()
+
+	info, err := os.Stat(CustomShaderFile)
+	if err != nil || info.ModTime().Equal(p.customModTime) {
+		return
+	}
+	fmt.Println("Reloading custom shader")
+	p.loadCustomShader()
+}
+
+func (p *Pipeline) loadCustomShader() {
+	info, err := os.Stat(CustomShaderFile)
+	if err != nil {
+		return
+	}
+	p.customModTime = info.ModTime()
+
+	code, err := os.ReadFile(CustomShaderFile)
+	if err != nil {
+		fmt.Println("Failed to read custom shader:", err)
+		return
+	}
+	shader := rl.LoadShaderFromMemory("", string(code))
+	if !rl.IsShaderValid(shader) {
+		fmt.Println("Failed to compile custom shader, keeping the previous one")
+		return
+	}
+
+	if p.customLoaded {
+		rl.UnloadShader(p.custom)
+	}
+	p.custom = shader
+	p.customLoaded = true
+	fmt.Println("Custom shader loaded successfully")
+}
+
+// Active reports whether frames are currently routed through the pipeline
+func (p *Pipeline) Active() bool {
+	return p.Enabled && p.Supported
+}
+
+// Begin redirects drawing into the render texture, call after rl.BeginDrawing
+func (p *Pipeline) Begin() {
+	if !p.Active() {
+		return
+	}
+	rl.BeginTextureMode(p.target)
+}
+
+// End draws the render texture to the output through the effects shader, call before rl.EndDrawing
+func (p *Pipeline) End() {
+	if !p.Active() {
+		return
+	}
+	// Texture modes don't nest, so switch back to the output target after leaving ours
+	rl.EndTextureMode()
+	if p.Output != nil {
+		rl.BeginTextureMode(*p.Output)
+	}
+	p.Draw(p.target.Texture, p.width, p.height)
+}
+
+// Draw draws a render texture of the given size at the origin through the current effects shader.
+// Without active effects the texture is drawn as is.
+func (p *Pipeline) Draw(texture rl.Texture2D, width, height int32) {
+	active := p.Active()
+	if active {
+		p.beginShader(width, height)
+	}
+	// Render textures are stored upside down, so flip the source rectangle
+	rl.DrawTextureRec(
+		texture,
+		rl.NewRectangle(0, 0, float32(width), -float32(height)),
+		rl.Vector2{X: 0, Y: 0},
+		rl.White,
+	)
+	if active {
+		rl.EndShaderMode()
+	}
+}
+
+func (p *Pipeline) beginShader(width, height int32) {
+	resolution := []float32{float32(width), float32(height)}
+	shader := p.shader
+	if p.UseCustom && p.customLoaded {
+		shader = p.custom
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "time"), []float32{float32(rl.GetTime())}, rl.ShaderUniformFloat)
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "resolution"), resolution, rl.ShaderUniformVec2)
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "score"), []float32{p.score}, rl.ShaderUniformFloat)
+	} else {
+		rl.SetShaderValue(shader, p.resolutionLoc, resolution, rl.ShaderUniformVec2)
+		for i, loc := range p.intensityLocs {
+			rl.SetShaderValue(shader, loc, []float32{p.Intensity[i]}, rl.ShaderUniformFloat)
+		}
+	}
+	rl.BeginShaderMode(shader)
+}
+
+func (p *Pipeline) Unload() {
+	if !p.Supported {
+		return
+	}
+	rl.UnloadRenderTexture(p.target)
+	rl.UnloadShader(p.shader)
+	if p.customLoaded {
+		rl.UnloadShader(p.custom)
+	}
+}
+
+const effectsShader = `#version 330
+
+in vec2 fragTexCoord;
+in vec4 fragColor;
+
+uniform sampler2D texture0;
+uniform vec4 colDiffuse;
+uniform vec2 resolution;
+uniform float scanlines;
+uniform float vignette;
+uniform float bloom;
+uniform float curvature;
+uniform float pixelate;
+
+out vec4 finalColor;
+
+void main()
+{
+    vec2 uv = fragTexCoord;
+
+    // Curvature: bulge the picture out from the middle, black past the curved edges
+    if (curvature > 0.0) {
+        vec2 centered = uv * 2.0 - 1.0;
+        centered += centered * centered.yx * centered.yx * curvature * 0.3;
+        uv = centered * 0.5 + 0.5;
+        if (uv.x < 0.0 || uv.x > 1.0 || uv.y < 0.0 || uv.y > 1.0) {
+            finalColor = vec4(0.0, 0.0, 0.0, 1.0);
+            return;
+        }
+    }
+
+    // Pixelate: sample the middle of blocks up to 8 pixels across
+    if (pixelate > 0.0) {
+        vec2 block = vec2(1.0 + floor(pixelate * 7.0)) / resolution;
+        uv = (floor(uv / block) + 0.5) * block;
+    }
+
+    vec4 color = texture(texture0, uv);
+
+    // Bloom: add the bright parts of a small blurred neighbourhood
+    if (bloom > 0.0) {
+        vec2 texel = 2.0 / resolution;
+        vec3 glow = vec3(0.0);
+        for (int x = -2; x <= 2; x++) {
+            for (int y = -2; y <= 2; y++) {
+                vec3 neighbour = texture(texture0, uv + vec2(x, y) * texel).rgb;
+                glow += max(neighbour - vec3(0.6), vec3(0.0));
+            }
+        }
+        color.rgb += glow / 25.0 * bloom * 3.0;
+    }
+
+    // Scanlines: darken every other pixel row
+    float line = 0.5 + 0.5 * sin(uv.y * resolution.y * 3.14159);
+    color.rgb *= 1.0 - scanlines * 0.4 * line;
+
+    // Vignette: fade the corners
+    float dist = distance(uv, vec2(0.5));
+    color.rgb *= 1.0 - vignette * smoothstep(0.3, 0.8, dist);
+
+    finalColor = color * colDiffuse * fragColor;
+}
+`
@@ -0,0 +1,108 @@
+// Package profiles keeps the named player profiles on this machine, so players sharing it each
+// have their own settings, stats and achievements. The first profile keeps the files from before
+// there were profiles, and every profile made since keeps its own in a folder under Dir.
+package profiles
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	listFile    = "profiles.json"
+	Dir         = "profiles"
+	DefaultName = "Player" // The first profile's name, until it is renamed
+	MaxProfiles = 6
+)
+
+var (
+	ErrTaken = errors.New("a profile already has that name")
+	ErrFull  = errors.New("no room for another profile")
+)
+
+// Profile is one player's profile
+type Profile struct {
+	Name string `json:"name"`
+	Dir  string `json:"dir,omitempty"` // Folder holding its files, empty for the first profile's
+}
+
+// List is every profile, and which one is in use
+type List struct {
+	Profiles []Profile `json:"profiles"`
+	Active   int       `json:"active"`
+}
+
+// Load reads the profile list. Without one there is a single profile, using the files from
+// before there were profiles.
+func Load() (List, error) {
+	list := List{Profiles: []Profile{{Name: DefaultName}}}
+	data, err := os.ReadFile(listFile)
+	if os.IsNotExist(err) {
+		return list, nil
+	} else if err != nil {
+		return list, err
+	}
+
+	var loaded List
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return list, err
+	}
+	if len(loaded.Profiles) == 0 {
+		return list, nil
+	}
+	if loaded.Active < 0 || loaded.Active >= len(loaded.Profiles) {
+		loaded.Active = 0
+	}
+	return loaded, nil
+}
+
+func Save(list List) error {
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(listFile, data, 0644)
+}
+
+// Current returns the profile in use
+func (l *List) Current() Profile {
+	return l.Profiles[l.Active]
+}
+
+// Add creates a profile with a folder of its own, returning its index. Names are compared
+// without case.
+func (l *List) Add(name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if len(l.Profiles) >= MaxProfiles {
+		return 0, ErrFull
+	}
+	for _, profile := range l.Profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return 0, ErrTaken
+		}
+	}
+
+	dir := freeDir(name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, err
+	}
+	l.Profiles = append(l.Profiles, Profile{Name: name, Dir: dir})
+	return len(l.Profiles) - 1, nil
+}
+
+// freeDir picks a folder for a new profile named after it, numbered if a folder of that name is
+// already there, such as one left by a profile removed by hand
+func freeDir(name string) string {
+	base := strings.ToLower(strings.ReplaceAll(name, " ", "_"))
+	dir := filepath.Join(Dir, base)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return dir
+		}
+		dir = filepath.Join(Dir, base+strconv.Itoa(i))
+	}
+}
@@ -0,0 +1,113 @@
+// Package render draws the board from a texture atlas: snakes whose tiles follow how their
+// segments join up, and food and bomb sprites. Tiles are light so they can be tinted any color.
+package render
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// Tile is one square of the atlas, which has every tile side by side in a single row
+type Tile int
+
+const (
+	TileHead   Tile = iota // Facing right, with eyes
+	TileBody               // Straight, joining the left and right edges
+	TileCorner             // Joining the left and bottom edges
+	TileTail               // Joined on the right, tapering to a tip on the left
+	TileApple
+	TileBomb
+	TileCount
+)
+
+// Atlas is the loaded tile texture
+type Atlas struct {
+	texture rl.Texture2D
+	size    float32 // Side of a tile in texels
+}
+
+// NewAtlas uses a loaded texture as an atlas, which must be TileCount square tiles in a row.
+// The texture stays owned by whoever loaded it.
+func NewAtlas(texture rl.Texture2D) (*Atlas, error) {
+	if texture.Width != texture.Height*int32(TileCount) {
+		return nil, fmt.Errorf("atlas should be %d square tiles in a row", TileCount)
+	}
+	return &Atlas{texture: texture, size: float32(texture.Height)}, nil
+}
+
+// Draw draws a tile filling dest, turned clockwise by rotation degrees about its center
+func (a *Atlas) Draw(tile Tile, dest rl.Rectangle, rotation float32, tint rl.Color) {
+	source := rl.NewRectangle(float32(tile)*a.size, 0, a.size, a.size)
+	origin := rl.Vector2{X: dest.Width / 2, Y: dest.Height / 2}
+	dest.X += origin.X
+	dest.Y += origin.Y
+	rl.DrawTexturePro(a.texture, source, dest, origin, rotation, tint)
+}
+
+// Piece is the tile a snake segment is drawn with, and how far it is turned
+type Piece struct {
+	Tile     Tile
+	Rotation float32
+}
+
+// SnakePieces works out the tile of each segment from its neighbours. The board size is needed
+// so segments that wrapped across an edge still join up.
+func SnakePieces(snake game.Snake, width, height int) []Piece {
+	segments := snake.Segments
+	pieces := make([]Piece, len(segments))
+	for i, segment := range segments {
+		switch {
+		case i == 0 && len(segments) == 1:
+			pieces[i] = Piece{Tile: TileHead, Rotation: angle(snake.Direction)}
+		case i == 0:
+			pieces[i] = Piece{Tile: TileHead, Rotation: angle(step(segments[1], segment, width, height))}
+		case i == len(segments)-1:
+			pieces[i] = Piece{Tile: TileTail, Rotation: angle(step(segment, segments[i-1], width, height))}
+		default:
+			toHead := angle(step(segment, segments[i-1], width, height))
+			toTail := angle(step(segment, segments[i+1], width, height))
+			if int(toHead-toTail+360)%180 == 0 {
+				pieces[i] = Piece{Tile: TileBody, Rotation: toHead}
+				continue
+			}
+			// The corner tile joins 180 and 90 degrees, so turn it until its lower edge matches
+			// whichever side is a quarter turn anticlockwise of the other
+			first := toHead
+			if int(toHead+90)%360 != int(toTail) {
+				first = toTail
+			}
+			pieces[i] = Piece{Tile: TileCorner, Rotation: float32(int(first+270) % 360)}
+		}
+	}
+	return pieces
+}
+
+// step returns the direction from one cell to a neighbouring one, across the board edge if
+// they are on opposite sides
+func step(from, to game.Point, width, height int) game.Direction {
+	return game.Direction{X: wrapDelta(to.X-from.X, width), Y: wrapDelta(to.Y-from.Y, height)}
+}
+
+func wrapDelta(delta, size int) int {
+	if delta > 1 {
+		delta -= size
+	} else if delta < -1 {
+		delta += size
+	}
+	return max(-1, min(1, delta))
+}
+
+// angle returns the clockwise rotation in degrees that turns a tile facing right to face d
+func angle(d game.Direction) float32 {
+	switch d {
+	case game.Down:
+		return 90
+	case game.Left:
+		return 180
+	case game.Up:
+		return 270
+	}
+	return 0
+}
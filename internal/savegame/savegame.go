@@ -0,0 +1,65 @@
+// Package savegame keeps a run that was quit part way through, so it can be continued later. Each
+// profile keeps its own, in its directory.
+package savegame
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+const saveFile = "savegame.json"
+
+// Run is everything needed to carry on a game exactly where it was left
+type Run struct {
+	Level      string      `json:"level"`
+	Difficulty string      `json:"difficulty"`
+	Board      string      `json:"board"`
+	Transform  string      `json:"transform,omitempty"` // How the level was turned round, see levels.Transform
+	Config     game.Config `json:"config"`
+	State      game.State  `json:"state"`
+	Seed       uint64      `json:"seed"`
+	Random     []byte      `json:"random"` // The spawn generator's state, see game.Engine.Random
+}
+
+// Exists reports whether there is a run saved in dir to continue
+func Exists(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, saveFile))
+	return err == nil
+}
+
+// Load reads the run saved in dir, or nil if there isn't one
+func Load(dir string) (*Run, error) {
+	data, err := os.ReadFile(filepath.Join(dir, saveFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	run := &Run{}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Save writes the run to dir, replacing any run saved there before
+func Save(dir string, run *Run) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, saveFile), data, 0644)
+}
+
+// Delete removes the run saved in dir, once it has ended
+func Delete(dir string) error {
+	err := os.Remove(filepath.Join(dir, saveFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
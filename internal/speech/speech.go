@@ -0,0 +1,96 @@
+// Package speech reads text aloud with the operating system's text to speech, for players who
+// navigate the menus by keyboard and can't easily see them. It runs the system's speech command:
+// say on macOS, the built-in synthesizer through PowerShell on Windows, and spd-say or espeak
+// elsewhere. Without one, nothing is spoken.
+package speech
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// command is a speech command, with how it takes the text
+type command struct {
+	name  string
+	args  []string
+	stdin bool // The text is written to the command's input rather than passed as an argument
+}
+
+// commands are the speech commands tried on each system, in order of preference
+var commands = map[string][]command{
+	"darwin": {{name: "say"}},
+	"windows": {{
+		name:  "powershell",
+		args:  []string{"-NoProfile", "-Command", "Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak([Console]::In.ReadToEnd())"},
+		stdin: true,
+	}},
+	"other": {
+		{name: "spd-say", args: []string{"--wait"}},
+		{name: "espeak-ng"},
+		{name: "espeak"},
+	},
+}
+
+// Speaker speaks one line at a time in the background. A line asked for while another is being
+// spoken waits, and replaces any line already waiting, so speech keeps up with the player.
+type Speaker struct {
+	Enabled bool
+
+	command *command
+	lines   chan string
+}
+
+// New finds the system's speech command and starts the speaker
+func New() *Speaker {
+	s := &Speaker{lines: make(chan string, 1)}
+	options, ok := commands[runtime.GOOS]
+	if !ok {
+		options = commands["other"]
+	}
+	for _, c := range options {
+		if _, err := exec.LookPath(c.name); err == nil {
+			s.command = &c
+			go s.run()
+			break
+		}
+	}
+	return s
+}
+
+// Available reports whether the system has a speech command
+func (s *Speaker) Available() bool {
+	return s.command != nil
+}
+
+// Say speaks text while the speaker is enabled, without waiting for it to be spoken
+func (s *Speaker) Say(text string) {
+	text = strings.TrimSpace(text)
+	if !s.Enabled || s.command == nil || text == "" {
+		return
+	}
+	// Drop the line already waiting, if there is one, as it is out of date
+	select {
+	case <-s.lines:
+	default:
+	}
+	select {
+	case s.lines <- text:
+	default:
+	}
+}
+
+func (s *Speaker) run() {
+	for text := range s.lines {
+		cmd := exec.Command(s.command.name, s.command.args...)
+		if s.command.stdin {
+			cmd.Stdin = strings.NewReader(text)
+		} else {
+			cmd.Args = append(cmd.Args, text)
+		}
+		if err := cmd.Run(); err != nil {
+			fmt.Println("Failed to speak:", err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MinRuns is how many runs a setting needs before the balance report ranks it, as fewer say
+// more about luck than the setting
+const MinRuns = 3
+
+// Metric is what the balance report ranks settings by
+type Metric int
+
+const (
+	MetricDuration Metric = iota // Average seconds a run lasted
+	MetricBombed                 // Share of runs that died to a bomb
+	MetricScore                  // Average score
+	MetricCount
+)
+
+var metricNames = [MetricCount]string{"Shortest runs", "Most bomb deaths", "Lowest scores"}
+
+func (m Metric) String() string {
+	return metricNames[m]
+}
+
+// Next cycles to the following metric, wrapping back to the first
+func (m Metric) Next() Metric {
+	return (m + 1) % MetricCount
+}
+
+// value is what a run adds to a setting's total for the metric
+func (m Metric) value(game Game) float32 {
+	switch m {
+	case MetricBombed:
+		if game.Bombed {
+			return 1
+		}
+		return 0
+	case MetricScore:
+		return float32(game.Score)
+	}
+	return game.Duration
+}
+
+// Bar is one setting in the balance report
+type Bar struct {
+	Setting string
+	Runs    int
+	Value   float32 // Average seconds, share of runs from 0 to 1, or average score, as the metric measures
+}
+
+// Report ranks every setting played in at least MinRuns of the runs by metric, hardest first: the
+// shortest runs, the most bomb deaths or the lowest scores
+func Report(history []Game, metric Metric) []Bar {
+	totals := make(map[string]*Bar)
+	for _, game := range history {
+		value := metric.value(game)
+		for _, setting := range game.Setup {
+			bar, ok := totals[setting]
+			if !ok {
+				bar = &Bar{Setting: setting}
+				totals[setting] = bar
+			}
+			bar.Runs++
+			bar.Value += value
+		}
+	}
+
+	bars := make([]Bar, 0, len(totals))
+	for _, bar := range totals {
+		if bar.Runs >= MinRuns {
+			bar.Value /= float32(bar.Runs)
+			bars = append(bars, *bar)
+		}
+	}
+	slices.SortFunc(bars, func(a, b Bar) int {
+		order := cmp.Compare(a.Value, b.Value)
+		if metric == MetricBombed {
+			order = -order
+		}
+		return cmp.Or(order, cmp.Compare(a.Setting, b.Setting))
+	})
+	return bars
+}
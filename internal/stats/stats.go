@@ -0,0 +1,100 @@
+// Package stats keeps lifetime totals across every game played
+package stats
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const (
+	statsFile    = "stats.json"
+	RecentScores = 20  // Latest scores kept for charting
+	HistoryRuns  = 200 // Latest runs kept for the balance report
+)
+
+// Game is the outcome of one finished game
+type Game struct {
+	Score    int      `json:"score"`
+	Food     int      `json:"food"`
+	Duration float32  `json:"duration"`         // Seconds played
+	Length   int      `json:"length"`           // Final snake length
+	Cause    string   `json:"cause,omitempty"`  // What the snake died to, empty if it didn't
+	Bombed   bool     `json:"bombed,omitempty"` // Died to a bomb or its blast
+	Setup    []string `json:"setup,omitempty"`  // Each setting the run was played with, such as "Mode: Zen"
+}
+
+// Stats are the lifetime totals
+type Stats struct {
+	Games   int            `json:"games"`
+	Food    int            `json:"food"`
+	Time    float32        `json:"time"` // Seconds played
+	Longest int            `json:"longest"`
+	Best    int            `json:"best"`
+	Deaths  map[string]int `json:"deaths"`            // By cause
+	Recent  []int          `json:"recent"`            // Latest scores, oldest first
+	History []Game         `json:"history,omitempty"` // Latest runs with a setup, oldest first
+}
+
+// Record adds a finished game to the totals
+func (s *Stats) Record(game Game) {
+	s.Games++
+	s.Food += game.Food
+	s.Time += game.Duration
+	s.Longest = max(s.Longest, game.Length)
+	s.Best = max(s.Best, game.Score)
+	if game.Cause != "" {
+		s.Deaths[game.Cause]++
+	}
+	s.Recent = append(s.Recent, game.Score)
+	if len(s.Recent) > RecentScores {
+		s.Recent = s.Recent[len(s.Recent)-RecentScores:]
+	}
+	// Only runs that know what they were played with tell the report anything
+	if len(game.Setup) > 0 {
+		s.History = append(s.History, game)
+		if len(s.History) > HistoryRuns {
+			s.History = s.History[len(s.History)-HistoryRuns:]
+		}
+	}
+}
+
+// Average is the mean score of the recent games
+func (s *Stats) Average() float32 {
+	if len(s.Recent) == 0 {
+		return 0
+	}
+	total := 0
+	for _, score := range s.Recent {
+		total += score
+	}
+	return float32(total) / float32(len(s.Recent))
+}
+
+// Load reads the stats saved in dir, starting fresh if there are none
+func Load(dir string) (Stats, error) {
+	stats := Stats{Deaths: make(map[string]int)}
+	data, err := os.ReadFile(filepath.Join(dir, statsFile))
+	if os.IsNotExist(err) {
+		return stats, nil
+	} else if err != nil {
+		return stats, err
+	}
+
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return Stats{Deaths: make(map[string]int)}, err
+	}
+	if stats.Deaths == nil {
+		stats.Deaths = make(map[string]int)
+	}
+	return stats, nil
+}
+
+// Save writes the stats to dir
+func Save(dir string, stats Stats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, statsFile), data, 0644)
+}
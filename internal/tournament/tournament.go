@@ -0,0 +1,122 @@
+// Package tournament runs a hot-seat knockout bracket. Each match is two players taking a turn
+// each on the same seed, and the higher score goes through to the next round.
+package tournament
+
+const (
+	MinPlayers = 2
+	MaxPlayers = 8
+)
+
+// Bye fills a bracket slot without a player, so the other player in the match goes through
+const Bye = -1
+
+// Match is two players' turns on one seed. Players are indexes into the tournament's players.
+type Match struct {
+	Players [2]int
+	Scores  [2]int
+	Played  int // Turns played so far, a match is decided after two
+	Seed    uint64
+}
+
+// Decided reports whether the match has a winner, which a bye has straight away
+func (m *Match) Decided() bool {
+	return m.Played == 2 || m.Players[0] == Bye || m.Players[1] == Bye
+}
+
+// Winner returns the player going through. Ties go to the player listed first, who is the higher
+// seed in the first round.
+func (m *Match) Winner() int {
+	switch {
+	case m.Players[1] == Bye:
+		return m.Players[0]
+	case m.Players[0] == Bye:
+		return m.Players[1]
+	case m.Scores[1] > m.Scores[0]:
+		return m.Players[1]
+	}
+	return m.Players[0]
+}
+
+// Tournament is the players and the rounds of the bracket drawn so far, first round first
+type Tournament struct {
+	Players []string
+	Rounds  [][]Match
+	seed    uint64
+}
+
+// New draws the first round for the players. The bracket is padded to a power of two with byes,
+// which go to the first players listed.
+func New(players []string, seed uint64) *Tournament {
+	size := 1
+	for size < len(players) {
+		size *= 2
+	}
+	slots := make([]int, size)
+	for i := range slots {
+		slots[i] = Bye
+		if i < len(players) {
+			slots[i] = i
+		}
+	}
+
+	t := &Tournament{Players: players, seed: seed}
+	first := make([]Match, size/2)
+	for i := range first {
+		first[i] = t.newMatch(0, i, slots[i], slots[size-1-i])
+	}
+	t.Rounds = [][]Match{first}
+	return t
+}
+
+// newMatch pairs two players, with a seed of its own so each match plays a different board
+func (t *Tournament) newMatch(round, index, a, b int) Match {
+	return Match{Players: [2]int{a, b}, Seed: t.seed + uint64(round*MaxPlayers+index+1)}
+}
+
+// Next returns the match to play next and which of its players takes the turn, false once the
+// tournament is over
+func (t *Tournament) Next() (*Match, int, bool) {
+	round := t.Rounds[len(t.Rounds)-1]
+	for i := range round {
+		if !round[i].Decided() {
+			return &round[i], round[i].Played, true
+		}
+	}
+	return nil, 0, false
+}
+
+// Record scores the turn Next returned, drawing the next round once every match of this one is
+// decided
+func (t *Tournament) Record(score int) {
+	match, player, ok := t.Next()
+	if !ok {
+		return
+	}
+	match.Scores[player] = score
+	match.Played++
+
+	round := t.Rounds[len(t.Rounds)-1]
+	if _, _, ok := t.Next(); ok || len(round) == 1 {
+		return
+	}
+	next := make([]Match, len(round)/2)
+	for i := range next {
+		next[i] = t.newMatch(len(t.Rounds), i, round[2*i].Winner(), round[2*i+1].Winner())
+	}
+	t.Rounds = append(t.Rounds, next)
+}
+
+// Champion returns the winner once the final is decided
+func (t *Tournament) Champion() (int, bool) {
+	round := t.Rounds[len(t.Rounds)-1]
+	if len(round) != 1 || !round[0].Decided() {
+		return 0, false
+	}
+	return round[0].Winner(), true
+}
+
+// Over reports whether the tournament has a champion
+func (t *Tournament) Over() bool {
+	_, over := t.Champion()
+	return over
+}
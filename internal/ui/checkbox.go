@@ -0,0 +1,55 @@
+package ui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Checkbox toggles an option on a click, or with Enter, Space or the gamepad's A button while focused
+type Checkbox struct {
+	Rect     rl.Rectangle
+	Label    string
+	Checked  bool
+	OnChange func(checked bool)
+
+	style Style
+}
+
+func NewCheckbox(style Style, rect rl.Rectangle, label string, checked bool) *Checkbox {
+	return &Checkbox{Rect: rect, Label: label, Checked: checked, style: style}
+}
+
+func (c *Checkbox) Bounds() rl.Rectangle { return c.Rect }
+
+func (c *Checkbox) Update(mouse rl.Vector2, focused bool) bool {
+	clicked := rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mouse, c.Rect)
+	if focused && activatePressed() {
+		clicked = true
+	}
+	if !clicked {
+		return false
+	}
+	c.Checked = !c.Checked
+	if c.OnChange != nil {
+		c.OnChange(c.Checked)
+	}
+	return true
+}
+
+func (c *Checkbox) Draw(focused bool) {
+	c.style.drawBackground(c.Rect, rl.CheckCollisionPointRec(rl.GetMousePosition(), c.Rect), focused)
+
+	// The box sits at the left, with the label beside it
+	side := c.Rect.Height * 0.55
+	box := rl.NewRectangle(c.Rect.X+textPadding, c.Rect.Y+(c.Rect.Height-side)/2, side, side)
+	rl.DrawRectangleRec(box, rl.RayWhite)
+	rl.DrawRectangleLinesEx(box, 2, c.style.Text)
+	if c.Checked {
+		tick := rl.NewRectangle(box.X+4, box.Y+4, box.Width-8, box.Height-8)
+		rl.DrawRectangleRec(tick, c.style.Focus)
+	}
+
+	label := c.Rect
+	label.X += side + textPadding
+	label.Width -= side + textPadding
+	c.style.drawText(c.Label, label, true, c.style.Text)
+}
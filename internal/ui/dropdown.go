@@ -0,0 +1,136 @@
+package ui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// maxDropdownRows is how many options an open dropdown shows before scrolling
+const maxDropdownRows = 6
+
+// Dropdown shows the selected option, and opens a list of the others on a click or with Enter,
+// Space or the gamepad's A button while focused. Left/Right step through the options without opening it.
+type Dropdown struct {
+	Rect     rl.Rectangle
+	Label    string
+	Options  []string
+	Selected int
+	OnChange func(index int)
+	Disabled bool
+
+	style Style
+	list  *List // The open options, nil while closed
+}
+
+func NewDropdown(style Style, rect rl.Rectangle, label string, options []string, selected int) *Dropdown {
+	return &Dropdown{Rect: rect, Label: label, Options: options, Selected: selected, style: style}
+}
+
+func (d *Dropdown) Bounds() rl.Rectangle { return d.Rect }
+
+func (d *Dropdown) IsDisabled() bool { return d.Disabled }
+
+// Capturing reports whether the options are open
+func (d *Dropdown) Capturing() bool { return d.list != nil }
+
+func (d *Dropdown) Update(mouse rl.Vector2, focused bool) bool {
+	if d.Disabled {
+		d.list = nil
+		return false
+	}
+	if d.list != nil {
+		return d.updateOpen(mouse)
+	}
+
+	clicked := rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mouse, d.Rect)
+	if clicked || focused && activatePressed() {
+		d.open()
+		return false
+	}
+	if focused && len(d.Options) > 0 {
+		if arrowPressed(rl.KeyLeft) {
+			return d.Select((d.Selected + len(d.Options) - 1) % len(d.Options))
+		}
+		if arrowPressed(rl.KeyRight) {
+			return d.Select((d.Selected + 1) % len(d.Options))
+		}
+	}
+	return false
+}
+
+// updateOpen runs the open options: clicking one or pressing Enter picks it and closes the
+// dropdown, clicking elsewhere or Escape closes it as it was
+func (d *Dropdown) updateOpen(mouse rl.Vector2) bool {
+	if rl.IsKeyPressed(rl.KeyEscape) {
+		d.list = nil
+		return false
+	}
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		row := d.list.rowAt(mouse)
+		d.list = nil
+		if row < 0 {
+			return false
+		}
+		return d.Select(row)
+	}
+	if activatePressed() {
+		selected := d.list.Selected
+		d.list = nil
+		return d.Select(selected)
+	}
+	d.list.Update(mouse, true)
+	return false
+}
+
+// open lays out the options under the dropdown, or over it if they would run off the screen
+func (d *Dropdown) open() {
+	rowHeight := d.Rect.Height
+	rows := min(len(d.Options), maxDropdownRows)
+	rect := rl.NewRectangle(d.Rect.X, d.Rect.Y+d.Rect.Height, d.Rect.Width, float32(rows)*rowHeight)
+	if rect.Y+rect.Height > d.style.Screen.Y+d.style.Screen.Height {
+		rect.Y = d.Rect.Y - rect.Height
+	}
+	d.list = NewList(d.style, rect, d.Options, d.Selected)
+	d.list.RowHeight = rowHeight
+	d.list.ScrollTo(d.Selected)
+}
+
+// Select picks the option at index, reporting whether the selection changed
+func (d *Dropdown) Select(index int) bool {
+	if index == d.Selected || index < 0 || index >= len(d.Options) {
+		return false
+	}
+	d.Selected = index
+	if d.OnChange != nil {
+		d.OnChange(index)
+	}
+	return true
+}
+
+func (d *Dropdown) Draw(focused bool) {
+	hovered := !d.Disabled && rl.CheckCollisionPointRec(rl.GetMousePosition(), d.Rect)
+	d.style.drawBackground(d.Rect, hovered || d.list != nil, focused)
+
+	text := d.Label
+	if d.Selected >= 0 && d.Selected < len(d.Options) {
+		text += ": " + d.Options[d.Selected]
+	}
+	// Leave room for the arrow on the right
+	arrow := d.Rect.Height * 0.3
+	label := d.Rect
+	label.Width -= arrow * 2
+	d.style.drawText(text, label, true, d.style.textColor(d.Disabled))
+
+	center := rl.Vector2{X: d.Rect.X + d.Rect.Width - arrow*1.5, Y: d.Rect.Y + d.Rect.Height/2}
+	rl.DrawTriangle(
+		rl.Vector2{X: center.X - arrow/2, Y: center.Y - arrow/4},
+		rl.Vector2{X: center.X, Y: center.Y + arrow/4},
+		rl.Vector2{X: center.X + arrow/2, Y: center.Y - arrow/4},
+		d.style.textColor(d.Disabled),
+	)
+
+	if d.list != nil {
+		rl.DrawRectangleRec(d.list.Rect, d.style.Fill)
+		d.list.Draw(false)
+		rl.DrawRectangleLinesEx(d.list.Rect, 2, d.style.Focus)
+	}
+}
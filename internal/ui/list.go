@@ -0,0 +1,124 @@
+package ui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// List shows items in rows, scrolling with the mouse wheel when there are more than fit.
+// Clicking a row selects it, as do Up/Down while the list is focused.
+type List struct {
+	Rect      rl.Rectangle
+	Items     []string
+	Selected  int // -1 for none
+	RowHeight float32
+	OnSelect  func(index int)
+
+	style  Style
+	scroll int // Index of the first visible row
+}
+
+func NewList(style Style, rect rl.Rectangle, items []string, selected int) *List {
+	list := &List{Rect: rect, Items: items, Selected: selected, RowHeight: style.FontSize * 1.4, style: style}
+	list.ScrollTo(selected)
+	return list
+}
+
+func (l *List) Bounds() rl.Rectangle { return l.Rect }
+
+// visibleRows returns how many rows fit
+func (l *List) visibleRows() int {
+	return max(1, int(l.Rect.Height/l.RowHeight))
+}
+
+func (l *List) maxScroll() int {
+	return max(0, len(l.Items)-l.visibleRows())
+}
+
+// ScrollTo scrolls just far enough to show the row at index
+func (l *List) ScrollTo(index int) {
+	if index < 0 {
+		return
+	}
+	if index < l.scroll {
+		l.scroll = index
+	} else if index >= l.scroll+l.visibleRows() {
+		l.scroll = index - l.visibleRows() + 1
+	}
+	l.scroll = max(0, min(l.maxScroll(), l.scroll))
+}
+
+// rowAt returns the index of the item under the point, -1 if there is none
+func (l *List) rowAt(point rl.Vector2) int {
+	if !rl.CheckCollisionPointRec(point, l.Rect) {
+		return -1
+	}
+	index := l.scroll + int((point.Y-l.Rect.Y)/l.RowHeight)
+	if index >= len(l.Items) || index >= l.scroll+l.visibleRows() {
+		return -1
+	}
+	return index
+}
+
+func (l *List) Update(mouse rl.Vector2, focused bool) bool {
+	if rl.CheckCollisionPointRec(mouse, l.Rect) {
+		wheel := rl.GetMouseWheelMove()
+		if wheel > 0 {
+			l.scroll = max(0, l.scroll-1)
+		} else if wheel < 0 {
+			l.scroll = min(l.maxScroll(), l.scroll+1)
+		}
+	}
+
+	selected := l.Selected
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		if row := l.rowAt(mouse); row >= 0 {
+			selected = row
+		}
+	}
+	if focused && len(l.Items) > 0 {
+		if arrowPressed(rl.KeyUp) {
+			selected = max(0, selected-1)
+		}
+		if arrowPressed(rl.KeyDown) {
+			selected = min(len(l.Items)-1, selected+1)
+		}
+	}
+	return l.Select(selected)
+}
+
+// Select picks the item at index and scrolls to it, reporting whether the selection changed
+func (l *List) Select(index int) bool {
+	if index == l.Selected {
+		return false
+	}
+	l.Selected = index
+	l.ScrollTo(index)
+	if l.OnSelect != nil {
+		l.OnSelect(index)
+	}
+	return true
+}
+
+func (l *List) Draw(focused bool) {
+	l.style.drawBackground(l.Rect, false, focused)
+
+	hovered := l.rowAt(rl.GetMousePosition())
+	end := min(len(l.Items), l.scroll+l.visibleRows())
+	for i := l.scroll; i < end; i++ {
+		row := rl.NewRectangle(l.Rect.X, l.Rect.Y+float32(i-l.scroll)*l.RowHeight, l.Rect.Width, l.RowHeight)
+		switch i {
+		case l.Selected:
+			rl.DrawRectangleRec(row, l.style.Accent)
+		case hovered:
+			rl.DrawRectangleRec(row, l.style.Hover)
+		}
+		l.style.drawText(l.Items[i], row, false, l.style.Text)
+	}
+
+	// Scrollbar when the items overflow
+	if l.maxScroll() > 0 {
+		thumbHeight := l.Rect.Height * float32(l.visibleRows()) / float32(len(l.Items))
+		thumbY := l.Rect.Y + (l.Rect.Height-thumbHeight)*float32(l.scroll)/float32(l.maxScroll())
+		rl.DrawRectangleRec(rl.NewRectangle(l.Rect.X+l.Rect.Width-4, thumbY, 4, thumbHeight), l.style.Text)
+	}
+}
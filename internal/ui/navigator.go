@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Navigator moves focus between the controls of a screen without the mouse. Up/Down, Tab and
+// Shift+Tab, and the gamepad's d-pad step through the controls in reading order, and Enter,
+// Space or the gamepad's A button press the focused one.
+//
+// Controls register themselves each frame with Visit, so screens don't keep a list of them.
+// Moving the mouse off the focused control clears the focus, handing the screen back to it.
+type Navigator struct {
+	visited  []rl.Rectangle // Controls seen this frame
+	order    []rl.Rectangle // Controls seen last frame, in reading order
+	focus    rl.Rectangle
+	active   bool // Whether a control has focus, rather than the mouse being in charge
+	activate bool // The focused control was pressed, to be picked up next frame
+	held     bool
+	arrows   bool
+
+	highlight   rl.Rectangle // Control shown selected this frame, by the mouse or focus
+	highlighted bool
+	last        rl.Rectangle // Control shown selected last frame
+	hadLast     bool
+	moved       bool
+}
+
+// Visit registers a control for this frame, reporting whether it has focus
+func (n *Navigator) Visit(rect rl.Rectangle) bool {
+	if !slices.Contains(n.visited, rect) {
+		n.visited = append(n.visited, rect)
+	}
+	return n.Has(rect)
+}
+
+// Has reports whether the control at rect has focus
+func (n *Navigator) Has(rect rl.Rectangle) bool {
+	return n.active && n.focus == rect
+}
+
+// HasControls reports whether any controls were visited last frame, meaning a menu is showing
+func (n *Navigator) HasControls() bool { return len(n.order) > 0 }
+
+// Active reports whether any control has focus. The mouse hover is ignored meanwhile, so only
+// one control ever looks selected.
+func (n *Navigator) Active() bool { return n.active }
+
+// Focus gives the control at rect focus, as when it is clicked
+func (n *Navigator) Focus(rect rl.Rectangle) {
+	n.focus = rect
+	n.active = true
+}
+
+// Hold keeps the focus where it is this frame, while a control takes every key
+func (n *Navigator) Hold() { n.held = true }
+
+// HoldArrows leaves Up/Down to the screen this frame, for a table scrolling with them.
+// Tab and the d-pad still move the focus.
+func (n *Navigator) HoldArrows() { n.arrows = true }
+
+// Highlight marks the control at rect as shown selected this frame, under the mouse or focused
+func (n *Navigator) Highlight(rect rl.Rectangle) {
+	n.highlight = rect
+	n.highlighted = true
+}
+
+// Moved reports whether the highlight moved onto another control this frame, for a hover sound
+func (n *Navigator) Moved() bool { return n.moved }
+
+// Activated reports whether the focused control was pressed. It is true for one call only,
+// so a press can't carry over to the next screen.
+func (n *Navigator) Activated() bool {
+	activated := n.activate
+	n.activate = false
+	return activated
+}
+
+// EndFrame handles the frame's navigation keys, once every control has been visited
+func (n *Navigator) EndFrame() {
+	n.moved = n.highlighted && (!n.hadLast || n.highlight != n.last)
+	n.last, n.hadLast = n.highlight, n.highlighted
+	n.highlighted = false
+
+	n.order, n.visited = n.visited, n.order[:0]
+	slices.SortStableFunc(n.order, func(a, b rl.Rectangle) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	held, arrows := n.held, n.arrows
+	n.held, n.arrows = false, false
+
+	delta := rl.GetMouseDelta()
+	if (delta.X != 0 || delta.Y != 0) && !rl.CheckCollisionPointRec(rl.GetMousePosition(), n.focus) {
+		n.active = false
+	}
+	if len(n.order) == 0 || held {
+		n.activate = false
+		return
+	}
+
+	// Focus stays on its control while it is shown, otherwise it moves to the first one,
+	// so a new screen opens ready to use
+	index := slices.Index(n.order, n.focus)
+	if n.active && index < 0 {
+		index = 0
+		n.focus = n.order[0]
+	}
+	n.activate = n.active && activatePressed()
+
+	step := 0
+	switch {
+	case rl.IsKeyPressed(rl.KeyTab):
+		step = 1
+		if rl.IsKeyDown(rl.KeyLeftShift) || rl.IsKeyDown(rl.KeyRightShift) {
+			step = -1
+		}
+	case !arrows && keyPressed(rl.KeyDown), gamepadPressed(rl.GamepadButtonLeftFaceDown):
+		step = 1
+	case !arrows && keyPressed(rl.KeyUp), gamepadPressed(rl.GamepadButtonLeftFaceUp):
+		step = -1
+	}
+	if step == 0 {
+		return
+	}
+	// Without focus, the first press starts from the top or the bottom
+	if !n.active {
+		index = -1
+		if step < 0 {
+			index = len(n.order)
+		}
+	}
+	count := len(n.order)
+	n.focus = n.order[(index+step+count)%count]
+	n.active = true
+}
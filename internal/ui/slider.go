@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Slider picks a value in a range by dragging across it, or with Left/Right while focused.
+// The filled part of the background shows the value, under a label describing it.
+type Slider struct {
+	Rect     rl.Rectangle
+	Min, Max float32
+	Step     float32 // Change per key press, and the value is rounded to it when dragged
+	Value    float32
+	Label    func(value float32) string
+	OnChange func(value float32)
+	Disabled bool
+
+	style    Style
+	dragging bool
+}
+
+func NewSlider(style Style, rect rl.Rectangle, min, max, step, value float32, label func(float32) string) *Slider {
+	return &Slider{Rect: rect, Min: min, Max: max, Step: step, Value: value, Label: label, style: style}
+}
+
+func (s *Slider) Bounds() rl.Rectangle { return s.Rect }
+
+func (s *Slider) IsDisabled() bool { return s.Disabled }
+
+func (s *Slider) Update(mouse rl.Vector2, focused bool) bool {
+	if s.Disabled {
+		s.dragging = false
+		return false
+	}
+	value := s.Value
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mouse, s.Rect) {
+		s.dragging = true
+	}
+	if !rl.IsMouseButtonDown(rl.MouseLeftButton) {
+		s.dragging = false
+	}
+	if s.dragging {
+		fraction := max(0, min(1, (mouse.X-s.Rect.X)/s.Rect.Width))
+		value = s.Min + fraction*(s.Max-s.Min)
+		value = float32(math.Round(float64(value/s.Step))) * s.Step
+	}
+	if focused {
+		if arrowPressed(rl.KeyLeft) {
+			value -= s.Step
+		}
+		if arrowPressed(rl.KeyRight) {
+			value += s.Step
+		}
+	}
+	return s.set(value)
+}
+
+// set changes the value, clamped to the range, and reports whether it changed
+func (s *Slider) set(value float32) bool {
+	value = max(s.Min, min(s.Max, value))
+	if value == s.Value {
+		return false
+	}
+	s.Value = value
+	if s.OnChange != nil {
+		s.OnChange(value)
+	}
+	return true
+}
+
+func (s *Slider) Draw(focused bool) {
+	hovered := !s.Disabled && (s.dragging || rl.CheckCollisionPointRec(rl.GetMousePosition(), s.Rect))
+	s.style.drawBackground(s.Rect, hovered, focused)
+	if s.Max > s.Min {
+		fill := s.Rect
+		fill.Width *= (s.Value - s.Min) / (s.Max - s.Min)
+		rl.DrawRectangleRec(fill, s.style.Accent)
+	}
+	s.style.drawText(s.Label(s.Value), s.Rect, true, s.style.textColor(s.Disabled))
+}
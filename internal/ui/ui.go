@@ -0,0 +1,206 @@
+// Package ui has the widgets the menus are built from beyond plain buttons: sliders, checkboxes,
+// dropdowns and scrollable lists. Widgets share a Style so they look like the menu buttons, and
+// a Navigator moves keyboard and gamepad focus between them and the buttons.
+package ui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	minFontSize = 12 // Text shrinks to fit down to this size
+	textPadding = 8  // Horizontal space kept clear inside a widget
+)
+
+// Style is the look shared by widgets
+type Style struct {
+	Font     rl.Font
+	FontSize float32
+	Fill     rl.Color     // Background
+	Hover    rl.Color     // Background under the mouse
+	Text     rl.Color     // Label text
+	Accent   rl.Color     // Slider fill, checkbox tick and selected list row
+	Focus    rl.Color     // Outline of the focused widget
+	Screen   rl.Rectangle // Area widgets may draw in, so dropdowns open within it
+}
+
+// DefaultStyle matches the menu buttons, on a screen of the given size
+func DefaultStyle(font rl.Font, fontSize, screenWidth, screenHeight float32) Style {
+	return Style{
+		Font:     font,
+		FontSize: fontSize,
+		Fill:     rl.LightGray,
+		Hover:    rl.Gray,
+		Text:     rl.DarkGray,
+		Accent:   rl.Color{R: 0, G: 158, B: 47, A: 110},
+		Focus:    rl.DarkGreen,
+		Screen:   rl.NewRectangle(0, 0, screenWidth, screenHeight),
+	}
+}
+
+// Widget is a control placed on a screen
+type Widget interface {
+	// Update handles a frame of input. Keys only reach the widget while it is focused.
+	// It reports whether the widget's value changed.
+	Update(mouse rl.Vector2, focused bool) bool
+	Draw(focused bool)
+	Bounds() rl.Rectangle
+}
+
+// capturer is a widget that takes every input while it is active, like an open dropdown
+type capturer interface {
+	Capturing() bool
+}
+
+// disabler is a widget that can be turned off, skipping it for input and focus
+type disabler interface {
+	IsDisabled() bool
+}
+
+// Group is the widgets of a screen. Focus is kept by the screen's Navigator, so it moves through
+// widgets and buttons alike, and clicking a widget focuses it too.
+type Group struct {
+	Widgets []Widget
+	nav     *Navigator
+}
+
+func NewGroup(nav *Navigator, widgets ...Widget) *Group {
+	return &Group{Widgets: widgets, nav: nav}
+}
+
+// Focused returns the widget with keyboard focus, nil if there is none
+func (g *Group) Focused() Widget {
+	for _, widget := range g.Widgets {
+		if g.nav.Has(widget.Bounds()) {
+			return widget
+		}
+	}
+	return nil
+}
+
+// Capturing reports whether a widget has taken over input, such as an open dropdown.
+// Screens should leave keys alone meanwhile.
+func (g *Group) Capturing() bool {
+	for _, widget := range g.Widgets {
+		if c, ok := widget.(capturer); ok && c.Capturing() {
+			return true
+		}
+	}
+	return false
+}
+
+// Update runs a frame of input through the widgets, returning whether any value changed
+func (g *Group) Update(mouse rl.Vector2) bool {
+	// A capturing widget is the only one updated until it lets go
+	for _, widget := range g.Widgets {
+		if c, ok := widget.(capturer); ok && c.Capturing() {
+			g.nav.Visit(widget.Bounds())
+			g.nav.Focus(widget.Bounds())
+			g.nav.Hold()
+			return widget.Update(mouse, true)
+		}
+	}
+
+	if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		for _, widget := range g.Widgets {
+			if enabled(widget) && rl.CheckCollisionPointRec(mouse, widget.Bounds()) {
+				g.nav.Focus(widget.Bounds())
+			}
+		}
+	}
+
+	// Disabled widgets aren't visited, so the focus skips them
+	changed := false
+	for _, widget := range g.Widgets {
+		if enabled(widget) && widget.Update(mouse, g.nav.Visit(widget.Bounds())) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Draw draws the widgets, any capturing one last so it shows over the rest
+func (g *Group) Draw() {
+	var top Widget
+	for _, widget := range g.Widgets {
+		if c, ok := widget.(capturer); ok && c.Capturing() {
+			top = widget
+			continue
+		}
+		widget.Draw(g.nav.Has(widget.Bounds()))
+	}
+	if top != nil {
+		top.Draw(true)
+	}
+}
+
+func enabled(widget Widget) bool {
+	d, ok := widget.(disabler)
+	return !ok || !d.IsDisabled()
+}
+
+// drawBackground fills a widget's bounds, lighter while the mouse is over it, and outlines it
+// when focused
+func (s *Style) drawBackground(bounds rl.Rectangle, hovered, focused bool) {
+	fill := s.Fill
+	if hovered {
+		fill = s.Hover
+	}
+	rl.DrawRectangleRec(bounds, fill)
+	if focused {
+		rl.DrawRectangleLinesEx(bounds, 2, s.Focus)
+	}
+}
+
+// drawText draws text in bounds, centered or from the left, shrinking it until it fits
+func (s *Style) drawText(text string, bounds rl.Rectangle, centered bool, color rl.Color) {
+	maxWidth := bounds.Width - textPadding*2
+	size := s.FontSize
+	for size > minFontSize && rl.MeasureTextEx(s.Font, text, size, 1).X > maxWidth {
+		size--
+	}
+	textSize := rl.MeasureTextEx(s.Font, text, size, 1)
+	x := bounds.X + textPadding
+	if centered {
+		x = bounds.X + (bounds.Width-textSize.X)/2
+	}
+	rl.DrawTextEx(s.Font, text, rl.Vector2{X: x, Y: bounds.Y + (bounds.Height-textSize.Y)/2}, size, 1, color)
+}
+
+// textColor is the label color, faded when the widget is disabled
+func (s *Style) textColor(disabled bool) rl.Color {
+	if disabled {
+		return rl.Fade(s.Text, 0.4)
+	}
+	return s.Text
+}
+
+// keyPressed reports a key press, repeating while it is held
+func keyPressed(key int32) bool {
+	return rl.IsKeyPressed(key) || rl.IsKeyPressedRepeat(key)
+}
+
+// dpad is the gamepad button that stands in for each arrow key
+var dpad = map[int32]int32{
+	rl.KeyUp:    rl.GamepadButtonLeftFaceUp,
+	rl.KeyDown:  rl.GamepadButtonLeftFaceDown,
+	rl.KeyLeft:  rl.GamepadButtonLeftFaceLeft,
+	rl.KeyRight: rl.GamepadButtonLeftFaceRight,
+}
+
+// arrowPressed reports an arrow key press, or the matching direction on the gamepad's d-pad
+func arrowPressed(key int32) bool {
+	return keyPressed(key) || gamepadPressed(dpad[key])
+}
+
+func gamepadPressed(button int32) bool {
+	return rl.IsGamepadAvailable(0) && rl.IsGamepadButtonPressed(0, button)
+}
+
+// activatePressed reports Enter, Space or the gamepad's A button, which press a focused
+// control. Alt+Enter is left for fullscreen.
+func activatePressed() bool {
+	altDown := rl.IsKeyDown(rl.KeyLeftAlt) || rl.IsKeyDown(rl.KeyRightAlt)
+	return rl.IsKeyPressed(rl.KeyEnter) && !altDown || rl.IsKeyPressed(rl.KeySpace) ||
+		gamepadPressed(rl.GamepadButtonRightFaceDown)
+}
@@ -0,0 +1,530 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/net"
+)
+
+// maxAddressLength is the longest host address that can be typed on the join screen
+const maxAddressLength = 40
+
+// openHostLobby hosts a LAN match and waits for a guest to join, showing the addresses they can
+// join with. Escape stops hosting.
+func (g *Game) openHostLobby() {
+	g.state = StateMainMenu
+	host, err := net.Listen(net.DefaultPort)
+	if err != nil {
+		fmt.Println("Failed to host a LAN game:", err)
+	}
+
+	addresses := strings.Join(net.LocalAddresses(), ", ")
+	if addresses == "" {
+		addresses = "this machine's IP address"
+	}
+	matchButton := NewMenuButton(float32(g.screenWidth)/2-150, float32(g.screenHeight)*0.7, 300, 50, g.lanMatchText(), 30, g.menu.font)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			break
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateVersusSelect
+			break
+		}
+		if host != nil && g.clicked(&matchButton, rl.GetMousePosition()) {
+			g.lanCoop = !g.lanCoop
+			matchButton.text = g.lanMatchText()
+		}
+		if host != nil {
+			if conn := host.Joined(); conn != nil {
+				fmt.Println("Player joined from", conn.RemoteAddr())
+				g.playLANHost(conn)
+				return
+			}
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText("HOST LAN GAME", float32(g.screenHeight)*0.1, 50, rl.DarkGreen)
+		if host == nil {
+			g.drawCenteredText("Couldn't start hosting, is another game using the port?", float32(g.screenHeight)*0.4, 24, rl.Red)
+		} else {
+			g.drawCenteredText("Waiting for a player to join...", float32(g.screenHeight)*0.35, 30, rl.DarkGray)
+			g.drawCenteredText(fmt.Sprintf("Join at %s (port %d)", addresses, net.DefaultPort), float32(g.screenHeight)*0.5, 24, rl.DarkGray)
+			if g.lanCoop {
+				g.drawCenteredText("Played as a team on your level and difficulty, on the large board", float32(g.screenHeight)*0.6, 20, rl.Gray)
+			} else {
+				g.drawCenteredText("Played on your level, board and difficulty", float32(g.screenHeight)*0.6, 20, rl.Gray)
+			}
+			matchButton.Draw()
+		}
+		g.drawCenteredText("Escape to go back", float32(g.screenHeight)*0.85, 20, rl.Gray)
+		g.endFrame()
+	}
+
+	if host != nil {
+		if err := host.Close(); err != nil {
+			fmt.Println("Failed to stop hosting:", err)
+		}
+	}
+}
+
+// openJoinScreen asks for the host's address and joins its match. Connecting runs in the
+// background so the screen keeps drawing; Escape goes back.
+func (g *Game) openJoinScreen() {
+	g.state = StateMainMenu
+	address := []rune(g.lanAddress)
+	g.typing = true
+	defer func() { g.typing = false }()
+
+	type dialResult struct {
+		conn *net.Conn
+		err  error
+	}
+	var dialing chan dialResult
+	status := ""
+
+	box := rl.NewRectangle(float32(g.screenWidth)/2-200, float32(g.screenHeight)*0.4, 400, 56)
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) && dialing == nil {
+			g.state = StateVersusSelect
+			return
+		}
+
+		if dialing == nil {
+			for char := rl.GetCharPressed(); char > 0; char = rl.GetCharPressed() {
+				if char > ' ' && char < 127 && len(address) < maxAddressLength {
+					address = append(address, char)
+				}
+			}
+			if (rl.IsKeyPressed(rl.KeyBackspace) || rl.IsKeyPressedRepeat(rl.KeyBackspace)) && len(address) > 0 {
+				address = address[:len(address)-1]
+			}
+			if rl.IsKeyPressed(rl.KeyEnter) && len(address) > 0 {
+				g.lanAddress = string(address)
+				dialing = make(chan dialResult, 1)
+				go func(address string) {
+					conn, err := net.Dial(address)
+					dialing <- dialResult{conn: conn, err: err}
+				}(g.lanAddress)
+				status = "Connecting..."
+			}
+		} else {
+			select {
+			case result := <-dialing:
+				dialing = nil
+				if result.err != nil {
+					fmt.Println("Failed to join:", result.err)
+					status = "Couldn't connect to " + g.lanAddress
+					break
+				}
+				g.typing = false
+				g.playLANGuest(result.conn)
+				return
+			default:
+			}
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText("JOIN LAN GAME", float32(g.screenHeight)*0.1, 50, rl.DarkGreen)
+		g.drawCenteredText("Type the host's address and press Enter", float32(g.screenHeight)*0.28, 24, rl.DarkGray)
+
+		rl.DrawRectangleRec(box, rl.LightGray)
+		rl.DrawRectangleLinesEx(box, 3, rl.DarkGray)
+		text := string(address)
+		if dialing == nil && int(rl.GetTime()*2)%2 == 0 {
+			text += "_"
+		}
+		drawTextFit(g.menu.font, text, rl.NewRectangle(box.X+textPadding, box.Y, box.Width-2*textPadding, box.Height), 30, rl.Black)
+
+		g.drawCenteredText(status, float32(g.screenHeight)*0.6, 24, rl.Gray)
+		g.drawCenteredText("Escape to go back", float32(g.screenHeight)*0.85, 20, rl.Gray)
+		g.endFrame()
+	}
+}
+
+// playLANHost runs a match against a guest on the network. The host's game is the real one: the
+// guest is a rival steered by the turns it sends, and gets the state back after every tick. The
+// first snake to crash ends the match, the other gets the survival bonus and the most points wins.
+// In co-op the guest is a partner instead, and the match lasts until both are down.
+func (g *Game) playLANHost(conn *net.Conn) {
+	defer conn.Close()
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+	defer g.audio.PlayMusic(&g.audio.MenuMusic)
+
+	// Co-op shares the large board, whatever board the host plays on
+	defer func(board BoardSize) { g.board = board }(g.board)
+	if g.lanCoop {
+		g.board = BoardLarge
+	}
+	width, height := g.boardSize()
+	config := g.difficulty.Config(width, height)
+	config.Walls = g.level.Walls(width, height)
+	config.Portals = g.level.Portals(width, height)
+	config.SolidEdges = g.solidEdges
+	config.Growth = g.growth
+	config.ShrinkOnBomb = g.shrinkOnBomb
+	config.Coop = g.lanCoop
+	engine := game.NewEngine(config, g.newSeed())
+	guest := engine.AddRival()
+	err := conn.Send(net.Message{Kind: net.KindWelcome, Config: &config})
+	if err == nil {
+		err = sendLANState(conn, engine)
+	}
+	if err != nil {
+		fmt.Println("Failed to start the LAN match:", err)
+		g.openResultsScreen("CONNECTION LOST", nil)
+		return
+	}
+	g.particles.Clear()
+	g.camFX.Reset()
+	g.startCountdown()
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		// A match over the network can't be paused, so pause leaves it
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if g.controls.Pressed(input.ActionPause) {
+			g.state = StateMainMenu
+			return
+		}
+
+		// Anything but a single step in one direction would have the guest jump cells
+		for message, ok := conn.Receive(); ok; message, ok = conn.Receive() {
+			if message.Kind == net.KindInput && slices.Contains(absoluteDirections[:], message.Direction) {
+				engine.InputRival(guest, message.Direction)
+			}
+		}
+		if conn.Closed() {
+			fmt.Println("Guest left:", conn.Err())
+			g.openResultsScreen("PLAYER LEFT", []string{fmt.Sprintf("You: %d", engine.State.Points)})
+			return
+		}
+		g.handleSnakeInput(engine)
+
+		ticks := engine.State.Ticks
+		delta := g.camFX.Step(g.holdForCountdown(g.simulationDelta(backgrounded)))
+		events := engine.Update(delta)
+		g.playEventSounds(events, &engine.State)
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		if engine.State.Ticks != ticks {
+			if err := sendLANState(conn, engine); err != nil {
+				fmt.Println("Failed to send the game state:", err)
+				g.openResultsScreen("CONNECTION LOST", []string{fmt.Sprintf("You: %d", engine.State.Points)})
+				return
+			}
+		}
+
+		hostCrashed := engine.State.Over
+		guestCrashed := !engine.State.Rivals[guest].Alive()
+		if config.Coop {
+			// A crashed partner waits for a heart, so the team is only out once the game is over
+			hostCrashed, guestCrashed = engine.State.Over, engine.State.Over
+		}
+		if hostCrashed || guestCrashed {
+			scores := [2]int{engine.State.Points, engine.State.Rivals[guest].Points}
+			if !hostCrashed {
+				scores[0] += versusSurvivalBonus
+			} else if !guestCrashed {
+				scores[1] += versusSurvivalBonus
+			}
+			if err := conn.Send(net.Message{Kind: net.KindEnd, Scores: scores}); err != nil {
+				fmt.Println("Failed to end the LAN match:", err)
+			}
+			g.openLANResults(scores, 0, config.Coop)
+			return
+		}
+
+		g.updateDebug(engine)
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		if config.SolidEdges {
+			g.drawSolidEdges()
+		}
+		rl.EndMode2D()
+		g.drawStatusTint(&engine.State)
+		g.drawEffectsHUD(engine.State.Effects)
+		if config.Coop {
+			g.drawCoopScores(&engine.State, 0)
+		} else {
+			g.drawLANScores(engine.State.Points, engine.State.Rivals[guest].Points, 0)
+		}
+
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// sendLANState sends the guest the state after a tick, leaving out the walls it already has
+func sendLANState(conn *net.Conn, engine *game.Engine) error {
+	state := engine.State
+	state.Walls = nil
+	return conn.Send(net.Message{Kind: net.KindState, State: &state})
+}
+
+// checkLANConfig checks the config a host sent before the guest builds a board from it, so a
+// bad or hostile host can't crash the game or have it allocate without bound
+func checkLANConfig(config *game.Config) error {
+	if config == nil {
+		return errors.New("the welcome has no config")
+	}
+	if _, ok := boardSizeOf(config.Width, config.Height); !ok {
+		return fmt.Errorf("a board of %dx%d cells isn't one of the board sizes", config.Width, config.Height)
+	}
+	if config.Mode < 0 || config.Mode >= game.ModeCount {
+		return fmt.Errorf("unknown mode %d", config.Mode)
+	}
+	cells := config.Width * config.Height
+	if len(config.Walls) > cells || len(config.Portals) > cells {
+		return errors.New("more walls or portals than cells")
+	}
+	for _, wall := range config.Walls {
+		if !onLANBoard(config, wall) {
+			return fmt.Errorf("wall %v is off the board", wall)
+		}
+	}
+	for _, portal := range config.Portals {
+		if !onLANBoard(config, portal.A) || !onLANBoard(config, portal.B) {
+			return fmt.Errorf("portal %v is off the board", portal)
+		}
+	}
+	return nil
+}
+
+// checkLANState checks a state the host sent against the config it welcomed the guest with
+func checkLANState(config *game.Config, state *game.State) error {
+	if state == nil {
+		return errors.New("the state message has no state")
+	}
+	cells := config.Width * config.Height
+	if len(state.Snake.Segments) == 0 || len(state.Snake.Segments) > cells {
+		return fmt.Errorf("the host's snake has %d segments", len(state.Snake.Segments))
+	}
+	if len(state.Rivals) > 1 {
+		return fmt.Errorf("%d rivals in a two player match", len(state.Rivals))
+	}
+	if len(state.Entities) > cells || len(state.Explosions) > cells {
+		return errors.New("more entities or explosions than cells")
+	}
+	if len(state.Effects) > int(game.PowerUpKindCount) || len(state.Statuses) > int(game.StatusKindCount) {
+		return errors.New("more effects than there are kinds")
+	}
+
+	segments := slices.Clone(state.Snake.Segments)
+	for _, rival := range state.Rivals {
+		if len(rival.Snake.Segments) > cells {
+			return fmt.Errorf("the guest's snake has %d segments", len(rival.Snake.Segments))
+		}
+		segments = append(segments, rival.Snake.Segments...)
+	}
+	for _, segment := range segments {
+		if !onLANBoard(config, segment) {
+			return fmt.Errorf("segment %v is off the board", segment)
+		}
+	}
+	for _, entity := range state.Entities {
+		if entity.Kind < 0 || entity.Kind >= game.EntityKindCount {
+			return fmt.Errorf("unknown entity kind %d", entity.Kind)
+		}
+		if entity.Kind == game.EntityPowerUp && (entity.Variant < 0 || entity.Variant >= int(game.PowerUpKindCount)) {
+			return fmt.Errorf("unknown power-up %d", entity.Variant)
+		}
+		if !onLANBoard(config, entity.Position) || !onLANBoard(config, entity.Link) {
+			return fmt.Errorf("entity at %v is off the board", entity.Position)
+		}
+	}
+	for _, explosion := range state.Explosions {
+		if !onLANBoard(config, explosion.Position) {
+			return fmt.Errorf("explosion at %v is off the board", explosion.Position)
+		}
+	}
+	for _, effect := range state.Effects {
+		if effect.Kind < 0 || effect.Kind >= game.PowerUpKindCount {
+			return fmt.Errorf("unknown effect %d", effect.Kind)
+		}
+	}
+	for _, status := range state.Statuses {
+		if status.Kind < 0 || status.Kind >= game.StatusKindCount {
+			return fmt.Errorf("unknown status %d", status.Kind)
+		}
+	}
+	return nil
+}
+
+// onLANBoard reports whether p is a cell of the board the config describes
+func onLANBoard(config *game.Config, p game.Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < config.Width && p.Y < config.Height
+}
+
+// playLANGuest plays a match hosted on another machine. The guest's snake is the host's rival, or
+// partner in co-op: its turns are sent to the host, and the board is drawn as the host last sent it.
+func (g *Game) playLANGuest(conn *net.Conn) {
+	defer conn.Close()
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+	defer g.audio.PlayMusic(&g.audio.MenuMusic)
+
+	// The board is drawn at the host's size
+	defer func(board BoardSize) { g.board = board }(g.board)
+
+	// The engine is only a place to load the host's states into, it never ticks
+	var engine *game.Engine
+	const guest = 0
+	g.state = StateMainMenu
+	g.particles.Clear()
+	g.camFX.Reset()
+
+	for {
+		g.audio.UpdateMusic()
+		g.updateFramePacing()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if g.controls.Pressed(input.ActionPause) {
+			return
+		}
+
+		for message, ok := conn.Receive(); ok; message, ok = conn.Receive() {
+			// A message that doesn't fit the match drops the connection, as if the host left
+			var err error
+			switch message.Kind {
+			case net.KindWelcome:
+				if err = checkLANConfig(message.Config); err == nil {
+					engine = game.NewEngine(*message.Config, 0)
+					g.board, _ = boardSizeOf(engine.Config.Width, engine.Config.Height)
+					g.startCountdown()
+				}
+			case net.KindState:
+				if engine == nil {
+					err = errors.New("a state came before the welcome")
+				} else if err = checkLANState(&engine.Config, message.State); err == nil {
+					state := *message.State
+					state.Walls = engine.Config.Walls
+					engine.Load(state)
+				}
+			case net.KindEnd:
+				g.openLANResults(message.Scores, 1, engine != nil && engine.Config.Coop)
+				return
+			}
+			if err != nil {
+				fmt.Println("Bad message from the host:", err)
+				g.openResultsScreen("CONNECTION LOST", nil)
+				return
+			}
+		}
+		if conn.Closed() {
+			fmt.Println("Host left:", conn.Err())
+			g.openResultsScreen("HOST LEFT", nil)
+			return
+		}
+
+		if engine != nil && len(engine.State.Rivals) > guest && engine.State.Rivals[guest].Alive() {
+			g.sendLANTurns(conn, engine.State.Rivals[guest].Snake)
+		}
+		g.holdForCountdown(rl.GetFrameTime())
+
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+		if engine == nil || len(engine.State.Rivals) <= guest {
+			g.drawCenteredText("Waiting for the host...", float32(g.screenHeight)/2-15, 30, rl.LightGray)
+		} else {
+			g.beginBoard(g.boardCamera())
+			g.drawBoard(&engine.State, snakePixels(engine.State.Snake))
+			if engine.Config.SolidEdges {
+				g.drawSolidEdges()
+			}
+			rl.EndMode2D()
+			if engine.Config.Coop {
+				g.drawCoopScores(&engine.State, 1)
+			} else {
+				g.drawLANScores(engine.State.Points, engine.State.Rivals[guest].Points, 1)
+			}
+			g.drawCountdown()
+		}
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// sendLANTurns sends the host the turns pressed this frame, for the guest's snake
+func (g *Game) sendLANTurns(conn *net.Conn, snake game.Snake) {
+	g.controls.Update()
+	for _, turn := range g.schemeTurns(snake.Head(), snake.Direction) {
+		if err := conn.Send(net.Message{Kind: net.KindInput, Direction: turn}); err != nil {
+			fmt.Println("Failed to send a turn:", err)
+		}
+	}
+}
+
+// drawLANScores shows both players' points, the host's in green on the left and the guest's in
+// orange on the right, marking which one is this player
+func (g *Game) drawLANScores(hostPoints, guestPoints, player int) {
+	labels := [2]string{"Host", "Guest"}
+	labels[player] = "You"
+	fontSize := float32(20)
+	hostText := fmt.Sprintf("%s: %d", labels[0], hostPoints)
+	rl.DrawTextEx(g.menu.font, hostText, rl.Vector2{X: 10, Y: 10}, fontSize, 1, rl.Green)
+	guestText := fmt.Sprintf("%s: %d", labels[1], guestPoints)
+	guestSize := rl.MeasureTextEx(g.menu.font, guestText, fontSize, 1)
+	rl.DrawTextEx(g.menu.font, guestText, rl.Vector2{X: float32(g.screenWidth) - guestSize.X - 10, Y: 10}, fontSize, 1, rl.Orange)
+}
+
+// openLANResults shows who won a LAN match, from the side of the given player: 0 the host, 1 the
+// guest, or the team's score after co-op
+func (g *Game) openLANResults(scores [2]int, player int, coop bool) {
+	if coop {
+		g.openResultsScreen(fmt.Sprintf("TEAM SCORE: %d", scores[0]+scores[1]), []string{
+			fmt.Sprintf("Host: %d", scores[0]),
+			fmt.Sprintf("Guest: %d", scores[1]),
+		})
+		return
+	}
+	titleText := "DRAW!"
+	if scores[player] > scores[1-player] {
+		titleText = "YOU WIN!"
+	} else if scores[player] < scores[1-player] {
+		titleText = "YOU LOSE!"
+	}
+	g.openResultsScreen(titleText, []string{
+		fmt.Sprintf("Host: %d", scores[0]),
+		fmt.Sprintf("Guest: %d", scores[1]),
+	})
+}
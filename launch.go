@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ztkent/snake/internal/ai"
+	"github.com/ztkent/snake/internal/config"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/levels"
+)
+
+// headlessTickLimit stops a headless game that the AI would otherwise play forever
+const headlessTickLimit = 100000
+
+// launchOptions are the command-line flags. Those that match a setting override the saved one for
+// this run only, and the saved one is kept when settings are saved unless the player changes it.
+type launchOptions struct {
+	dev        bool
+	debug      bool
+	width      int // Window size, 0 for the saved one. The canvas stays 800x450, scaled to fit
+	height     int
+	fullscreen bool
+	mute       bool
+	seed       uint64 // Seeds every game's spawns, 0 for a new seed each game
+	difficulty string // Empty for the saved difficulty
+	skipMenu   bool   // Start a game straight away instead of opening the main menu
+	headless   bool   // Play one AI game without a window and print the result
+}
+
+// parseLaunchOptions reads the command-line flags
+func parseLaunchOptions() launchOptions {
+	var options launchOptions
+	flag.BoolVar(&options.dev, "dev", false, "Enable developer controls")
+	flag.BoolVar(&options.debug, "debug", false, "Enable the F3 debug overlay and debug commands")
+	flag.IntVar(&options.width, "width", 0, "Window width for this run, the game is scaled to fit")
+	flag.IntVar(&options.height, "height", 0, "Window height for this run, the game is scaled to fit")
+	flag.BoolVar(&options.fullscreen, "fullscreen", false, "Start fullscreen, for this run")
+	flag.BoolVar(&options.mute, "mute", false, "Start muted, for this run")
+	flag.Uint64Var(&options.seed, "seed", 0, "Seed for food and bomb spawns, so every game plays out the same")
+	flag.StringVar(&options.difficulty, "difficulty", "", "Difficulty for this run: Easy, Normal or Hard")
+	flag.BoolVar(&options.skipMenu, "skip-menu", false, "Jump straight into a game")
+	flag.BoolVar(&options.headless, "headless", false, "Play one game with the AI without opening a window, and print the result")
+	flag.Parse()
+
+	// Accept any case for the difficulty, and drop names that aren't one
+	if options.difficulty != "" {
+		name := options.difficulty
+		options.difficulty = ""
+		for _, difficultyName := range difficultyNames {
+			if strings.EqualFold(name, difficultyName) {
+				options.difficulty = difficultyName
+			}
+		}
+		if options.difficulty == "" {
+			fmt.Println("Unknown difficulty, using the saved one:", name)
+		}
+	}
+	return options
+}
+
+// apply overrides the saved settings with the flags given, for opening the window and headless runs
+func (o launchOptions) apply(settings *config.Settings) {
+	if o.width > 0 {
+		settings.WindowWidth = o.width
+	}
+	if o.height > 0 {
+		settings.WindowHeight = o.height
+	}
+	if o.fullscreen {
+		settings.Fullscreen = true
+	}
+	if o.mute {
+		settings.Muted = true
+	}
+	if o.difficulty != "" {
+		settings.Difficulty = o.difficulty
+	}
+}
+
+// applyLaunchOptions puts the flags that match a setting into effect over the active profile's
+// settings. The window's were already used to open it.
+func (g *Game) applyLaunchOptions() {
+	if g.launch.mute {
+		g.audio.SetMuted(true)
+	}
+	if g.launch.difficulty != "" {
+		g.difficulty = ParseDifficulty(g.launch.difficulty)
+	}
+}
+
+// keepSaved puts back the saved value of each setting still as a flag set it, so a launch flag
+// doesn't become a lasting preference. A setting the player changed during the run is saved.
+func (o launchOptions) keepSaved(settings *config.Settings, saved config.Settings) {
+	if o.width > 0 && settings.WindowWidth == o.width {
+		settings.WindowWidth = saved.WindowWidth
+	}
+	if o.height > 0 && settings.WindowHeight == o.height {
+		settings.WindowHeight = saved.WindowHeight
+	}
+	if o.fullscreen && settings.Fullscreen {
+		settings.Fullscreen = saved.Fullscreen
+	}
+	if o.mute && settings.Muted {
+		settings.Muted = saved.Muted
+	}
+	if o.difficulty != "" && settings.Difficulty == o.difficulty {
+		settings.Difficulty = saved.Difficulty
+	}
+}
+
+// newSeed returns the seed for a new game: the one given with -seed, or else a fresh one
+func (g *Game) newSeed() uint64 {
+	if g.seed != 0 {
+		return g.seed
+	}
+	return uint64(time.Now().UnixNano())
+}
+
+// runHeadless lets the AI play one game on the saved level, board and difficulty, ticking the
+// engine as fast as it can without a window, then prints how it went
+func runHeadless(options launchOptions, settings config.Settings) {
+	cells := boardCells[ParseBoardSize(settings.BoardSize)]
+	level := levels.Find(settings.Level)
+	difficulty := ParseDifficulty(settings.Difficulty)
+	config := difficulty.Config(cells.X, cells.Y)
+	config.Walls = level.Walls(cells.X, cells.Y)
+	config.Portals = level.Portals(cells.X, cells.Y)
+
+	seed := options.seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	engine := game.NewEngine(config, seed)
+	for !engine.State.Over && engine.State.Ticks < headlessTickLimit {
+		engine.Steer(ai.NextDirection(engine))
+		engine.Tick()
+	}
+
+	state := &engine.State
+	fmt.Printf("Seed %d, %s on %s: scored %d, length %d, %d ticks (%.1fs), state hash %016x\n",
+		seed, difficulty, level.Name, state.Points, len(state.Snake.Segments), state.Ticks, engine.Duration(), engine.Hash())
+	if state.Over {
+		fmt.Println("Died:", state.Cause)
+	} else {
+		fmt.Println("Stopped after", headlessTickLimit, "ticks")
+	}
+}
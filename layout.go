@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// The UI scale enlarges or shrinks text and buttons, for big displays and players who find the
+// text too small. Every screen is laid out on the fixed canvas, so the HUD and toasts, which
+// have room around them, take the whole scale, while the menus only grow as far as their
+// layouts have room for.
+
+const (
+	minUIScale   = 0.75
+	maxUIScale   = 2.0
+	maxMenuScale = 1.2 // Past this, buttons in the tightest menus would meet their neighbours
+)
+
+// uiScale is the UI scale setting. Like menuTheme, text and buttons are drawn without the game
+// at hand, so it is kept here.
+var uiScale float32 = 1
+
+// uiScaleLabel labels the UI scale as a percentage
+func uiScaleLabel(scale float32) string {
+	return fmt.Sprintf("UI Scale: %.0f%%", scale*100)
+}
+
+// menuScale returns the scale menus are drawn at, the UI scale up to maxMenuScale
+func menuScale() float32 {
+	return min(uiScale, maxMenuScale)
+}
+
+// menuFont scales a menu font size
+func menuFont(size float32) float32 {
+	return size * menuScale()
+}
+
+// hudFont scales a font size in play, such as the HUD's
+func hudFont(size float32) float32 {
+	return size * uiScale
+}
+
+// menuButtonRect scales a button's height about its middle. Buttons side by side have no room
+// to widen, and their labels shrink to fit the width anyway.
+func menuButtonRect(rect rl.Rectangle) rl.Rectangle {
+	height := rect.Height * menuScale()
+	rect.Y -= (height - rect.Height) / 2
+	rect.Height = height
+	return rect
+}
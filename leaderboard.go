@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ztkent/snake/internal/highscores"
+	"github.com/ztkent/snake/internal/leaderboard"
+)
+
+// globalBoard is a leaderboard fetch for one board, a difficulty or a daily challenge's date
+type globalBoard struct {
+	board  string
+	scores []highscores.HighScore
+	err    error
+}
+
+// The leaderboard calls below run in the background, so they are handed the client and limit
+// rather than reading the game's, which switching profiles may replace meanwhile.
+
+// fetchGlobalBoard gets a difficulty's top limit scores from the leaderboard server, and drops
+// the result if nobody has been reading them
+func fetchGlobalBoard(client *leaderboard.Client, difficulty string, limit int, out chan<- globalBoard) {
+	scores, err := client.Top(difficulty, limit)
+	select {
+	case out <- globalBoard{board: difficulty, scores: scores, err: err}:
+	default:
+	}
+}
+
+// fetchDailyBoard gets the top scores of a day's challenge, like fetchGlobalBoard
+func fetchDailyBoard(client *leaderboard.Client, date string, limit int, out chan<- globalBoard) {
+	scores, err := client.TopDaily(date, limit)
+	select {
+	case out <- globalBoard{board: date, scores: scores, err: err}:
+	default:
+	}
+}
+
+// submitGlobalScore sends a score to the leaderboard server, then any queued from offline runs
+func submitGlobalScore(client *leaderboard.Client, score highscores.HighScore) {
+	if err := client.Submit(score); err != nil {
+		fmt.Println("Failed to submit score, queued for later:", err)
+		return
+	}
+	if err := client.Flush(); err != nil {
+		fmt.Println("Failed to submit queued scores:", err)
+	}
+}
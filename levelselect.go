@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/levels"
+)
+
+// openLevelSelect lists the built-in and custom layouts with a preview of the hovered one, and picks the
+// game mode, board edges, lives, growth per food, what bombs do, the rewind assist and whether the
+// level is mirrored or rotated. Picking a level starts the game on it.
+func (g *Game) openLevelSelect() {
+	buttonWidth := float32(200)
+	optionWidth := float32(148)
+	buttonHeight := float32(36)
+	buttonSpacing := float32(8)
+	startX := float32(g.screenWidth) * 0.08
+	startY := float32(g.screenHeight) * 0.22
+
+	all := levels.All()
+	levelButtons := make([]MenuButton, len(all))
+	for i, level := range all {
+		levelButtons[i] = NewMenuButton(
+			startX,
+			0, // Placed each frame, as the list scrolls
+			buttonWidth,
+			buttonHeight,
+			level.Name,
+			24,
+			g.menu.font,
+		)
+	}
+
+	backButton := NewMenuButton(
+		startX,
+		float32(g.screenHeight)-buttonHeight-20,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		24,
+		g.menu.font,
+	)
+
+	livesButton := NewMenuButton(
+		float32(g.screenWidth)-startX-optionWidth,
+		float32(g.screenHeight)-buttonHeight-20,
+		optionWidth,
+		buttonHeight,
+		g.livesText(),
+		24,
+		g.menu.font,
+	)
+
+	edgesButton := NewMenuButton(
+		float32(g.screenWidth)-startX-2*optionWidth-10,
+		float32(g.screenHeight)-buttonHeight-20,
+		optionWidth,
+		buttonHeight,
+		g.edgesText(),
+		24,
+		g.menu.font,
+	)
+
+	modeButton := NewMenuButton(
+		float32(g.screenWidth)-startX-3*optionWidth-20,
+		float32(g.screenHeight)-buttonHeight-20,
+		optionWidth,
+		buttonHeight,
+		"Mode: "+g.mode.String(),
+		24,
+		g.menu.font,
+	)
+
+	// A second row of rules sits above the first
+	rulesY := float32(g.screenHeight) - 2*buttonHeight - 20 - buttonSpacing
+	growthButton := NewMenuButton(
+		float32(g.screenWidth)-startX-optionWidth,
+		rulesY,
+		optionWidth,
+		buttonHeight,
+		g.growthText(),
+		24,
+		g.menu.font,
+	)
+
+	bombsButton := NewMenuButton(
+		float32(g.screenWidth)-startX-2*optionWidth-10,
+		rulesY,
+		optionWidth,
+		buttonHeight,
+		g.bombRuleText(),
+		24,
+		g.menu.font,
+	)
+
+	rewindButton := NewMenuButton(
+		float32(g.screenWidth)-startX-3*optionWidth-20,
+		rulesY,
+		optionWidth,
+		buttonHeight,
+		g.rewindText(),
+		24,
+		g.menu.font,
+	)
+
+	// The twist sits above the rules, on its own
+	twistY := rulesY - buttonHeight - buttonSpacing
+	twistButton := NewMenuButton(
+		float32(g.screenWidth)-startX-optionWidth,
+		twistY,
+		optionWidth,
+		buttonHeight,
+		"Twist: "+g.twist.String(),
+		24,
+		g.menu.font,
+	)
+
+	// The preview shows the board at a reduced scale to the right of the list, clear of the
+	// rules and with room for the level name under it
+	previewArea := float32(g.screenWidth) - startX - buttonWidth - 80
+	previewHeight := twistY - startY - 48
+	previewWidth := min(previewArea, previewHeight*float32(g.screenWidth)/float32(g.screenHeight))
+	previewScale := previewWidth / float32(g.screenWidth)
+	preview := rl.NewRectangle(
+		startX+buttonWidth+40+(previewArea-previewWidth)/2,
+		startY,
+		previewWidth,
+		float32(g.screenHeight)*previewScale,
+	)
+
+	// The list scrolls with the mouse wheel once custom levels make it longer than fits above Back,
+	// starting scrolled to the selected level
+	visibleRows := int((backButton.rect.Y - startY) / (buttonHeight + buttonSpacing))
+	maxScroll := max(0, len(all)-visibleRows)
+	scroll := 0
+	for i, level := range all {
+		if level.Name == g.level.Name {
+			scroll = min(max(0, i-visibleRows+1), maxScroll)
+		}
+	}
+
+	previewed := g.level
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		if wheel := rl.GetMouseWheelMove(); wheel != 0 {
+			scroll = min(max(0, scroll-int(wheel)), maxScroll)
+		}
+
+		mousePoint := rl.GetMousePosition()
+		for i, level := range all {
+			levelButtons[i].rect.Y = startY + float32(i-scroll)*(buttonHeight+buttonSpacing)
+			if i < scroll || i >= scroll+visibleRows {
+				continue
+			}
+			if levelButtons[i].IsHovered(mousePoint) {
+				levelButtons[i].color = rl.Gray
+				previewed = level
+				if g.menu.handleButtonClick() {
+					g.level = level
+					g.state = StateGame
+					return
+				}
+			} else if level.Name == g.level.Name {
+				levelButtons[i].color = rl.Green
+			} else {
+				levelButtons[i].color = rl.LightGray
+			}
+		}
+
+		// Clicking the mode cycles Endless -> Timed -> Zen -> Arena
+		if modeButton.IsHovered(mousePoint) {
+			modeButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.mode = g.mode.Next()
+				modeButton.text = "Mode: " + g.mode.String()
+			}
+		} else {
+			modeButton.color = rl.LightGray
+		}
+
+		// Clicking the edges button switches between wrapping round and deadly edges
+		if edgesButton.IsHovered(mousePoint) {
+			edgesButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.solidEdges = !g.solidEdges
+				edgesButton.text = g.edgesText()
+			}
+		} else {
+			edgesButton.color = rl.LightGray
+		}
+
+		// Clicking the lives button switches between one life and lives mode
+		if livesButton.IsHovered(mousePoint) {
+			livesButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.livesMode = !g.livesMode
+				livesButton.text = g.livesText()
+			}
+		} else {
+			livesButton.color = rl.LightGray
+		}
+
+		// Clicking the growth button cycles the segments grown per food from 1 to maxGrowth
+		if growthButton.IsHovered(mousePoint) {
+			growthButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.growth = g.growth%maxGrowth + 1
+				growthButton.text = g.growthText()
+			}
+		} else {
+			growthButton.color = rl.LightGray
+		}
+
+		// Clicking the bombs button switches between deadly bombs and ones that shrink the snake
+		if bombsButton.IsHovered(mousePoint) {
+			bombsButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.shrinkOnBomb = !g.shrinkOnBomb
+				bombsButton.text = g.bombRuleText()
+			}
+		} else {
+			bombsButton.color = rl.LightGray
+		}
+
+		// Clicking the rewind button turns the rewind assist on or off
+		if rewindButton.IsHovered(mousePoint) {
+			rewindButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.rewind = !g.rewind
+				rewindButton.text = g.rewindText()
+			}
+		} else {
+			rewindButton.color = rl.LightGray
+		}
+
+		// Clicking the twist cycles Off -> Mirror -> Flip -> Rotate -> Random
+		if twistButton.IsHovered(mousePoint) {
+			twistButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.twist = g.twist.Next()
+				twistButton.text = "Twist: " + g.twist.String()
+			}
+		} else {
+			twistButton.color = rl.LightGray
+		}
+
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateMainMenu
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+
+		g.drawCenteredText("SELECT LEVEL", float32(g.screenHeight)*0.06, 40, rl.DarkGreen)
+
+		for i := scroll; i < min(len(all), scroll+visibleRows); i++ {
+			levelButtons[i].Draw()
+		}
+		if maxScroll > 0 {
+			// A scroll bar beside the list
+			listHeight := float32(visibleRows)*(buttonHeight+buttonSpacing) - buttonSpacing
+			thumbHeight := listHeight * float32(visibleRows) / float32(len(all))
+			thumbY := startY + (listHeight-thumbHeight)*float32(scroll)/float32(maxScroll)
+			rl.DrawRectangleV(rl.Vector2{X: startX + buttonWidth + 6, Y: startY}, rl.Vector2{X: 4, Y: listHeight}, rl.LightGray)
+			rl.DrawRectangleV(rl.Vector2{X: startX + buttonWidth + 6, Y: thumbY}, rl.Vector2{X: 4, Y: thumbHeight}, rl.DarkGray)
+		}
+		backButton.Draw()
+		livesButton.Draw()
+		edgesButton.Draw()
+		modeButton.Draw()
+		growthButton.Draw()
+		bombsButton.Draw()
+		rewindButton.Draw()
+		twistButton.Draw()
+
+		// Draw the previewed layout, turned as it will be played unless each run picks its own way
+		layout := previewed
+		if g.twist != TwistRandom {
+			layout = previewed.Transformed(g.twist.transform(0))
+		}
+		rl.DrawRectangleRec(preview, rl.DarkGray)
+		width, height := g.boardSize()
+		cell := min(preview.Width/float32(width), preview.Height/float32(height))
+		for _, wall := range layout.Walls(width, height) {
+			rl.DrawRectangleV(
+				rl.Vector2{X: preview.X + float32(wall.X)*cell, Y: preview.Y + float32(wall.Y)*cell},
+				rl.Vector2{X: cell, Y: cell},
+				rl.LightGray,
+			)
+		}
+		for i, portal := range layout.Portals(width, height) {
+			for _, end := range []game.Point{portal.A, portal.B} {
+				rl.DrawCircleV(
+					rl.Vector2{X: preview.X + (float32(end.X)+0.5)*cell, Y: preview.Y + (float32(end.Y)+0.5)*cell},
+					cell/2,
+					portalColors[i%len(portalColors)],
+				)
+			}
+		}
+		if g.solidEdges {
+			rl.DrawRectangleLinesEx(preview, 4, edgeColor)
+		} else {
+			rl.DrawRectangleLinesEx(preview, 2, rl.Black)
+		}
+		drawTextFit(g.menu.font, levelLabel(previewed), rl.NewRectangle(preview.X, preview.Y+preview.Height+8, preview.Width, 32), 24, rl.DarkGray)
+
+		g.endFrame()
+	}
+}
+
+// levelLabel names a level under its preview, with who made it and its par for a custom one
+func levelLabel(level levels.Level) string {
+	label := level.Name
+	if level.Author != "" {
+		label += " by " + level.Author
+	}
+	if level.Par > 0 {
+		label += fmt.Sprintf(", par %d", level.Par)
+	}
+	return label
+}
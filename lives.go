@@ -0,0 +1,14 @@
+package main
+
+import "fmt"
+
+// livesModeLives is how many lives a game starts with in lives mode
+const livesModeLives = 3
+
+// livesText labels the lives mode toggle
+func (g *Game) livesText() string {
+	if g.livesMode {
+		return fmt.Sprintf("Lives: %d", livesModeLives)
+	}
+	return "Lives: 1"
+}
@@ -1,62 +1,396 @@
 package main
 
 import (
+	"fmt"
+
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/ai"
+	"github.com/ztkent/snake/internal/assets"
 	"github.com/ztkent/snake/internal/audio"
+	"github.com/ztkent/snake/internal/campaign"
+	"github.com/ztkent/snake/internal/config"
+	"github.com/ztkent/snake/internal/cosmetics"
+	"github.com/ztkent/snake/internal/credits"
+	"github.com/ztkent/snake/internal/daily"
+	"github.com/ztkent/snake/internal/game"
 	"github.com/ztkent/snake/internal/highscores"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/leaderboard"
+	"github.com/ztkent/snake/internal/levels"
+	"github.com/ztkent/snake/internal/mods"
+	"github.com/ztkent/snake/internal/particles"
+	"github.com/ztkent/snake/internal/postfx"
+	"github.com/ztkent/snake/internal/profiles"
+	"github.com/ztkent/snake/internal/speech"
+)
+
+const (
+	gameVersion   = "v0"
+	targetFPS     = 60
+	backgroundFPS = 5 // Frame rate while the window is minimized or hidden
+	fontFile      = "assets/RetroGaming.ttf"
 )
 
-// NewGame creates and initializes a new game instance
-func NewGame(screenWidth, screenHeight int32) *Game {
+// NewGame creates and initializes a new game instance with the active profile's saved settings
+func NewGame(screenWidth, screenHeight int32, options launchOptions, players profiles.List, settings config.Settings) *Game {
 	scores, err := highscores.LoadHighScores()
 	if err != nil {
 		scores = make([]highscores.HighScore, 0)
 	}
 
+	// Check assets against the manifest generated at build time
+	problems, err := assets.VerifyDir(assets.AssetsDir)
+	if err != nil {
+		fmt.Println("Failed to load asset manifest:", err)
+	}
+	for _, problem := range problems {
+		fmt.Println("Asset check failed,", problem)
+	}
+
+	credited, err := credits.LoadCredits()
+	if err != nil {
+		fmt.Println("Failed to load credits:", err)
+	}
+
+	stages, err := campaign.Load()
+	if err != nil {
+		fmt.Println("Failed to load campaign progress:", err)
+	}
+
+	attempts, err := daily.Load()
+	if err != nil {
+		fmt.Println("Failed to load daily challenge results:", err)
+	}
+
+	variants, err := mods.Load()
+	if err != nil {
+		fmt.Println("Skipped mods:", err)
+	}
+
+	loader := assets.NewManager()
 	am := audio.NewAudioManager()
-	am.LoadResources()
+	am.LoadResources(loader)
 
+	canvas := rl.LoadRenderTexture(screenWidth, screenHeight)
 	game := &Game{
 		state:        StateMainMenu,
-		volume:       100,
 		screenWidth:  screenWidth,
 		screenHeight: screenHeight,
 		running:      true,
-		menu:         NewMenuState(screenWidth, screenHeight),
+		menu:         NewMenuState(screenWidth, screenHeight, loader.Font(fontFile), am),
 		highScores:   scores,
+		credits:      credited,
 		audio:        am,
+		assets:       loader,
+		postfx:       postfx.NewPipeline(screenWidth, screenHeight),
+		campaign:     stages,
+		particles:    particles.New(maxParticles),
+		skinSprites:  loadSkinSprites(loader),
+		thumbnails:   make(map[string]rl.Texture2D),
+		atlas:        loadAtlas(loader),
+		daily:        attempts,
+		profiles:     players,
+		canvas:       canvas,
+		devMode:      options.dev,
+		debugMode:    options.debug,
+		seed:         options.seed,
+		launch:       options,
+		timeScale:    1,
+		mods:         variants,
 	}
+	speaker = speech.New()
+	// Effects draw into the canvas rather than straight to the window
+	game.postfx.Output = &game.canvas
+	game.loadProfile()
+	game.applySettings(settings)
+	game.applyLaunchOptions()
 	return game
 }
 
-// Run is the main game loop
+// applySettings puts a profile's settings into effect, at launch or on switching profiles. The
+// window keeps its size, which goes with the machine rather than the player.
+func (g *Game) applySettings(settings config.Settings) {
+	g.settings = settings
+	g.volume = settings.Volume
+	g.musicVolume = settings.MusicVolume
+	g.sfxVolume = settings.SfxVolume
+	g.audio.MusicEnabled = settings.Music
+	g.audio.SetMusicVolume(settings.MusicVolume)
+	g.audio.SetSfxVolume(settings.SfxVolume)
+	g.audio.SetMuted(settings.Muted)
+	g.controls = input.DefaultInputMap()
+	g.controls.SetBindings(settings.Controls)
+	g.scheme = ParseControlScheme(settings.Scheme)
+	g.level = levels.Find(settings.Level)
+	g.difficulty = ParseDifficulty(settings.Difficulty)
+	g.playerName = settings.PlayerName
+	g.aiSkill = ai.ParseSkill(settings.AISkill)
+	g.board = ParseBoardSize(settings.BoardSize)
+	g.livesMode = settings.LivesMode
+	g.solidEdges = settings.SolidEdges
+	g.growth = min(maxGrowth, max(1, settings.Growth))
+	g.shrinkOnBomb = settings.ShrinkOnBomb
+	g.rewind = settings.Rewind
+	g.twist = ParseBoardTwist(settings.Twist)
+	g.lanAddress = settings.LANAddress
+	g.mode = game.ParseMode(settings.Mode)
+	g.camFX.intensity = min(1, max(0, settings.ScreenShake))
+	g.skin = cosmetics.FindSkin(settings.Skin)
+	g.theme = cosmetics.FindTheme(settings.Theme)
+	g.hat = cosmetics.FindHat(settings.Hat)
+	g.stepped = settings.Stepped
+	g.hudLayout = ParseHUDLayout(settings.HUDLayout)
+	g.boardPattern = ParseBoardPattern(settings.BoardPattern)
+	g.hudCorner = ParseHUDCorner(settings.HUDCorner)
+	g.cursor = ParseCursorStyle(settings.Cursor)
+	g.noParticles = settings.NoParticles
+	g.noFlashing = settings.NoFlashing
+	g.gameSpeed = min(maxGameSpeed, max(minGameSpeed, settings.GameSpeed))
+	g.menu.static = settings.StaticMenus
+	uiScale = min(maxUIScale, max(minUIScale, settings.UIScale))
+	speaker.Enabled = settings.Speech
+
+	// Send any scores queued while the leaderboard server was unreachable
+	g.leaderboard = nil
+	if settings.Leaderboard != "" {
+		client := leaderboard.NewClient(settings.Leaderboard)
+		g.leaderboard = client
+		go func() {
+			if err := client.Flush(); err != nil {
+				fmt.Println("Failed to submit queued scores:", err)
+			}
+		}()
+	}
+
+	// A saved skin or theme can be locked again if the achievement progress was reset
+	if !g.skin.Unlock.Met(&g.achievements) {
+		g.skin = cosmetics.Skins[0]
+	}
+	if !g.hat.Unlock.Met(&g.achievements) {
+		g.hat = cosmetics.Hats[0]
+	}
+	if !g.theme.Unlock.Met(&g.achievements) {
+		g.theme = cosmetics.Themes[0]
+	}
+	g.setTheme(g.theme)
+
+	g.postfx.Enabled = settings.Effects.Enabled && g.postfx.Supported
+	g.postfx.UseCustom = settings.Effects.UseCustom && g.postfx.HasCustom()
+	for i := range min(len(settings.Effects.Intensity), len(g.postfx.Intensity)) {
+		g.postfx.Intensity[i] = settings.Effects.Intensity[i]
+	}
+}
+
+// saveSettings writes the current settings to the settings file
+func (g *Game) saveSettings() {
+	settings := g.settings
+	settings.Volume = g.volume
+	settings.MusicVolume = g.musicVolume
+	settings.SfxVolume = g.sfxVolume
+	settings.Difficulty = g.difficulty.String()
+	settings.Level = g.level.Name
+	settings.PlayerName = g.playerName
+	settings.AISkill = g.aiSkill.String()
+	settings.BoardSize = g.board.String()
+	settings.LivesMode = g.livesMode
+	settings.SolidEdges = g.solidEdges
+	settings.Growth = g.growth
+	settings.ShrinkOnBomb = g.shrinkOnBomb
+	settings.Rewind = g.rewind
+	settings.Twist = g.twist.String()
+	settings.LANAddress = g.lanAddress
+	settings.Mode = g.mode.String()
+	settings.ScreenShake = g.camFX.intensity
+	settings.Skin = g.skin.Name
+	settings.Hat = g.hat.Name
+	settings.Theme = g.theme.Name
+	settings.Stepped = g.stepped
+	settings.HUDLayout = g.hudLayout.String()
+	settings.BoardPattern = g.boardPattern.String()
+	settings.HUDCorner = g.hudCorner.String()
+	settings.Cursor = g.cursor.String()
+	settings.NoParticles = g.noParticles
+	settings.NoFlashing = g.noFlashing
+	settings.StaticMenus = g.menu.static
+	settings.GameSpeed = g.gameSpeed
+	settings.UIScale = uiScale
+	settings.Speech = speaker.Enabled
+	settings.Controls = g.controls.Bindings()
+	settings.Scheme = g.scheme.String()
+	settings.Music = g.audio.MusicEnabled
+	settings.Muted = g.audio.Muted
+	settings.Effects = config.EffectSettings{
+		Enabled:   g.postfx.Enabled,
+		UseCustom: g.postfx.UseCustom,
+		Intensity: append([]float32(nil), g.postfx.Intensity[:]...),
+	}
+
+	// The windowed size is kept while fullscreen, to restore it next launch
+	settings.Fullscreen = rl.IsWindowState(rl.FlagBorderlessWindowedMode)
+	if !settings.Fullscreen {
+		settings.WindowWidth = rl.GetScreenWidth()
+		settings.WindowHeight = rl.GetScreenHeight()
+	}
+	g.launch.keepSaved(&settings, g.settings)
+
+	if err := config.Save(g.profiles.Current().Dir, settings); err != nil {
+		fmt.Println("Failed to save settings:", err)
+	}
+	g.settings = settings
+}
+
+// Run is the main game loop. Open scenes get a frame each time round, and with none open the
+// current state's screen is opened, either pushing its scene or running its own loop until it
+// moves on to another state.
 func (g *Game) Run() {
 	for g.running && !rl.WindowShouldClose() {
+		if !g.scenes.Empty() {
+			g.runScenes()
+			continue
+		}
+
 		switch g.state {
 		case StateMainMenu:
-			g.running = g.openMainMenu()
+			g.scenes.Push(g.newMainMenuScene())
+			continue
 		case StateSettings:
-			g.openSettingsMenu()
+			g.scenes.Push(g.newSettingsScene())
+			continue
 		case StateGame:
 			g.StartGame()
+		case StateResume:
+			g.ResumeGame()
+		case StateHostLAN:
+			g.openHostLobby()
+		case StateJoinLAN:
+			g.openJoinScreen()
+		case StateTournament:
+			g.openTournament()
 		case StateGameOver:
 			g.openGameOverScreen()
 		case StateHighScores:
 			g.openHighScoresScreen()
+		case StateVersus:
+			g.StartVersus()
+		case StateAbout:
+			g.openAboutScreen()
+		case StateControls:
+			g.openControlsScreen()
+		case StateLevelSelect:
+			g.openLevelSelect()
+		case StateAchievements:
+			g.openAchievementsScreen()
+		case StateWatchAI:
+			g.openWatchAI(false)
+		case StateAttract:
+			g.openWatchAI(true)
+		case StateVersusSelect:
+			g.openVersusSelect()
+		case StateVsAI:
+			g.StartVsAI()
+		case StateCampaign:
+			g.openCampaignScreen()
+		case StateCampaignStage:
+			g.StartCampaignStage()
+		case StateDaily:
+			g.openDailyScreen()
+		case StateDailyGame:
+			g.StartDailyGame()
+		case StateAppearance:
+			g.openAppearanceScreen()
+		case StateAccessibility:
+			g.openAccessibilityScreen()
+		case StateStats:
+			g.openStatsScreen()
+		case StateBalance:
+			g.openBalanceScreen()
+		case StateProfiles:
+			g.scenes.Push(g.newProfilesScene())
+			continue
+		case StateFirstRun:
+			g.scenes.Push(g.newFirstRunScene())
+			continue
 		}
+
+		// Screens change settings as they go, save whatever the last one changed
+		g.saveSettings()
+	}
+}
+
+// updateFramePacing throttles the frame rate and pauses music while the window is minimized or hidden,
+// restoring both as soon as it is visible again. It returns true while the window is backgrounded.
+func (g *Game) updateFramePacing() bool {
+	hidden := rl.IsWindowMinimized() || rl.IsWindowHidden()
+	if hidden == g.backgrounded {
+		return hidden
 	}
+
+	g.backgrounded = hidden
+	if hidden {
+		rl.SetTargetFPS(backgroundFPS)
+		g.audio.PauseMusic()
+	} else {
+		rl.SetTargetFPS(targetFPS)
+		g.audio.ResumeMusic()
+	}
+	return hidden
 }
 
 func main() {
+	options := parseLaunchOptions()
+
+	players, err := profiles.Load()
+	if err != nil {
+		fmt.Println("Failed to load profiles:", err)
+	}
+	firstRun := !config.Exists(players.Current().Dir)
+	settings, err := config.Load(players.Current().Dir)
+	if err != nil {
+		fmt.Println("Failed to load settings, using defaults:", err)
+	}
+	if err := cosmetics.LoadThemes(); err != nil {
+		fmt.Println("Skipped themes:", err)
+	}
+	if err := levels.LoadCustom(); err != nil {
+		fmt.Println("Skipped custom levels:", err)
+	}
+	// The flags last for this run, the game is handed the saved settings to keep
+	launched := settings
+	options.apply(&launched)
+	if options.headless {
+		runHeadless(options, launched)
+		return
+	}
+
+	// The canvas is always 800x450, the window opens at its saved size
 	screenWidth := int32(800)
 	screenHeight := int32(450)
-	rl.InitWindow(screenWidth, screenHeight, "snake v0")
+	rl.SetConfigFlags(rl.FlagWindowResizable)
+	rl.InitWindow(int32(max(minWindowWidth, launched.WindowWidth)), int32(max(minWindowHeight, launched.WindowHeight)), "snake "+gameVersion)
 	defer rl.CloseWindow()
+	rl.SetWindowMinSize(minWindowWidth, minWindowHeight)
+	setWindowIcon()
+	if launched.Fullscreen {
+		rl.ToggleBorderlessWindowed()
+	}
+
+	rl.SetTargetFPS(targetFPS)
 
-	rl.SetTargetFPS(60)
+	// Escape is a bindable key and backs out of menus, it must not close the window
+	rl.SetExitKey(rl.KeyNull)
 
-	game := NewGame(screenWidth, screenHeight)
-	defer game.audio.UnloadResources()
-	defer rl.UnloadFont(game.menu.font)
+	game := NewGame(screenWidth, screenHeight, options, players, settings)
+	switch {
+	case options.skipMenu:
+		game.state = StateGame
+	case firstRun:
+		// Nothing saved yet, so the player sets up the basics before the menu
+		game.state = StateFirstRun
+	}
+	defer game.audio.Close()
+	defer game.assets.UnloadAll()
+	defer game.postfx.Unload()
+	defer game.patternCache.Unload()
+	defer rl.UnloadRenderTexture(game.canvas)
 	game.Run()
 }
@@ -3,10 +3,18 @@ package main
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/audio"
+	"github.com/ztkent/snake/internal/game"
 	"github.com/ztkent/snake/internal/highscores"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/particles"
+	"github.com/ztkent/snake/internal/postfx"
+	"github.com/ztkent/snake/internal/savegame"
+	"github.com/ztkent/snake/internal/ui"
 )
 
 // Sprite represents a falling pixel element in the background
@@ -29,6 +37,10 @@ type SnakeSegment struct {
 	direction float32
 }
 
+// menuFocus moves keyboard and gamepad focus through the buttons and widgets of whichever
+// screen is open. Only one screen takes input at a time, so they all share it.
+var menuFocus ui.Navigator
+
 // MenuState handles menu-specific UI elements and animations
 type MenuState struct {
 	sprites        []Sprite
@@ -40,13 +52,21 @@ type MenuState struct {
 	snakeSegments  []SnakeSegment
 	turnPoints     []TurnPoint
 	font           rl.Font
+	audio          *audio.AudioManager // Plays the click of a button
 	buttonReleased bool
 	screenWidth    int32
 	screenHeight   int32
+	sparkles       *particles.System // Trail behind the menu snake
+	static         bool              // Draw a still gradient instead of the falling sprites
 }
 
-func NewMenuState(screenWidth, screenHeight int32) *MenuState {
+// menuSparkle is the trail effect behind the menu snake
+var menuSparkle = particles.Sparkle.Tinted(rl.Lime)
+
+func NewMenuState(screenWidth, screenHeight int32, font rl.Font, am *audio.AudioManager) *MenuState {
 	menu := &MenuState{
+		font:           font,
+		audio:          am,
 		sprites:        make([]Sprite, 50),
 		snakePos:       rl.Vector2{X: 0, Y: float32(screenHeight - 40)},
 		snakeDir:       1,
@@ -56,6 +76,7 @@ func NewMenuState(screenWidth, screenHeight int32) *MenuState {
 		snakeSegments:  make([]SnakeSegment, 12),
 		turnPoints:     make([]TurnPoint, 0),
 		buttonReleased: true,
+		sparkles:       particles.New(128),
 		screenWidth:    screenWidth, // Initialize screen dimensions
 		screenHeight:   screenHeight,
 	}
@@ -76,35 +97,105 @@ func NewMenuState(screenWidth, screenHeight int32) *MenuState {
 		}
 	}
 
-	menu.font = rl.LoadFont("assets/RetroGaming.ttf")
 	return menu
 }
 
-// openMainMenu displays the main menu interface with Start, Settings, and Exit buttons.
-func (g *Game) openMainMenu() bool {
+// mainMenuScene is the main menu: Start (via Level Select), Difficulty, Versus, High Scores, Settings
+// and Exit, with smaller buttons for the other screens along the top. Continue heads the list while
+// there is a saved game.
+type mainMenuScene struct {
+	g *Game
+
+	saved              bool // There is a saved game, shown with the continue button
+	continueButton     MenuButton
+	startButton        MenuButton
+	difficultyButton   MenuButton
+	versusButton       MenuButton
+	highScoresButton   MenuButton
+	settingsButton     MenuButton
+	exitButton         MenuButton
+	aboutButton        MenuButton
+	profileButton      MenuButton
+	avatar             rl.Rectangle // The profile's snake beside its button, which also opens Profiles
+	achievementsButton MenuButton
+	watchButton        MenuButton
+	campaignButton     MenuButton
+	dailyButton        MenuButton
+	statsButton        MenuButton
+
+	idleSince float64 // The AI demo starts after a while without input
+
+	titleText     string
+	titleFontSize float32
+	titleSize     rl.Vector2
+	titleY        float32
+}
+
+func (g *Game) newMainMenuScene() *mainMenuScene {
 	// Start the menu music
-	g.audio.SetVolume(g.volume * .4)
+	g.audio.SetMasterVolume(g.volume * .4)
 	g.audio.PlayMusic(&g.audio.MenuMusic)
 
-	lastUpdateTime := float32(0)
 	buttonWidth := float32(200)
-	buttonHeight := float32(50)
-	buttonSpacing := float32(20)
-	startY := float32(g.screenHeight)/2 - (buttonHeight*4+buttonSpacing*3)/2 // Adjusted for new button
+	buttonHeight := float32(40)
+	buttonSpacing := float32(10)
+	startY := float32(g.screenHeight)/2 - (buttonHeight*6+buttonSpacing*5)/2 + 20 // Adjusted for new button
+
+	s := &mainMenuScene{g: g, idleSince: rl.GetTime(), saved: savegame.Exists(g.profiles.Current().Dir)}
 
-	startButton := NewMenuButton(
+	// Continue squeezes the buttons together rather than pushing them into the title
+	first := 0
+	if s.saved {
+		first = 1
+		buttonHeight, buttonSpacing = 36, 8
+	}
+	row := func(i int) float32 {
+		return startY + float32(first+i)*(buttonHeight+buttonSpacing)
+	}
+
+	s.continueButton = NewMenuButton(
 		float32(g.screenWidth)/2-buttonWidth/2,
 		startY,
 		buttonWidth,
 		buttonHeight,
+		"Continue",
+		30,
+		g.menu.font,
+	)
+
+	s.startButton = NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		row(0),
+		buttonWidth,
+		buttonHeight,
 		"Start",
 		30,
 		g.menu.font,
 	)
 
-	highScoresButton := NewMenuButton(
+	s.difficultyButton = NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		row(1),
+		buttonWidth,
+		buttonHeight,
+		g.difficulty.String(),
+		30,
+		g.menu.font,
+	)
+
+	s.versusButton = NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		row(2),
+		buttonWidth,
+		buttonHeight,
+		"Versus",
+		30,
+		g.menu.font,
+	)
+
+	s.highScoresButton = NewMenuButton(
 		float32(g.screenWidth)/2-buttonWidth/2,
-		startY+buttonHeight+buttonSpacing,
+		row(3),
 		buttonWidth,
 		buttonHeight,
 		"High Scores",
@@ -112,9 +203,9 @@ func (g *Game) openMainMenu() bool {
 		g.menu.font,
 	)
 
-	settingsButton := NewMenuButton(
+	s.settingsButton = NewMenuButton(
 		float32(g.screenWidth)/2-buttonWidth/2,
-		startY+2*(buttonHeight+buttonSpacing),
+		row(4),
 		buttonWidth,
 		buttonHeight,
 		"Settings",
@@ -122,9 +213,9 @@ func (g *Game) openMainMenu() bool {
 		g.menu.font,
 	)
 
-	exitButton := NewMenuButton(
+	s.exitButton = NewMenuButton(
 		float32(g.screenWidth)/2-buttonWidth/2,
-		startY+3*(buttonHeight+buttonSpacing),
+		row(5),
 		buttonWidth,
 		buttonHeight,
 		"Exit",
@@ -132,209 +223,442 @@ func (g *Game) openMainMenu() bool {
 		g.menu.font,
 	)
 
-	// Title configuration
-	titleText := "SNAKE!"
-	titleFontSize := float32(80)
-	titleSize := rl.MeasureTextEx(g.menu.font, titleText, titleFontSize, 1)
-	titleY := startY - titleSize.Y - buttonSpacing + 10
+	// Small About button in the top right corner
+	s.aboutButton = NewMenuButton(
+		float32(g.screenWidth)-110,
+		10,
+		100,
+		30,
+		"About",
+		20,
+		g.menu.font,
+	)
 
-	for !rl.WindowShouldClose() {
-		// Update music at consistent intervals
-		currentTime := rl.GetTime()
-		deltaTime := float32(currentTime) - lastUpdateTime
-		if deltaTime >= 1.0/60.0 { // Update at 60Hz
-			g.audio.UpdateMusic()
-		}
+	// The active profile under the About button, opening the Profiles screen
+	s.profileButton = NewMenuButton(
+		float32(g.screenWidth)-170,
+		48,
+		160,
+		26,
+		"Profile: "+g.profiles.Current().Name,
+		18,
+		g.menu.font,
+	)
+	s.avatar = rl.NewRectangle(s.profileButton.rect.X-8-3*gridSize, s.profileButton.rect.Y+3, 3*gridSize, gridSize)
 
-		// Update snake animation
-		g.menu.updateMenuSnake()
+	// Small Achievements button in the top left corner
+	s.achievementsButton = NewMenuButton(
+		10,
+		10,
+		150,
+		30,
+		"Achievements",
+		20,
+		g.menu.font,
+	)
 
-		mousePoint := rl.GetMousePosition()
+	s.watchButton = NewMenuButton(
+		170,
+		10,
+		110,
+		30,
+		"Watch AI",
+		20,
+		g.menu.font,
+	)
 
-		// Update button states
-		if startButton.IsHovered(mousePoint) {
-			startButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				g.state = StateGame
-				return true
-			}
-		} else {
-			startButton.color = rl.LightGray
-		}
+	s.campaignButton = NewMenuButton(
+		290,
+		10,
+		120,
+		30,
+		"Campaign",
+		20,
+		g.menu.font,
+	)
 
-		if highScoresButton.IsHovered(mousePoint) {
-			highScoresButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				g.state = StateHighScores
-				return true
-			}
-		} else {
-			highScoresButton.color = rl.LightGray
-		}
+	s.dailyButton = NewMenuButton(
+		420,
+		10,
+		100,
+		30,
+		"Daily",
+		20,
+		g.menu.font,
+	)
 
-		if settingsButton.IsHovered(mousePoint) {
-			settingsButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				g.state = StateSettings
-				return true
-			}
-		} else {
-			settingsButton.color = rl.LightGray
-		}
+	s.statsButton = NewMenuButton(
+		530,
+		10,
+		90,
+		30,
+		"Stats",
+		20,
+		g.menu.font,
+	)
 
-		if exitButton.IsHovered(mousePoint) {
-			exitButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				return false
-			}
-		} else {
-			exitButton.color = rl.LightGray
-		}
+	// Title configuration
+	s.titleText = "SNAKE!"
+	s.titleFontSize = 80
+	s.titleSize = rl.MeasureTextEx(g.menu.font, s.titleText, s.titleFontSize, 1)
+	s.titleY = startY - s.titleSize.Y - buttonSpacing + 20
+	return s
+}
 
-		rl.BeginDrawing()
-		rl.ClearBackground(rl.RayWhite)
+func (s *mainMenuScene) Update(dt float32) {
+	g := s.g
+	g.audio.UpdateMusic()
 
-		// Draw background first
-		g.menu.updateBackground()
+	// Update snake animation
+	g.menu.updateMenuSnake()
 
-		// Draw title with custom font
-		rl.DrawTextEx(
-			g.menu.font,
-			titleText,
-			rl.Vector2{
-				X: float32(g.screenWidth)/2 - titleSize.X/2,
-				Y: titleY,
-			},
-			titleFontSize,
-			1,
-			rl.DarkGreen,
-		)
+	if anyInput() {
+		s.idleSince = rl.GetTime()
+	} else if rl.GetTime()-s.idleSince >= attractDelay {
+		g.switchState(StateAttract)
+		return
+	}
 
-		startButton.Draw()
-		highScoresButton.Draw()
-		settingsButton.Draw()
-		exitButton.Draw()
+	// Buttons are checked in turn, highlighting the hovered one, until one is clicked
+	mousePoint := rl.GetMousePosition()
+	switch {
+	case s.saved && g.clicked(&s.continueButton, mousePoint):
+		g.switchState(StateResume)
+	case g.clicked(&s.startButton, mousePoint):
+		g.switchState(StateLevelSelect)
+	case g.clicked(&s.difficultyButton, mousePoint):
+		// Clicking the difficulty cycles Easy -> Normal -> Hard
+		g.difficulty = g.difficulty.Next()
+		s.difficultyButton.text = g.difficulty.String()
+	case g.clicked(&s.versusButton, mousePoint):
+		g.switchState(StateVersusSelect)
+	case g.clicked(&s.highScoresButton, mousePoint):
+		g.switchState(StateHighScores)
+	case g.clicked(&s.settingsButton, mousePoint):
+		// Settings opens over the menu, which is still here once it closes
+		g.state = StateSettings
+		g.scenes.Push(g.newSettingsScene())
+	case g.clicked(&s.exitButton, mousePoint):
+		g.running = false
+		g.scenes.Clear()
+	case g.clicked(&s.aboutButton, mousePoint):
+		g.switchState(StateAbout)
+	case g.clicked(&s.profileButton, mousePoint),
+		rl.CheckCollisionPointRec(mousePoint, s.avatar) && g.menu.handleButtonClick():
+		// Profiles opens over the menu, like Settings
+		g.state = StateProfiles
+		g.scenes.Push(g.newProfilesScene())
+	case g.clicked(&s.statsButton, mousePoint):
+		g.switchState(StateStats)
+	case g.clicked(&s.dailyButton, mousePoint):
+		g.switchState(StateDaily)
+	case g.clicked(&s.campaignButton, mousePoint):
+		g.switchState(StateCampaign)
+	case g.clicked(&s.watchButton, mousePoint):
+		g.switchState(StateWatchAI)
+	case g.clicked(&s.achievementsButton, mousePoint):
+		g.switchState(StateAchievements)
+	}
+}
+
+func (s *mainMenuScene) Draw() {
+	g := s.g
+	rl.ClearBackground(menuTheme.Menu)
 
-		// Draw snake at the bottom
-		g.menu.drawMenuSnake()
+	// Draw background first
+	g.menu.updateBackground()
+
+	// Draw title with custom font
+	rl.DrawTextEx(
+		g.menu.font,
+		s.titleText,
+		rl.Vector2{
+			X: float32(g.screenWidth)/2 - s.titleSize.X/2,
+			Y: s.titleY,
+		},
+		s.titleFontSize,
+		1,
+		rl.DarkGreen,
+	)
 
-		rl.EndDrawing()
+	if s.saved {
+		s.continueButton.Draw()
 	}
-	return false
+	s.startButton.Draw()
+	s.difficultyButton.Draw()
+	s.versusButton.Draw()
+	s.highScoresButton.Draw()
+	s.settingsButton.Draw()
+	s.exitButton.Draw()
+	s.aboutButton.Draw()
+	s.profileButton.Draw()
+	g.drawAvatar(s.avatar.X, s.avatar.Y)
+	s.achievementsButton.Draw()
+	s.watchButton.Draw()
+	s.campaignButton.Draw()
+	s.dailyButton.Draw()
+	s.statsButton.Draw()
+
+	// Draw snake at the bottom
+	g.menu.drawMenuSnake()
 }
 
-// openSettingsMenu displays the settings interface with volume control and a back button.
-func (g *Game) openSettingsMenu() {
-	buttonWidth := float32(200)
-	buttonHeight := float32(50)
-	buttonSpacing := float32(20)
-	startY := float32(g.screenHeight)/2 - (buttonHeight*2+buttonSpacing)/2
+// settingsScene is the settings screen: volume, music and steering, the display effects and
+// their intensities, screen shake, board size, and the way into the Controls, Appearance and
+// Accessibility screens
+type settingsScene struct {
+	g *Game
+
+	panel               settingsPanel // Volume, music and steering, shared with the pause screen
+	effects             *ui.Dropdown
+	intensities         []*ui.Slider // One per post-processing effect
+	shake               *ui.Slider
+	board               *ui.Dropdown
+	widgets             *ui.Group
+	controlsButton      MenuButton
+	appearanceButton    MenuButton
+	accessibilityButton MenuButton
+	backButton          MenuButton
+
+	instructionsY float32
+}
 
-	volumeText := fmt.Sprintf("Volume: %0.f%%", g.volume)
+// Effects are chosen from Off, On and Custom, which needs a custom shader to be loaded
+const (
+	effectsOff = iota
+	effectsOn
+	effectsCustom
+)
 
-	volumeButton := NewMenuButton(
-		float32(g.screenWidth)/2-buttonWidth/2,
-		startY,
-		buttonWidth,
+func (g *Game) newSettingsScene() *settingsScene {
+	buttonWidth := float32(300)
+	buttonHeight := float32(30)
+	buttonSpacing := float32(6)
+	effectRows := (postfx.EffectCount + 1) / 2 // The intensity sliders sit two to a row
+	buttonCount := float32(5 + settingsPanelRows + effectRows)
+	startY := float32(g.screenHeight)/2 - (buttonHeight*buttonCount+buttonSpacing*(buttonCount-1))/2
+	x := float32(g.screenWidth)/2 - buttonWidth/2
+	row := func(i float32) rl.Rectangle {
+		return rl.NewRectangle(x, startY+i*(buttonHeight+buttonSpacing), buttonWidth, buttonHeight)
+	}
+	style := g.uiStyle(30)
+
+	s := &settingsScene{g: g, instructionsY: startY - buttonSpacing*3}
+	s.panel = g.newSettingsPanel(x, startY, buttonWidth, buttonHeight, buttonSpacing)
+
+	// The effects can't be turned on when the hardware can't run the shaders
+	effectOptions := []string{"Off", "On"}
+	if g.postfx.HasCustom() {
+		effectOptions = append(effectOptions, "Custom")
+	}
+	selected := effectsOff
+	switch {
+	case g.postfx.Enabled && g.postfx.UseCustom:
+		selected = effectsCustom
+	case g.postfx.Enabled:
+		selected = effectsOn
+	}
+	if !g.postfx.Supported {
+		effectOptions, selected = []string{"N/A"}, 0
+	}
+	s.effects = ui.NewDropdown(style, row(settingsPanelRows), "Effects", effectOptions, selected)
+	s.effects.Disabled = !g.postfx.Supported
+	s.effects.OnChange = func(index int) {
+		g.postfx.Enabled = index != effectsOff
+		g.postfx.UseCustom = index == effectsCustom
+	}
+
+	s.intensities = make([]*ui.Slider, postfx.EffectCount)
+	for i := range s.intensities {
+		effect := postfx.Effect(i)
+		bounds := row(float32(settingsPanelRows + 1 + i/2))
+		bounds.Width = buttonWidth/2 - buttonSpacing/2
+		bounds.X += float32(i%2) * (bounds.Width + buttonSpacing)
+		slider := ui.NewSlider(style, bounds, 0, 1, 0.01, g.postfx.Intensity[effect], func(value float32) string {
+			return fmt.Sprintf("%s: %0.f%%", effect, value*100)
+		})
+		slider.OnChange = func(value float32) {
+			g.postfx.Intensity[effect] = value
+		}
+		s.intensities[i] = slider
+	}
+
+	// All the way down turns screen shake and hit-stop off
+	s.shake = ui.NewSlider(style, row(buttonCount-4), 0, 1, 0.01, g.camFX.intensity, shakeLabel)
+	s.shake.OnChange = func(value float32) {
+		g.camFX.intensity = value
+	}
+
+	sizes := make([]string, BoardSizeCount)
+	for i := range sizes {
+		sizes[i] = BoardSize(i).String()
+	}
+	s.board = ui.NewDropdown(style, row(buttonCount-3), "Board", sizes, int(g.board))
+	s.board.OnChange = func(index int) {
+		g.board = BoardSize(index)
+	}
+
+	widgets := s.panel.widgets()
+	widgets = append(widgets, s.effects)
+	for _, slider := range s.intensities {
+		widgets = append(widgets, slider)
+	}
+	widgets = append(widgets, s.shake, s.board)
+	s.widgets = ui.NewGroup(&menuFocus, widgets...)
+	s.updateIntensities()
+
+	// Controls, Appearance and Accessibility share a row
+	third := (buttonWidth - 2*buttonSpacing) / 3
+	s.controlsButton = NewMenuButton(
+		x,
+		startY+(buttonCount-2)*(buttonHeight+buttonSpacing),
+		third,
 		buttonHeight,
-		volumeText,
+		"Controls",
+		30,
+		g.menu.font,
+	)
+	s.appearanceButton = NewMenuButton(
+		x+third+buttonSpacing,
+		startY+(buttonCount-2)*(buttonHeight+buttonSpacing),
+		third,
+		buttonHeight,
+		"Appearance",
+		30,
+		g.menu.font,
+	)
+	s.accessibilityButton = NewMenuButton(
+		x+2*(third+buttonSpacing),
+		startY+(buttonCount-2)*(buttonHeight+buttonSpacing),
+		third,
+		buttonHeight,
+		"Accessibility",
 		30,
 		g.menu.font,
 	)
 
-	backButton := NewMenuButton(
-		float32(g.screenWidth)/2-buttonWidth/2,
-		startY+buttonHeight+buttonSpacing,
+	s.backButton = NewMenuButton(
+		x,
+		startY+(buttonCount-1)*(buttonHeight+buttonSpacing),
 		buttonWidth,
 		buttonHeight,
 		"Back",
 		30,
 		g.menu.font,
 	)
+	return s
+}
 
-	for {
-		// Escape to return to main menu
-		if rl.IsKeyReleased(rl.KeyEscape) {
-			g.state = StateMainMenu
-			return
-		}
+// updateIntensities enables the intensity sliders only while the built-in effects are in use
+func (s *settingsScene) updateIntensities() {
+	for _, slider := range s.intensities {
+		slider.Disabled = !s.g.postfx.Active() || s.g.postfx.UseCustom
+	}
+}
 
-		mousePoint := rl.GetMousePosition()
+func (s *settingsScene) Update(dt float32) {
+	g := s.g
+	mousePoint := rl.GetMousePosition()
+	s.panel.refresh(g)
 
-		// Handle volume control
-		if volumeButton.IsHovered(mousePoint) {
-			volumeButton.color = rl.Gray
-			if rl.IsKeyDown(rl.KeyLeft) {
-				vol := float32(max(0, float64(g.volume-1)))
-				if vol < 0 {
-					vol = 0
-				}
-				g.volume = vol
-				g.audio.SetVolume(vol) // Update audio volume
-				volumeText = fmt.Sprintf("Volume: %0.f%%", g.volume)
-				volumeButton.text = volumeText
-			}
-			if rl.IsKeyDown(rl.KeyRight) {
-				vol := float32(min(100, float64(g.volume+1)))
-				if vol > 100 {
-					vol = 100
-				}
-				g.volume = vol
-				g.audio.SetVolume(vol) // Update audio volume
-				volumeText = fmt.Sprintf("Volume: %0.f%%", g.volume)
-				volumeButton.text = volumeText
-			}
-		} else {
-			volumeButton.color = rl.LightGray
-		}
+	// An open dropdown takes the frame's clicks and keys
+	if s.widgets.Capturing() {
+		s.widgets.Update(mousePoint)
+		s.updateIntensities()
+		return
+	}
 
-		// Handle back button
-		if backButton.IsHovered(mousePoint) {
-			backButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				g.state = StateMainMenu
-				return
-			}
-		} else {
-			backButton.color = rl.LightGray
-		}
+	// Escape to return to main menu
+	if rl.IsKeyReleased(rl.KeyEscape) {
+		g.closeScene(StateMainMenu)
+		return
+	}
 
-		rl.BeginDrawing()
-		rl.ClearBackground(rl.RayWhite)
+	s.widgets.Update(mousePoint)
+	s.updateIntensities()
+
+	switch {
+	case g.clicked(&s.controlsButton, mousePoint):
+		g.switchState(StateControls)
+	case g.clicked(&s.appearanceButton, mousePoint):
+		g.switchState(StateAppearance)
+	case g.clicked(&s.accessibilityButton, mousePoint):
+		g.switchState(StateAccessibility)
+	case g.clicked(&s.backButton, mousePoint):
+		g.closeScene(StateMainMenu)
+	}
+}
 
-		volumeButton.Draw()
-		backButton.Draw()
+func (s *settingsScene) Draw() {
+	g := s.g
+	rl.ClearBackground(menuTheme.Menu)
 
-		// Draw instructions
-		instructionsText := "Use Left/Right arrows to adjust volume"
-		fontSize := float32(20)
-		textSize := rl.MeasureTextEx(g.menu.font, instructionsText, fontSize, 1)
-		rl.DrawTextEx(
-			g.menu.font,
-			instructionsText,
-			rl.Vector2{
-				X: float32(g.screenWidth)/2 - textSize.X/2,
-				Y: startY - buttonSpacing*2,
-			},
-			fontSize,
-			1,
-			rl.DarkGray,
-		)
+	s.controlsButton.Draw()
+	s.accessibilityButton.Draw()
+	s.appearanceButton.Draw()
+	s.backButton.Draw()
+	s.widgets.Draw()
 
-		rl.EndDrawing()
+	// Draw instructions
+	instructionsText := "Drag the sliders, or Tab to a control and use the arrow keys"
+	g.drawCenteredText(instructionsText, s.instructionsY, 20, rl.DarkGray)
+}
+
+// shakeLabel describes a screen shake intensity
+func shakeLabel(intensity float32) string {
+	if intensity == 0 {
+		return "Screen Shake: Off"
 	}
+	return fmt.Sprintf("Screen Shake: %0.f%%", intensity*100)
 }
 
-// Display a pause screen with resume and quit buttons
-func (g *Game) openPauseScreen() bool {
-	buttonWidth := float32(200)
-	buttonHeight := float32(50)
-	buttonSpacing := float32(20)
+// boardScene draws a game's board as it stands, frozen, for scenes like pause to overlay
+type boardScene struct {
+	g     *Game
+	state *game.State
+}
+
+func (s *boardScene) Update(dt float32) {}
+
+func (s *boardScene) Draw() {
+	rl.ClearBackground(s.g.theme.Background)
+	s.g.beginBoard(s.g.boardCamera())
+	s.g.drawBoard(s.state, snakePixels(s.state.Snake))
+	rl.EndMode2D()
+}
+
+// pauseScene is the pause screen over the board, with resume, photo mode, settings and quit buttons.
+// Settings swaps the buttons for a settings panel, so audio and steering can change mid-run.
+type pauseScene struct {
+	g     *Game
+	state *game.State
+
+	resumeButton    MenuButton
+	photoButton     MenuButton
+	settingsButton  MenuButton
+	quitButton      MenuButton
+	panel           settingsPanel // The settings panel is wider than the buttons it replaces, with its own way back
+	panelWidgets    *ui.Group
+	panelBackButton MenuButton
+	showSettings    bool
+
+	opened bool // Past the first frame, when the pause that opened the scene still reads as pressed
+	resume bool // Play goes on once the scene closes, rather than quitting to the menu
+}
 
-	// Create buttons
-	resumeButton := NewMenuButton(
+func (s *pauseScene) overlay() {}
+
+func (g *Game) newPauseScene(state *game.State) *pauseScene {
+	buttonWidth := float32(220)
+	buttonHeight := float32(45)
+	buttonSpacing := float32(12)
+	buttonsY := float32(g.screenHeight) * 0.45
+
+	s := &pauseScene{g: g, state: state}
+
+	s.resumeButton = NewMenuButton(
 		float32(g.screenWidth)/2-buttonWidth/2,
-		float32(g.screenHeight)*0.6,
+		buttonsY,
 		buttonWidth,
 		buttonHeight,
 		"Resume",
@@ -342,9 +666,29 @@ func (g *Game) openPauseScreen() bool {
 		g.menu.font,
 	)
 
-	quitButton := NewMenuButton(
+	s.photoButton = NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		buttonsY+buttonHeight+buttonSpacing,
+		buttonWidth,
+		buttonHeight,
+		"Photo Mode",
+		30,
+		g.menu.font,
+	)
+
+	s.settingsButton = NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		buttonsY+2*(buttonHeight+buttonSpacing),
+		buttonWidth,
+		buttonHeight,
+		"Settings",
+		30,
+		g.menu.font,
+	)
+
+	s.quitButton = NewMenuButton(
 		float32(g.screenWidth)/2-buttonWidth/2,
-		float32(g.screenHeight)*0.6+buttonHeight+buttonSpacing,
+		buttonsY+3*(buttonHeight+buttonSpacing),
 		buttonWidth,
 		buttonHeight,
 		"Quit to Menu",
@@ -352,94 +696,109 @@ func (g *Game) openPauseScreen() bool {
 		g.menu.font,
 	)
 
-	// Text configuration
-	pauseText := "PAUSED"
-	titleFontSize := float32(60)
-	statsFontSize := float32(30)
-	titleSize := rl.MeasureTextEx(g.menu.font, pauseText, titleFontSize, 1)
+	panelWidth := float32(340)
+	s.panel = g.newSettingsPanel(float32(g.screenWidth)/2-panelWidth/2, buttonsY, panelWidth, buttonHeight, buttonSpacing)
+	s.panelWidgets = ui.NewGroup(&menuFocus, s.panel.widgets()...)
+	s.panelBackButton = NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		buttonsY+settingsPanelRows*(buttonHeight+buttonSpacing),
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		30,
+		g.menu.font,
+	)
+	return s
+}
 
-	for {
-		mousePoint := rl.GetMousePosition()
+// openPauseScreen pauses over the board until play resumes, reporting false if the player quit
+func (g *Game) openPauseScreen(state *game.State) bool {
+	pause := g.newPauseScene(state)
+	g.openScenes(&boardScene{g: g, state: state}, pause)
+	return pause.resume
+}
 
-		// Handle button states
-		if resumeButton.IsHovered(mousePoint) {
-			resumeButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				g.state = StateGame
-				return true
-			}
-		} else {
-			resumeButton.color = rl.LightGray
-		}
+// close leaves the pause screen along with the board under it
+func (s *pauseScene) close(resume bool) {
+	s.resume = resume
+	s.g.scenes.Clear()
+}
 
-		if quitButton.IsHovered(mousePoint) {
-			quitButton.color = rl.Gray
-			if g.menu.handleButtonClick() {
-				g.state = StateMainMenu
-				return false
-			}
-		} else {
-			quitButton.color = rl.LightGray
-		}
+func (s *pauseScene) Update(dt float32) {
+	g := s.g
+	mousePoint := rl.GetMousePosition()
 
-		rl.BeginDrawing()
-		// Draw semi-transparent overlay
-		rl.DrawRectangle(0, 0, g.screenWidth, g.screenHeight, rl.Color{R: 0, G: 0, B: 0, A: 120})
+	// An open dropdown takes the frame's clicks and keys
+	if s.showSettings && s.panelWidgets.Capturing() {
+		s.panelWidgets.Update(mousePoint)
+		return
+	}
 
-		// Draw pause text
-		rl.DrawTextEx(
-			g.menu.font,
-			pauseText,
-			rl.Vector2{
-				X: float32(g.screenWidth)/2 - titleSize.X/2,
-				Y: float32(g.screenHeight) * 0.2,
-			},
-			titleFontSize,
-			1,
-			rl.White,
-		)
+	// Pause backs out of the settings first, then resumes
+	first := !s.opened
+	s.opened = true
+	if !first && g.controls.Pressed(input.ActionPause) {
+		if s.showSettings {
+			s.showSettings = false
+			g.saveSettings()
+			return
+		}
+		g.state = StateGame
+		s.close(true)
+		return
+	}
 
-		// Draw score
-		scoreText := fmt.Sprintf("Score: %d", g.score.points)
-		timeText := fmt.Sprintf("Time: %.1fs", g.score.duration)
+	if s.showSettings {
+		s.panel.refresh(g)
+		s.panelWidgets.Update(mousePoint)
+		if g.clicked(&s.panelBackButton, mousePoint) {
+			s.showSettings = false
+			g.saveSettings()
+		}
+		return
+	}
 
-		scoreSize := rl.MeasureTextEx(g.menu.font, scoreText, statsFontSize, 1)
-		rl.DrawTextEx(
-			g.menu.font,
-			scoreText,
-			rl.Vector2{
-				X: float32(g.screenWidth)/2 - scoreSize.X/2,
-				Y: float32(g.screenHeight) * 0.4,
-			},
-			statsFontSize,
-			1,
-			rl.Green,
-		)
+	switch {
+	case g.clicked(&s.resumeButton, mousePoint):
+		g.state = StateGame
+		g.startCountdown()
+		s.close(true)
+	case g.clicked(&s.photoButton, mousePoint):
+		g.openPhotoMode(s.state)
+	case g.clicked(&s.settingsButton, mousePoint):
+		s.showSettings = true
+	case g.clicked(&s.quitButton, mousePoint):
+		g.state = StateMainMenu
+		s.close(false)
+	}
+}
 
-		// Draw time
-		timeSize := rl.MeasureTextEx(g.menu.font, timeText, statsFontSize, 1)
-		rl.DrawTextEx(
-			g.menu.font,
-			timeText,
-			rl.Vector2{
-				X: float32(g.screenWidth)/2 - timeSize.X/2,
-				Y: float32(g.screenHeight)*0.4 + scoreSize.Y + buttonSpacing/2,
-			},
-			statsFontSize,
-			1,
-			rl.Green,
-		)
+func (s *pauseScene) Draw() {
+	g := s.g
+	statsFontSize := float32(30)
 
-		// Draw buttons
-		resumeButton.Draw()
-		quitButton.Draw()
+	// Darken the board under the pause screen
+	rl.DrawRectangle(0, 0, g.screenWidth, g.screenHeight, rl.Color{R: 0, G: 0, B: 0, A: 200})
 
-		rl.EndDrawing()
+	// Draw pause text
+	g.drawCenteredText("PAUSED", float32(g.screenHeight)*0.08, 60, rl.White)
 
-		if rl.IsKeyPressed(rl.KeyEscape) {
-			g.state = StateGame
-			return true
-		}
+	// Draw score and time
+	scoreText := fmt.Sprintf("Score: %d", g.score.points)
+	timeText := fmt.Sprintf("Time: %.1fs", g.score.duration)
+	scoreSize := rl.MeasureTextEx(g.menu.font, scoreText, statsFontSize, 1)
+	g.drawCenteredText(scoreText, float32(g.screenHeight)*0.25, statsFontSize, rl.Green)
+	g.drawCenteredText(timeText, float32(g.screenHeight)*0.25+scoreSize.Y+6, statsFontSize, rl.Green)
+
+	// Draw buttons
+	if s.showSettings {
+		s.panelBackButton.Draw()
+		s.panelWidgets.Draw()
+	} else {
+		s.resumeButton.Draw()
+		s.photoButton.Draw()
+		s.settingsButton.Draw()
+		s.quitButton.Draw()
 	}
 }
 
@@ -462,24 +821,70 @@ func (g *Game) openGameOverScreen() {
 
 	// Game Over text configuration
 	gameOverText := "GAME OVER!"
+	if g.mode == game.ModeTimed && g.score.duration >= game.TimedLength {
+		gameOverText = "TIME'S UP!"
+	}
 	titleFontSize := float32(60)
 	titleSize := rl.MeasureTextEx(g.menu.font, gameOverText, titleFontSize, 1)
+	causeText := deathMessage(g.score.cause)
 
 	// Score text configuration
 	scoreText := fmt.Sprintf("Final Score: %d", g.score.points)
 	timeText := fmt.Sprintf("Time: %.1fs", g.score.duration)
 	statsFontSize := float32(30)
 
-	// Check for high score
-	isNewHighScore := highscores.IsHighScore(g.score.points, g.highScores)
+	// Check for high score, and ask who set it
+	isNewHighScore := highscores.IsHighScore(g.score.points, g.difficulty.String(), g.highScores, g.settings.HighScores)
+	announcement := gameOverText + " " + scoreText
+	if isNewHighScore {
+		announcement += ". New high score"
+	}
+	speaker.Say(announcement)
 	if isNewHighScore {
+		// The canvas still holds the run's last frame, for the photo finish
+		snapshot := g.captureThumbnail()
+		name, ok := g.openNameEntryScreen()
+		if !ok {
+			rl.UnloadImage(snapshot)
+			return
+		}
 		newScore := highscores.HighScore{
-			Score:    g.score.points,
-			Duration: g.score.duration,
-			Date:     time.Now().Format("2006-01-02"),
+			Name:       name,
+			Score:      g.score.points,
+			Duration:   g.score.duration,
+			Difficulty: g.difficulty.String(),
+			Level:      g.level.Name,
+			Board:      g.board.String(),
+			Length:     g.score.length,
+			MaxCombo:   g.score.maxCombo,
+			Mode:       g.mode.String(),
+			SolidEdges: g.solidEdges,
+			Transform:  transformName(g.transform),
+			Cause:      causeName(g.score.cause),
+			Date:       time.Now(),
+			Version:    gameVersion,
+			Speed:      g.speedPercent(),
+			Profile:    g.profiles.Current().Name,
+		}
+		if path, err := saveThumbnail(snapshot, newScore.Date); err != nil {
+			fmt.Println("Failed to save photo finish:", err)
+		} else {
+			newScore.Thumbnail = path
+		}
+		rl.UnloadImage(snapshot)
+
+		g.highScores = highscores.UpdateHighScores(g.highScores, newScore, g.settings.HighScores)
+		if err := highscores.SaveHighScores(g.highScores); err != nil {
+			fmt.Println("Failed to save high scores:", err)
+		}
+		pruneThumbnails(g.highScores)
+		// Modded and slowed scores stay off the global leaderboard, as the rules were different
+		if g.leaderboard != nil && len(g.mods) == 0 && g.gameSpeed >= 1 {
+			// The photo finish and profile stay on this machine
+			newScore.Thumbnail = ""
+			newScore.Profile = ""
+			go submitGlobalScore(g.leaderboard, newScore)
 		}
-		g.highScores = highscores.UpdateHighScores(g.highScores, newScore)
-		highscores.SaveHighScores(g.highScores)
 	}
 
 	// Create high score text
@@ -488,6 +893,8 @@ func (g *Game) openGameOverScreen() {
 	highScoreSize := rl.MeasureTextEx(g.menu.font, highScoreText, highScoreFontSize, 1)
 
 	for {
+		g.updateFramePacing()
+
 		mousePoint := rl.GetMousePosition()
 		// Handle button interaction
 		if exitButton.IsHovered(mousePoint) {
@@ -500,8 +907,8 @@ func (g *Game) openGameOverScreen() {
 			exitButton.color = rl.LightGray
 		}
 
-		rl.BeginDrawing()
-		rl.ClearBackground(rl.RayWhite)
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
 
 		// Draw background
 		g.menu.updateBackground()
@@ -512,12 +919,15 @@ func (g *Game) openGameOverScreen() {
 			gameOverText,
 			rl.Vector2{
 				X: float32(g.screenWidth)/2 - titleSize.X/2,
-				Y: float32(g.screenHeight) * 0.2,
+				Y: float32(g.screenHeight) * 0.1,
 			},
 			titleFontSize,
 			1,
 			rl.Maroon,
 		)
+		if causeText != "" {
+			g.drawCenteredText(causeText, float32(g.screenHeight)*0.25, 24, rl.DarkGray)
+		}
 
 		scoreSize := rl.MeasureTextEx(g.menu.font, scoreText, statsFontSize, 1)
 
@@ -588,12 +998,152 @@ func (g *Game) openGameOverScreen() {
 			)
 		}
 
-		// Draw exit button
+		// Draw exit button, and any unlocks from the end of the run
 		exitButton.Draw()
-		rl.EndDrawing()
+		g.drawToasts()
+		g.endFrame()
+	}
+}
+
+// openNameEntryScreen asks for the name to record with a new high score, prefilled with the last
+// name entered, which for a new profile is its name. It returns false if the window was closed.
+func (g *Game) openNameEntryScreen() (string, bool) {
+	g.audio.Play(audio.EventHighScore)
+	entered, ok := "", false
+	g.openScenes(g.newNameScene("NEW HIGH SCORE!", "Enter your name", g.playerName, false, func(name string) {
+		entered, ok = name, true
+	}))
+	if !ok {
+		g.typing = false
+		g.running = false
+		return "", false
+	}
+	g.playerName = entered
+	return entered, true
+}
+
+// nameScene has the player type a name under a title, such as for a high score or a new profile,
+// and hands it to done once Enter is pressed. Escape closes it without one where cancelable.
+type nameScene struct {
+	g          *Game
+	title      string
+	prompt     string
+	name       []rune
+	cancelable bool
+	done       func(name string)
+	box        rl.Rectangle
+}
+
+func (g *Game) newNameScene(title, prompt, initial string, cancelable bool, done func(name string)) *nameScene {
+	g.typing = true
+	boxWidth := float32(360)
+	boxHeight := float32(60)
+	return &nameScene{
+		g:          g,
+		title:      title,
+		prompt:     prompt,
+		name:       []rune(initial),
+		cancelable: cancelable,
+		done:       done,
+		box:        rl.NewRectangle(float32(g.screenWidth)/2-boxWidth/2, float32(g.screenHeight)*0.45, boxWidth, boxHeight),
 	}
 }
 
+// valid reports whether the name typed is long enough to keep
+func (s *nameScene) valid() bool {
+	return len(strings.TrimSpace(string(s.name))) >= highscores.MinNameLength
+}
+
+func (s *nameScene) close() {
+	s.g.typing = false
+	s.g.scenes.Pop()
+}
+
+func (s *nameScene) Update(dt float32) {
+	// Every key goes to the name, none move between buttons
+	menuFocus.Hold()
+	s.g.audio.UpdateMusic()
+	if s.cancelable && rl.IsKeyReleased(rl.KeyEscape) {
+		s.close()
+		return
+	}
+
+	// Collect typed characters, GetCharPressed drains a queue of everything typed this frame
+	for char := rl.GetCharPressed(); char > 0; char = rl.GetCharPressed() {
+		if len(s.name) < highscores.MaxNameLength && isNameChar(char) {
+			s.name = append(s.name, char)
+		}
+	}
+	if (rl.IsKeyPressed(rl.KeyBackspace) || rl.IsKeyPressedRepeat(rl.KeyBackspace)) && len(s.name) > 0 {
+		s.name = s.name[:len(s.name)-1]
+	}
+
+	if rl.IsKeyPressed(rl.KeyEnter) && s.valid() {
+		s.close()
+		s.done(strings.TrimSpace(string(s.name)))
+	}
+}
+
+func (s *nameScene) Draw() {
+	g := s.g
+	rl.ClearBackground(menuTheme.Menu)
+	g.menu.updateBackground()
+
+	titleFontSize := float32(50)
+	titleSize := rl.MeasureTextEx(g.menu.font, s.title, titleFontSize, 1)
+	rl.DrawTextEx(
+		g.menu.font,
+		s.title,
+		rl.Vector2{
+			X: float32(g.screenWidth)/2 - titleSize.X/2,
+			Y: float32(g.screenHeight) * 0.15,
+		},
+		titleFontSize,
+		1,
+		rl.Gold,
+	)
+	g.drawCenteredText(s.prompt, float32(g.screenHeight)*0.33, 28, rl.DarkGray)
+
+	// Draw the text box with a blinking cursor
+	nameFontSize := float32(36)
+	rl.DrawRectangleRec(s.box, rl.LightGray)
+	rl.DrawRectangleLinesEx(s.box, 3, rl.DarkGray)
+	text := string(s.name)
+	if int(rl.GetTime()*2)%2 == 0 && len(s.name) < highscores.MaxNameLength {
+		text += "_"
+	}
+	textSize := rl.MeasureTextEx(g.menu.font, text, nameFontSize, 1)
+	rl.DrawTextEx(
+		g.menu.font,
+		text,
+		rl.Vector2{
+			X: s.box.X + textPadding,
+			Y: s.box.Y + s.box.Height/2 - textSize.Y/2,
+		},
+		nameFontSize,
+		1,
+		rl.Black,
+	)
+
+	hintText := fmt.Sprintf("%d-%d characters, Enter to save", highscores.MinNameLength, highscores.MaxNameLength)
+	if s.cancelable {
+		hintText += ", Esc to cancel"
+	}
+	hintColor := rl.Gray
+	if s.valid() {
+		hintColor = rl.DarkGreen
+	}
+	g.drawCenteredText(hintText, s.box.Y+s.box.Height+20, 20, hintColor)
+}
+
+// isNameChar reports whether a typed character may be used in a high score name
+func isNameChar(char int32) bool {
+	return (char >= 'a' && char <= 'z') ||
+		(char >= 'A' && char <= 'Z') ||
+		(char >= '0' && char <= '9') ||
+		char == ' ' || char == '-' || char == '_'
+}
+
 // Add new method for high scores screen
 func (g *Game) openHighScoresScreen() {
 	buttonWidth := float32(200)
@@ -614,15 +1164,139 @@ func (g *Game) openHighScoresScreen() {
 	statsFontSize := float32(30)
 	titleSize := rl.MeasureTextEx(g.menu.font, titleText, titleFontSize, 1)
 
+	// Controls for which board is shown, in a row under the title
+	style := g.uiStyle(20)
+	tabWidth := float32(180)
+	tabGap := float32(10)
+	tabsX := float32(g.screenWidth)/2 - tabWidth*1.5 - tabGap
+	tab := func(i float32) rl.Rectangle {
+		return rl.NewRectangle(tabsX+i*(tabWidth+tabGap), float32(g.screenHeight)*0.26, tabWidth, 30)
+	}
+
+	// Scores on this machine, or the leaderboard server's
+	global := false
+	sourceBox := ui.NewCheckbox(style, tab(0), "Global Scores", global)
+
+	// Boards are kept per difficulty, starting on the selected one
+	boardDifficulty := g.difficulty
+	difficulties := make([]string, DifficultyCount)
+	for i := range difficulties {
+		difficulties[i] = Difficulty(i).String()
+	}
+	difficultyList := ui.NewDropdown(style, tab(1), "Board", difficulties, int(boardDifficulty))
+
+	// Boards are ranked by score, but can be listed by time or date instead
+	sortOrder := highscores.SortByScore
+	orders := make([]string, highscores.SortOrderCount)
+	for i := range orders {
+		orders[i] = highscores.SortOrder(i).String()
+	}
+	sortList := ui.NewDropdown(style, tab(2), "Sort", orders, int(sortOrder))
+	widgets := ui.NewGroup(&menuFocus, sourceBox, difficultyList, sortList)
+
+	// Score table between the title and the back button
+	tableWidth := float32(g.screenWidth) * 0.8
+	table := NewTable(
+		float32(g.screenWidth)/2-tableWidth/2,
+		float32(g.screenHeight)*0.36,
+		tableWidth,
+		float32(g.screenHeight)*0.4,
+		[]TableColumn{
+			{title: "#", width: 0.06, alignRight: true},
+			{title: "Name", width: 0.22},
+			{title: "Score", width: 0.12, alignRight: true},
+			{title: "Combo", width: 0.1, alignRight: true},
+			{title: "Time", width: 0.12, alignRight: true},
+			{title: "Mode", width: 0.15},
+			{title: "Date", width: 0.23},
+		},
+		24,
+		g.menu.font,
+	)
+	// Picking a score shows its photo finish
+	table.selectable = true
+
+	// Global boards are fetched in the background, the status is shown until they arrive
+	var board []highscores.HighScore
+	status := ""
+	fetched := make(chan globalBoard, 8)
+	showBoard := func() {
+		board = nil
+		status = ""
+		switch {
+		case !global:
+			board = highscores.FilterByDifficulty(g.highScores, boardDifficulty.String())
+		case g.leaderboard == nil:
+			status = "No leaderboard server set"
+		default:
+			status = "Loading..."
+			go fetchGlobalBoard(g.leaderboard, boardDifficulty.String(), g.settings.HighScores, fetched)
+		}
+		table.SetRows(highScoreRows(board, sortOrder))
+	}
+	showBoard()
+	sourceBox.OnChange = func(checked bool) {
+		global = checked
+		showBoard()
+	}
+	difficultyList.OnChange = func(index int) {
+		boardDifficulty = Difficulty(index)
+		showBoard()
+	}
+	sortList.OnChange = func(index int) {
+		sortOrder = highscores.SortOrder(index)
+		table.SetRows(highScoreRows(board, sortOrder))
+	}
+
 	for {
-		if rl.IsKeyReleased(rl.KeyEscape) {
+		g.updateFramePacing()
+
+		mousePoint := rl.GetMousePosition()
+		// An open dropdown takes the frame's clicks and keys
+		capturing := widgets.Capturing()
+		widgets.Update(mousePoint)
+
+		if !capturing && rl.IsKeyReleased(rl.KeyEscape) {
 			g.state = StateMainMenu
 			return
 		}
 
-		mousePoint := rl.GetMousePosition()
+		if !capturing {
+			table.HandleScroll(mousePoint)
+			table.HandleClick(mousePoint)
+			table.HandleKeys()
+		}
 
-		if backButton.IsHovered(mousePoint) {
+		// Fetches for boards no longer shown are dropped
+		for len(fetched) > 0 {
+			result := <-fetched
+			if global && result.board == boardDifficulty.String() {
+				board = result.scores
+				status = ""
+				if result.err != nil {
+					fmt.Println("Failed to fetch leaderboard:", result.err)
+					status = "Leaderboard unavailable"
+				}
+				table.SetRows(highScoreRows(board, sortOrder))
+			}
+		}
+
+		// G switches between local and global scores, and Left/Right between difficulty boards
+		// while no control has focus
+		if !capturing && widgets.Focused() == nil {
+			if rl.IsKeyPressed(rl.KeyG) {
+				sourceBox.Checked = !sourceBox.Checked
+				sourceBox.OnChange(sourceBox.Checked)
+			}
+			if rl.IsKeyPressed(rl.KeyLeft) {
+				difficultyList.Select((int(boardDifficulty) + int(DifficultyCount) - 1) % int(DifficultyCount))
+			}
+			if rl.IsKeyPressed(rl.KeyRight) {
+				difficultyList.Select(int(boardDifficulty.Next()))
+			}
+		}
+
+		if !capturing && backButton.IsHovered(mousePoint) {
 			backButton.color = rl.Gray
 			if g.menu.handleButtonClick() {
 				g.state = StateMainMenu
@@ -632,8 +1306,8 @@ func (g *Game) openHighScoresScreen() {
 			backButton.color = rl.LightGray
 		}
 
-		rl.BeginDrawing()
-		rl.ClearBackground(rl.RayWhite)
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
 
 		// Draw title
 		rl.DrawTextEx(
@@ -648,35 +1322,31 @@ func (g *Game) openHighScoresScreen() {
 			rl.DarkGreen,
 		)
 
-		// Draw high scores
-		startY := float32(g.screenHeight) * 0.3
-		for i, score := range g.highScores {
-			scoreText := fmt.Sprintf("%d. Score: %d  Time: %.1fs  (%s)",
-				i+1, score.Score, score.Duration, score.Date)
-			scoreSize := rl.MeasureTextEx(g.menu.font, scoreText, statsFontSize, 1)
-			rl.DrawTextEx(
-				g.menu.font,
-				scoreText,
-				rl.Vector2{
-					X: float32(g.screenWidth)/2 - scoreSize.X/2,
-					Y: startY + float32(i)*statsFontSize*1.5,
-				},
-				statsFontSize,
-				1,
-				rl.DarkGray,
-			)
-		}
-
-		// Draw "No scores yet" if there are no high scores
-		if len(g.highScores) == 0 {
+		// Draw high scores, or why there are none
+		if len(board) > 0 {
+			table.Draw()
+			// The selected score's photo finish, beside the back button
+			if selected := table.Selected(); !global && selected >= 0 && selected < len(board) {
+				score := board[highscores.Sorted(board, sortOrder)[selected]]
+				if texture, ok := g.thumbnail(score); ok {
+					drawThumbnail(texture, table.rect.X+table.rect.Width-thumbnailWidth, float32(g.screenHeight)-thumbnailHeight-10)
+				}
+				if score.Profile != "" {
+					rl.DrawTextEx(g.menu.font, "Profile: "+score.Profile, rl.Vector2{X: table.rect.X, Y: float32(g.screenHeight) - 30}, 20, 1, rl.DarkGray)
+				}
+			}
+		} else {
 			noScoresText := "No scores yet!"
+			if status != "" {
+				noScoresText = status
+			}
 			textSize := rl.MeasureTextEx(g.menu.font, noScoresText, statsFontSize, 1)
 			rl.DrawTextEx(
 				g.menu.font,
 				noScoresText,
 				rl.Vector2{
 					X: float32(g.screenWidth)/2 - textSize.X/2,
-					Y: float32(g.screenHeight) * 0.4,
+					Y: float32(g.screenHeight) * 0.45,
 				},
 				statsFontSize,
 				1,
@@ -685,7 +1355,132 @@ func (g *Game) openHighScoresScreen() {
 		}
 
 		backButton.Draw()
-		rl.EndDrawing()
+		widgets.Draw()
+		g.endFrame()
+	}
+}
+
+// highScoreRows formats a board of scores as table rows in the given order,
+// each numbered with its rank by score
+func highScoreRows(scores []highscores.HighScore, order highscores.SortOrder) [][]string {
+	rows := make([][]string, len(scores))
+	for i, index := range highscores.Sorted(scores, order) {
+		score := scores[index]
+		// Scores saved before names were recorded have none
+		name := score.Name
+		if name == "" {
+			name = "---"
+		}
+		mode := score.Mode
+		if mode == "" {
+			mode = game.ModeEndless.String()
+		}
+		if score.SolidEdges {
+			mode += ", Solid"
+		}
+		if score.Transform != "" {
+			mode += ", " + score.Transform
+		}
+		if score.Speed != 0 {
+			mode += fmt.Sprintf(", %d%%", score.Speed)
+		}
+		rows[i] = []string{
+			fmt.Sprintf("%d", index+1),
+			name,
+			fmt.Sprintf("%d", score.Score),
+			fmt.Sprintf("%d", score.MaxCombo),
+			fmt.Sprintf("%.1fs", score.Duration),
+			mode,
+			score.Date.Format("2006-01-02"),
+		}
+	}
+	return rows
+}
+
+// openAboutScreen shows the game credits, with asset authors and licenses in a scrollable table
+func (g *Game) openAboutScreen() {
+	buttonWidth := float32(200)
+	buttonHeight := float32(50)
+
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		float32(g.screenHeight)*0.8,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		30,
+		g.menu.font,
+	)
+
+	titleText := "ABOUT"
+	titleFontSize := float32(60)
+	titleSize := rl.MeasureTextEx(g.menu.font, titleText, titleFontSize, 1)
+
+	tableWidth := float32(g.screenWidth) * 0.9
+	table := NewTable(
+		float32(g.screenWidth)/2-tableWidth/2,
+		float32(g.screenHeight)*0.3,
+		tableWidth,
+		float32(g.screenHeight)*0.45,
+		[]TableColumn{
+			{title: "Asset", width: 0.35},
+			{title: "Author", width: 0.4},
+			{title: "License", width: 0.25},
+		},
+		18,
+		g.menu.font,
+	)
+	rows := make([][]string, len(g.credits))
+	for i, credit := range g.credits {
+		rows[i] = []string{credit.Asset, credit.Author, credit.License}
+	}
+	table.SetRows(rows)
+
+	for {
+		g.updateFramePacing()
+
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		table.HandleScroll(mousePoint)
+
+		if backButton.IsHovered(mousePoint) {
+			backButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateMainMenu
+				return
+			}
+		} else {
+			backButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+
+		rl.DrawTextEx(
+			g.menu.font,
+			titleText,
+			rl.Vector2{
+				X: float32(g.screenWidth)/2 - titleSize.X/2,
+				Y: float32(g.screenHeight) * 0.05,
+			},
+			titleFontSize,
+			1,
+			rl.DarkGreen,
+		)
+		g.drawCenteredText("Snake v0, built with Go and raylib", float32(g.screenHeight)*0.2, 20, rl.DarkGray)
+
+		if len(g.credits) > 0 {
+			table.Draw()
+		} else {
+			g.drawCenteredText("Credits unavailable", float32(g.screenHeight)*0.4, 24, rl.Gray)
+		}
+
+		backButton.Draw()
+		g.endFrame()
 	}
 }
 
@@ -704,6 +1499,13 @@ func (m *MenuState) updateMenuSnake() {
 		m.snakeDir = 1
 	}
 
+	// Leave a trail from the tail
+	if !m.static {
+		tail := m.snakeSegments[m.snakeLength-1].position
+		m.sparkles.Emit(menuSparkle, rl.Vector2{X: tail.X + m.snakeSize/2, Y: tail.Y + m.snakeSize/2})
+	}
+	m.sparkles.Update(deltaTime)
+
 	// Update head segment
 	m.snakeSegments[0].position = m.snakePos
 	m.snakeSegments[0].direction = m.snakeDir
@@ -742,11 +1544,16 @@ func (m *MenuState) updateMenuSnake() {
 }
 
 func (m *MenuState) drawMenuSnake() {
+	now := rl.GetTime()
+	m.sparkles.Draw()
+
 	// Draw body segments first
 	for i := m.snakeLength - 1; i > 0; i-- {
 		segment := m.snakeSegments[i]
+		position := segment.position
+		position.Y += wiggleOffset(i, now, m.snakeSize)
 		rl.DrawRectangleV(
-			segment.position,
+			position,
 			rl.Vector2{X: m.snakeSize, Y: m.snakeSize},
 			rl.Green,
 		)
@@ -754,19 +1561,36 @@ func (m *MenuState) drawMenuSnake() {
 
 	// Draw head
 	headColor := rl.DarkGreen
+	headPos := m.snakePos
+	headPos.Y += wiggleOffset(0, now, m.snakeSize)
 	if m.snakeDir > 0 {
 		// Draw eyes on right side when moving right
-		rl.DrawRectangleV(m.snakePos, rl.Vector2{X: m.snakeSize, Y: m.snakeSize}, headColor)
-		rl.DrawCircleV(rl.Vector2{X: m.snakePos.X + m.snakeSize*0.7, Y: m.snakePos.Y + m.snakeSize*0.3}, 2, rl.White)
+		rl.DrawRectangleV(headPos, rl.Vector2{X: m.snakeSize, Y: m.snakeSize}, headColor)
+		rl.DrawCircleV(rl.Vector2{X: headPos.X + m.snakeSize*0.7, Y: headPos.Y + m.snakeSize*0.3}, 2, rl.White)
 	} else {
 		// Draw eyes on left side when moving left
-		rl.DrawRectangleV(m.snakePos, rl.Vector2{X: m.snakeSize, Y: m.snakeSize}, headColor)
-		rl.DrawCircleV(rl.Vector2{X: m.snakePos.X + m.snakeSize*0.3, Y: m.snakePos.Y + m.snakeSize*0.3}, 2, rl.White)
+		rl.DrawRectangleV(headPos, rl.Vector2{X: m.snakeSize, Y: m.snakeSize}, headColor)
+		rl.DrawCircleV(rl.Vector2{X: headPos.X + m.snakeSize*0.3, Y: headPos.Y + m.snakeSize*0.3}, 2, rl.White)
 	}
 }
 
-// Update and draw background sprites
+// wiggleOffset returns a small sine-based draw offset for a snake segment.
+// Each segment is phase shifted so the body ripples; it only affects rendering, never positions.
+func wiggleOffset(index int, now float64, size float32) float32 {
+	const (
+		amplitude = 0.15 // Fraction of the segment size
+		frequency = 6.0  // Radians per second
+		phase     = 0.8  // Radians between neighbouring segments
+	)
+	return float32(math.Sin(now*frequency-float64(index)*phase)) * size * amplitude
+}
+
+// Update and draw background sprites, or a still gradient in their place when static
 func (m *MenuState) updateBackground() {
+	if m.static {
+		rl.DrawRectangleGradientV(0, 0, m.screenWidth, m.screenHeight, menuTheme.Menu, rl.ColorBrightness(menuTheme.Menu, -0.15))
+		return
+	}
 	deltaTime := rl.GetFrameTime()
 
 	for i := range m.sprites {
@@ -787,11 +1611,17 @@ func (m *MenuState) updateBackground() {
 	}
 }
 
-// Helper method to handle button clicks safely
+// Helper method to handle button clicks safely. Pressing a focused button with the keyboard or
+// gamepad counts as a click.
 func (m *MenuState) handleButtonClick() bool {
+	if menuFocus.Activated() {
+		m.audio.Play(audio.EventMenuClick)
+		return true
+	}
 	if rl.IsMouseButtonDown(rl.MouseLeftButton) {
 		if m.buttonReleased {
 			m.buttonReleased = false
+			m.audio.Play(audio.EventMenuClick)
 			return true
 		}
 	} else {
@@ -828,7 +1658,7 @@ type MenuButton struct {
 
 func NewMenuButton(x, y, width, height float32, text string, fontSize int32, font rl.Font) MenuButton {
 	return MenuButton{
-		rect:     rl.NewRectangle(x, y, width, height),
+		rect:     menuButtonRect(rl.NewRectangle(x, y, width, height)),
 		text:     text,
 		fontSize: fontSize,
 		color:    rl.LightGray,
@@ -836,22 +1666,33 @@ func NewMenuButton(x, y, width, height float32, text string, fontSize int32, fon
 	}
 }
 
+// Draw draws the button in the menu theme. Screens color buttons light gray, or gray while
+// hovered, and the theme's button colors stand in for those.
 func (b *MenuButton) Draw() {
-	rl.DrawRectangleRec(b.rect, b.color)
-	textSize := rl.MeasureTextEx(b.font, b.text, float32(b.fontSize), 1)
-	rl.DrawTextEx(
-		b.font,
-		b.text,
-		rl.Vector2{
-			X: b.rect.X + (b.rect.Width-textSize.X)/2,
-			Y: b.rect.Y + (b.rect.Height-textSize.Y)/2,
-		},
-		float32(b.fontSize),
-		1,
-		rl.DarkGray,
-	)
+	color := b.color
+	switch color {
+	case rl.LightGray:
+		color = menuTheme.Button
+	case rl.Gray:
+		color = menuTheme.ButtonHover
+	}
+	rl.DrawRectangleRec(b.rect, color)
+	if menuFocus.Has(b.rect) {
+		rl.DrawRectangleLinesEx(b.rect, 2, rl.DarkGreen)
+		announceFocus(b.text)
+	}
+	drawTextFit(b.font, b.text, b.rect, menuFont(float32(b.fontSize)), menuTheme.Text)
 }
 
+// IsHovered reports whether the mouse is over the button, or while the keyboard or gamepad is
+// in use, whether the button has focus
 func (b *MenuButton) IsHovered(mousePoint rl.Vector2) bool {
-	return rl.CheckCollisionPointRec(mousePoint, b.rect)
+	hovered := menuFocus.Visit(b.rect)
+	if !menuFocus.Active() {
+		hovered = rl.CheckCollisionPointRec(mousePoint, b.rect)
+	}
+	if hovered {
+		menuFocus.Highlight(b.rect)
+	}
+	return hovered
 }
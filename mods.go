@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/ztkent/snake/internal/game"
+)
+
+// configureMods applies the loaded mods' config rules to a game of Play about to start
+func (g *Game) configureMods(config *game.Config) {
+	for _, mod := range g.mods {
+		mod.Configure(config)
+	}
+}
+
+// hookMods hooks the loaded mods into a game of Play, new or resumed
+func (g *Game) hookMods(engine *game.Engine) {
+	for _, mod := range g.mods {
+		engine.AddMod(mod)
+	}
+}
+
+// modsText lists the mods changing the rules, for the HUD
+func (g *Game) modsText() string {
+	names := make([]string, len(g.mods))
+	for i, mod := range g.mods {
+		names[i] = mod.Name
+	}
+	return "Mods: " + strings.Join(names, ", ")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// muteIconSize is the height of the muted speaker drawn in a bottom corner
+const muteIconSize = 20
+
+// toggleMute silences or restores all audio, keeping the volumes as they were
+func (g *Game) toggleMute() {
+	g.audio.SetMuted(!g.audio.Muted)
+	g.saveSettings()
+}
+
+// drawMuteIndicator draws a crossed out speaker while muted, in the bottom corner away from the HUD
+func (g *Game) drawMuteIndicator() {
+	if !g.audio.Muted {
+		return
+	}
+	x := float32(hudMargin)
+	if g.hudCorner.bottom() && g.hudCorner.left() {
+		x = float32(g.screenWidth) - hudMargin - muteIconSize*1.6
+	}
+	y := float32(g.screenHeight) - hudMargin - muteIconSize
+	size := float32(muteIconSize)
+	color := rl.Fade(rl.Red, 0.8)
+
+	// Speaker body and cone
+	rl.DrawRectangleRec(rl.NewRectangle(x, y+size*0.3, size*0.3, size*0.4), color)
+	rl.DrawTriangle(
+		rl.Vector2{X: x + size*0.7, Y: y},
+		rl.Vector2{X: x + size*0.2, Y: y + size*0.5},
+		rl.Vector2{X: x + size*0.7, Y: y + size},
+		color,
+	)
+	// Cross beside it
+	cross := rl.NewRectangle(x+size*0.9, y+size*0.25, size*0.5, size*0.5)
+	rl.DrawLineEx(rl.Vector2{X: cross.X, Y: cross.Y}, rl.Vector2{X: cross.X + cross.Width, Y: cross.Y + cross.Height}, 3, color)
+	rl.DrawLineEx(rl.Vector2{X: cross.X + cross.Width, Y: cross.Y}, rl.Vector2{X: cross.X, Y: cross.Y + cross.Height}, 3, color)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/particles"
+)
+
+// maxParticles is how many particles the board can show at once
+const maxParticles = 1024
+
+// effectSparkles are the sparkles left by a snake under each power-up, in its color
+var effectSparkles [game.PowerUpKindCount]particles.Effect
+
+func init() {
+	for kind, color := range powerUpColors {
+		effectSparkles[kind] = particles.Sparkle.Tinted(color)
+	}
+}
+
+// cellCenter returns the middle of a board cell in board coordinates
+func cellCenter(p game.Point) rl.Vector2 {
+	position := cellPosition(p)
+	return rl.Vector2{X: position.X + gridSize/2, Y: position.Y + gridSize/2}
+}
+
+// updateParticles emits the effects for a frame's events and moves the particles on by dt seconds.
+// A crashed snake dissolves from where it was before the fatal tick, since with lives left it has
+// already respawned. A snake under a power-up leaves sparkles in its color.
+func (g *Game) updateParticles(events []game.Event, engine *game.Engine, dt float32) {
+	if g.noParticles {
+		return
+	}
+	state := &engine.State
+	exploded := false
+	for _, event := range events {
+		switch event {
+		case game.EventAte:
+			g.particles.Emit(particles.FoodBurst, cellCenter(state.Snake.Head()))
+		case game.EventAteGolden:
+			g.particles.Emit(particles.GoldenBurst, cellCenter(state.Snake.Head()))
+		case game.EventDied, game.EventLifeLost, game.EventDowned:
+			// A co-op game ended by the partner's crash has already dissolved the downed player
+			if event == game.EventDied && state.Down {
+				continue
+			}
+			for _, segment := range engine.Previous() {
+				g.particles.Emit(particles.Dissolve, cellCenter(segment))
+			}
+			if event != game.EventLifeLost {
+				g.throwHat(engine)
+			}
+		case game.EventShrunk:
+			// The lost tail segments burst where they were
+			previous := engine.Previous()
+			for _, segment := range previous[min(len(previous), len(state.Snake.Segments)):] {
+				g.particles.Emit(particles.Explosion, cellCenter(segment))
+			}
+		case game.EventExploded:
+			exploded = true
+		}
+	}
+	if exploded {
+		for _, explosion := range state.Explosions {
+			// Explosions still have their full time on the tick they went off
+			if explosion.Remaining == game.ExplosionTime {
+				g.particles.Emit(particles.Explosion, cellCenter(explosion.Position))
+			}
+		}
+	}
+	if len(state.Effects) > 0 && !state.Over {
+		g.particles.Emit(effectSparkles[state.Effects[0].Kind], cellCenter(state.Snake.Head()))
+	}
+	g.particles.Update(dt)
+}
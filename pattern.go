@@ -0,0 +1,91 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// BoardPattern is the background drawn on the play field, to help judge which cell is which
+type BoardPattern int
+
+const (
+	PatternPlain     BoardPattern = iota // Just the board color
+	PatternGrid                          // Lines between the cells
+	PatternCheckered                     // Alternate cells shaded
+	BoardPatternCount
+)
+
+var boardPatternNames = [BoardPatternCount]string{"Plain", "Grid", "Checkered"}
+
+func (p BoardPattern) String() string {
+	return boardPatternNames[p]
+}
+
+// ParseBoardPattern returns the pattern with the given name, defaulting to Plain
+func ParseBoardPattern(name string) BoardPattern {
+	for i, patternName := range boardPatternNames {
+		if patternName == name {
+			return BoardPattern(i)
+		}
+	}
+	return PatternPlain
+}
+
+// Next cycles to the following pattern, wrapping back to Plain
+func (p BoardPattern) Next() BoardPattern {
+	return (p + 1) % BoardPatternCount
+}
+
+// patternCache holds the board pattern drawn once into a texture, so a frame draws it in one go
+// instead of a line or square per cell. It is redrawn when the pattern, board size or color changes.
+type patternCache struct {
+	texture rl.Texture2D
+	loaded  bool
+	pattern BoardPattern
+	width   int
+	height  int
+	color   rl.Color
+}
+
+// Texture returns the pattern for a board of the given size in cells, drawing it if the cached
+// one doesn't match
+func (c *patternCache) Texture(pattern BoardPattern, width, height int, color rl.Color) rl.Texture2D {
+	if c.loaded && c.pattern == pattern && c.width == width && c.height == height && c.color == color {
+		return c.texture
+	}
+	c.Unload()
+
+	// The image is built on the CPU, since the cache is filled mid-frame where the canvas is
+	// already the render target
+	var image *rl.Image
+	if pattern == PatternCheckered {
+		image = rl.GenImageChecked(width*gridSize, height*gridSize, gridSize, gridSize, color, rl.Blank)
+	} else {
+		image = rl.GenImageColor(width*gridSize, height*gridSize, rl.Blank)
+		for x := 1; x < width; x++ {
+			rl.ImageDrawRectangle(image, int32(x*gridSize), 0, 1, int32(height*gridSize), color)
+		}
+		for y := 1; y < height; y++ {
+			rl.ImageDrawRectangle(image, 0, int32(y*gridSize), int32(width*gridSize), 1, color)
+		}
+	}
+	c.texture = rl.LoadTextureFromImage(image)
+	rl.UnloadImage(image)
+	c.loaded, c.pattern, c.width, c.height, c.color = true, pattern, width, height, color
+	return c.texture
+}
+
+// Unload frees the cached texture
+func (c *patternCache) Unload() {
+	if c.loaded {
+		rl.UnloadTexture(c.texture)
+		c.loaded = false
+	}
+}
+
+// drawBoardPattern draws the chosen pattern over the board, in the theme's grid color
+func (g *Game) drawBoardPattern(width, height int) {
+	if g.boardPattern == PatternPlain {
+		return
+	}
+	rl.DrawTexture(g.patternCache.Texture(g.boardPattern, width, height, g.theme.Grid), 0, 0, rl.White)
+}
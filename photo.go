@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+const (
+	screenshotsDir = "screenshots"
+	photoScale     = 3 // Captures are rendered at this multiple of the window size
+	photoMinZoom   = 0.5
+	photoMaxZoom   = 4.0
+)
+
+// photoFilter selects which post-processing pass is applied in photo mode
+type photoFilter int
+
+const (
+	photoFilterNone photoFilter = iota
+	photoFilterEffects
+	photoFilterCustom
+)
+
+// openPhotoMode shows the paused board without the HUD, with a free camera and high resolution captures:
+//
+// - Arrow keys or mouse drag to pan
+// - Mouse wheel to zoom
+// - F to cycle filters from the post-processing set
+// - Enter or Space to save a capture to the screenshots folder
+// - Escape to return to the pause screen
+func (g *Game) openPhotoMode(state *game.State) {
+	center := rl.Vector2{X: float32(g.screenWidth) / 2, Y: float32(g.screenHeight) / 2}
+	camera := rl.Camera2D{Offset: center, Target: center, Zoom: 1}
+
+	// Photo mode picks its own filter, restore the display settings on exit
+	enabled, useCustom := g.postfx.Enabled, g.postfx.UseCustom
+	defer func() {
+		g.postfx.Enabled, g.postfx.UseCustom = enabled, useCustom
+	}()
+	filter := photoFilterNone
+	g.applyPhotoFilter(filter)
+
+	message := ""
+	messageTime := 0.0
+
+	for {
+		g.updateFramePacing()
+
+		if rl.IsKeyPressed(rl.KeyEscape) {
+			return
+		}
+
+		// Pan with the arrow keys or by dragging with the mouse
+		panSpeed := 300 * rl.GetFrameTime() / camera.Zoom
+		if rl.IsKeyDown(rl.KeyLeft) {
+			camera.Target.X -= panSpeed
+		}
+		if rl.IsKeyDown(rl.KeyRight) {
+			camera.Target.X += panSpeed
+		}
+		if rl.IsKeyDown(rl.KeyUp) {
+			camera.Target.Y -= panSpeed
+		}
+		if rl.IsKeyDown(rl.KeyDown) {
+			camera.Target.Y += panSpeed
+		}
+		if rl.IsMouseButtonDown(rl.MouseLeftButton) {
+			delta := rl.GetMouseDelta()
+			camera.Target.X -= delta.X / camera.Zoom
+			camera.Target.Y -= delta.Y / camera.Zoom
+		}
+
+		// Zoom with the mouse wheel
+		if wheel := rl.GetMouseWheelMove(); wheel != 0 {
+			camera.Zoom = min(photoMaxZoom, max(photoMinZoom, camera.Zoom*(1+wheel*0.1)))
+		}
+
+		// Cycle filters, skipping any the hardware or shader setup can't provide
+		if rl.IsKeyPressed(rl.KeyF) && g.postfx.Supported {
+			filter = (filter + 1) % (photoFilterCustom + 1)
+			if filter == photoFilterCustom && !g.postfx.HasCustom() {
+				filter = photoFilterNone
+			}
+			g.applyPhotoFilter(filter)
+		}
+
+		// Capture
+		if rl.IsKeyPressed(rl.KeyEnter) || rl.IsKeyPressed(rl.KeySpace) {
+			path, err := g.capturePhoto(camera, state)
+			if err != nil {
+				fmt.Println("Failed to save photo:", err)
+				message = "Capture failed"
+			} else {
+				message = "Saved " + path
+			}
+			messageTime = rl.GetTime()
+		}
+
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+		g.beginBoard(viewedThrough(g.boardCamera(), camera))
+		g.drawBoard(state, snakePixels(state.Snake))
+		rl.EndMode2D()
+		g.postfx.End()
+
+		// Draw controls hint and the last capture result, these are never captured
+		fontSize := float32(16)
+		hintText := "Drag/Arrows: pan  Wheel: zoom  F: filter  Enter: capture  Esc: back"
+		rl.DrawTextEx(g.menu.font, hintText, rl.Vector2{X: 10, Y: float32(g.screenHeight) - fontSize - 10}, fontSize, 1, rl.White)
+		if message != "" && rl.GetTime()-messageTime < 3 {
+			rl.DrawTextEx(g.menu.font, message, rl.Vector2{X: 10, Y: 10}, fontSize, 1, rl.Yellow)
+		}
+
+		g.endFrame()
+	}
+}
+
+func (g *Game) applyPhotoFilter(filter photoFilter) {
+	g.postfx.Enabled = filter != photoFilterNone
+	g.postfx.UseCustom = filter == photoFilterCustom
+}
+
+// capturePhoto renders the board at photoScale times the window size, applies the current filter
+// and saves it as a PNG in the screenshots folder, returning the file path
+func (g *Game) capturePhoto(camera rl.Camera2D, state *game.State) (string, error) {
+	width := g.screenWidth * photoScale
+	height := g.screenHeight * photoScale
+
+	// Scale the camera so the capture frames exactly what is on screen
+	camera = viewedThrough(g.boardCamera(), camera)
+	camera.Offset = rl.Vector2{X: camera.Offset.X * photoScale, Y: camera.Offset.Y * photoScale}
+	camera.Zoom *= photoScale
+
+	scene := rl.LoadRenderTexture(width, height)
+	defer rl.UnloadRenderTexture(scene)
+	rl.BeginTextureMode(scene)
+	rl.ClearBackground(g.theme.Background)
+	g.beginBoard(camera)
+	g.drawBoard(state, snakePixels(state.Snake))
+	rl.EndMode2D()
+	rl.EndTextureMode()
+
+	output := scene
+	if g.postfx.Active() {
+		filtered := rl.LoadRenderTexture(width, height)
+		defer rl.UnloadRenderTexture(filtered)
+		rl.BeginTextureMode(filtered)
+		g.postfx.Draw(scene.Texture, width, height)
+		rl.EndTextureMode()
+		output = filtered
+	}
+
+	if err := os.MkdirAll(screenshotsDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(screenshotsDir, fmt.Sprintf("snake-%s.png", time.Now().Format("20060102-150405")))
+
+	// Render textures are stored upside down
+	image := rl.LoadImageFromTexture(output.Texture)
+	defer rl.UnloadImage(image)
+	rl.ImageFlipVertical(image)
+	if !rl.ExportImage(*image, path) {
+		return "", fmt.Errorf("could not write %s", path)
+	}
+	return path, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/highscores"
+)
+
+// A photo finish is a thumbnail of the last frame of a high scoring run, saved with its score
+// and shown when the score is picked on the high scores screen
+const (
+	thumbnailsDir   = "thumbnails"
+	thumbnailWidth  = 160
+	thumbnailHeight = 90
+)
+
+// captureThumbnail copies the frame last drawn to the canvas, shrunk to a thumbnail.
+// The caller unloads the image.
+func (g *Game) captureThumbnail() *rl.Image {
+	image := rl.LoadImageFromTexture(g.canvas.Texture)
+	// Render textures are stored upside down
+	rl.ImageFlipVertical(image)
+	rl.ImageResize(image, thumbnailWidth, thumbnailHeight)
+	return image
+}
+
+// saveThumbnail writes a thumbnail as a PNG named after the time of its score, returning the path
+func saveThumbnail(image *rl.Image, date time.Time) (string, error) {
+	if err := os.MkdirAll(thumbnailsDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(thumbnailsDir, fmt.Sprintf("score-%s.png", date.Format("20060102-150405")))
+	if !rl.ExportImage(*image, path) {
+		return "", fmt.Errorf("could not write %s", path)
+	}
+	return path, nil
+}
+
+// pruneThumbnails deletes the thumbnails of scores no longer on any board
+func pruneThumbnails(scores []highscores.HighScore) {
+	entries, err := os.ReadDir(thumbnailsDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(thumbnailsDir, entry.Name())
+		kept := slices.ContainsFunc(scores, func(score highscores.HighScore) bool {
+			return score.Thumbnail == path
+		})
+		if !kept {
+			if err := os.Remove(path); err != nil {
+				fmt.Println("Failed to remove thumbnail:", err)
+			}
+		}
+	}
+}
+
+// thumbnail returns the texture of a score's photo finish, loading it the first time it is
+// shown. It reports false for scores without one, or whose file is gone.
+func (g *Game) thumbnail(score highscores.HighScore) (rl.Texture2D, bool) {
+	if score.Thumbnail == "" {
+		return rl.Texture2D{}, false
+	}
+	texture, seen := g.thumbnails[score.Thumbnail]
+	if !seen {
+		texture, _ = g.assets.Texture(score.Thumbnail)
+		g.thumbnails[score.Thumbnail] = texture
+	}
+	return texture, rl.IsTextureValid(texture)
+}
+
+// drawThumbnail draws a photo finish framed at x, y
+func drawThumbnail(texture rl.Texture2D, x, y float32) {
+	frame := rl.NewRectangle(x, y, thumbnailWidth, thumbnailHeight)
+	rl.DrawTexturePro(
+		texture,
+		rl.NewRectangle(0, 0, float32(texture.Width), float32(texture.Height)),
+		frame,
+		rl.Vector2{},
+		0,
+		rl.White,
+	)
+	rl.DrawRectangleLinesEx(frame, 2, rl.DarkGreen)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+var poisonColor = rl.Color{R: 150, G: 60, B: 200, A: 255}
+
+// drawPoisonApple draws a purple apple that fades as it is about to despawn
+func (g *Game) drawPoisonApple(food game.Entity) {
+	color := rl.Fade(poisonColor, 0.4+0.6*min(1, food.Remaining/2))
+	if g.atlas != nil {
+		g.drawApple(cellPosition(food.Position), color)
+		return
+	}
+	center := cellPosition(food.Position)
+	center.X += gridSize / 2
+	center.Y += gridSize / 2
+	rl.DrawCircleV(center, gridSize/2-2, color)
+}
+
+// drawStatusTint tints the screen purple while the controls are reversed, pulsing gently and
+// fading out over the last second
+func (g *Game) drawStatusTint(state *game.State) {
+	for _, status := range state.Statuses {
+		if status.Kind != game.StatusReversed {
+			continue
+		}
+		pulse := float32(0.5 + 0.5*math.Sin(rl.GetTime()*6))
+		alpha := (0.12 + 0.08*pulse) * min(1, status.Remaining)
+		rl.DrawRectangle(0, 0, g.screenWidth, g.screenHeight, rl.Fade(poisonColor, alpha))
+	}
+}
+
+// statusLines are the HUD lines for the snake's statuses, with their seconds left
+func statusLines(state *game.State) []hudLine {
+	var lines []hudLine
+	for _, status := range state.Statuses {
+		lines = append(lines, hudLine{
+			text:  fmt.Sprintf("%s: %.0fs", status.Kind, math.Ceil(float64(status.Remaining))),
+			color: poisonColor,
+			bar:   status.Remaining / status.Duration,
+		})
+	}
+	return lines
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// portalColors tell portal pairs apart, each pair taking the next color
+var portalColors = []rl.Color{
+	{R: 0, G: 200, B: 255, A: 255},
+	{R: 255, G: 140, B: 0, A: 255},
+	{R: 230, G: 60, B: 200, A: 255},
+}
+
+// drawPortalPair draws both ends of the index'th portal pair as a swirl in the pair's color,
+// blinking as a pair opened in Endless is about to close
+func (g *Game) drawPortalPair(portal game.Entity, index int) {
+	if portal.Remaining > 0 && portal.Remaining < 2 && g.blinking(8) {
+		return
+	}
+	color := portalColors[index%len(portalColors)]
+	drawPortal(portal.Position, color)
+	drawPortal(portal.Link, color)
+}
+
+// drawPortal draws three arms spinning round a dark middle
+func drawPortal(p game.Point, color rl.Color) {
+	center := cellPosition(p)
+	center.X += gridSize / 2
+	center.Y += gridSize / 2
+	rl.DrawCircleV(center, gridSize/2, rl.Fade(color, 0.3))
+	spin := float32(rl.GetTime() * 240)
+	for arm := range 3 {
+		start := spin + float32(arm)*120
+		rl.DrawRing(center, gridSize/4, gridSize/2, start, start+70, 8, color)
+	}
+	rl.DrawCircleV(center, gridSize/5, rl.Fade(rl.Black, 0.7))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+var powerUpColors = [game.PowerUpKindCount]rl.Color{
+	game.PowerUpSlow:   rl.SkyBlue,
+	game.PowerUpShield: rl.Violet,
+	game.PowerUpDouble: rl.Orange,
+	game.PowerUpShrink: rl.Pink,
+}
+
+// powerUpIcons are single letters drawn on power-ups and their HUD icons
+var powerUpIcons = [game.PowerUpKindCount]string{
+	game.PowerUpSlow:   "S",
+	game.PowerUpShield: "O",
+	game.PowerUpDouble: "2",
+	game.PowerUpShrink: "-",
+}
+
+// drawPowerUp draws a power-up as a colored cell with its letter, blinking as it is about to despawn
+func (g *Game) drawPowerUp(powerUp game.Entity) {
+	if powerUp.Remaining < 2 && g.blinking(6) {
+		return
+	}
+	position := cellPosition(powerUp.Position)
+	cell := rl.NewRectangle(position.X, position.Y, gridSize, gridSize)
+	rl.DrawRectangleRounded(cell, 0.4, 4, powerUpColors[powerUp.PowerUpKind()])
+	g.drawIcon(powerUpIcons[powerUp.PowerUpKind()], cell, 16)
+}
+
+// drawEffectsHUD shows each active effect in the bottom left as an icon with its seconds left
+// and a bar that empties as it runs out. They move to the bottom right if the HUD stats are in
+// the bottom left.
+func (g *Game) drawEffectsHUD(effects []game.Effect) {
+	iconSize := float32(28)
+	fontSize := float32(18)
+	x := float32(10)
+	if g.hudCorner == CornerBottomLeft {
+		x = float32(g.screenWidth) - float32(len(effects))*(iconSize+50)
+	}
+	y := float32(g.screenHeight) - iconSize - 16
+
+	for _, effect := range effects {
+		icon := rl.NewRectangle(x, y, iconSize, iconSize)
+		rl.DrawRectangleRounded(icon, 0.3, 4, powerUpColors[effect.Kind])
+		g.drawIcon(powerUpIcons[effect.Kind], icon, 20)
+
+		timerText := fmt.Sprintf("%.0fs", effect.Remaining)
+		rl.DrawTextEx(g.menu.font, timerText, rl.Vector2{X: x + iconSize + 4, Y: y + 4}, fontSize, 1, rl.White)
+
+		rl.DrawRectangleV(
+			rl.Vector2{X: x, Y: y + iconSize + 4},
+			rl.Vector2{X: iconSize * effect.Remaining / effect.Duration, Y: 4},
+			powerUpColors[effect.Kind],
+		)
+		x += iconSize + 50
+	}
+}
+
+// drawIcon centers a short label in a rectangle
+func (g *Game) drawIcon(text string, bounds rl.Rectangle, fontSize float32) {
+	size := rl.MeasureTextEx(g.menu.font, text, fontSize, 1)
+	rl.DrawTextEx(
+		g.menu.font,
+		text,
+		rl.Vector2{X: bounds.X + bounds.Width/2 - size.X/2, Y: bounds.Y + bounds.Height/2 - size.Y/2},
+		fontSize,
+		1,
+		rl.Black,
+	)
+}
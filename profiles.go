@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/achievements"
+	"github.com/ztkent/snake/internal/config"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/profiles"
+	"github.com/ztkent/snake/internal/render"
+	"github.com/ztkent/snake/internal/stats"
+)
+
+// loadProfile reads the active profile's stats and achievements
+func (g *Game) loadProfile() {
+	dir := g.profiles.Current().Dir
+	progress, err := achievements.Load(dir)
+	if err != nil {
+		fmt.Println("Failed to load achievements:", err)
+	}
+	lifetime, err := stats.Load(dir)
+	if err != nil {
+		fmt.Println("Failed to load stats:", err)
+	}
+	g.achievements = progress
+	g.stats = lifetime
+}
+
+// switchProfile saves the active profile's settings and puts another's into effect, without a
+// restart
+func (g *Game) switchProfile(index int) {
+	if index == g.profiles.Active {
+		return
+	}
+	g.saveSettings()
+	g.profiles.Active = index
+	if err := profiles.Save(g.profiles); err != nil {
+		fmt.Println("Failed to save profiles:", err)
+	}
+
+	settings, err := config.Load(g.profiles.Current().Dir)
+	if err != nil {
+		fmt.Println("Failed to load settings, using defaults:", err)
+	}
+	g.loadProfile()
+	g.applySettings(settings)
+}
+
+// avatarSnake is the short snake drawn beside the profile on the main menu, head first
+var avatarSnake = game.Snake{Segments: []game.Point{{X: 2}, {X: 1}, {X: 0}}, Direction: game.Right}
+
+// drawAvatar draws the active profile's snake, in its skin and theme, with its tail at x, y
+func (g *Game) drawAvatar(x, y float32) {
+	segments := make([]rl.Vector2, len(avatarSnake.Segments))
+	for i, cell := range avatarSnake.Segments {
+		segments[i] = rl.Vector2{X: x + float32(cell.X*gridSize), Y: y + float32(cell.Y*gridSize)}
+	}
+	pieces := render.SnakePieces(avatarSnake, len(avatarSnake.Segments), 1)
+	g.drawSkinned(g.theme.Skinned(g.skin), pieces, segments)
+}
+
+// profileError explains why a profile couldn't be made
+func profileError(err error) string {
+	switch {
+	case errors.Is(err, profiles.ErrTaken):
+		return "A profile already has that name"
+	case errors.Is(err, profiles.ErrFull):
+		return fmt.Sprintf("There can be at most %d profiles", profiles.MaxProfiles)
+	}
+	return "Couldn't make the profile"
+}
+
+// Size of the buttons on the Profiles screen, which fit MaxProfiles above New Profile and Back
+const (
+	profileButtonWidth   = 300
+	profileButtonHeight  = 34
+	profileButtonSpacing = 8
+)
+
+// profilesScene lists the players' profiles, each with their own settings, stats and
+// achievements. Clicking one switches to it, and New Profile names a new one.
+type profilesScene struct {
+	g *Game
+
+	profileButtons []MenuButton
+	newButton      MenuButton
+	backButton     MenuButton
+	status         string // Why the last new profile couldn't be made, if it couldn't
+	switched       bool   // A profile was switched to, so the menu is laid out anew for it
+}
+
+func (g *Game) newProfilesScene() *profilesScene {
+	s := &profilesScene{g: g}
+	s.layout()
+	s.newButton = NewMenuButton(
+		float32(g.screenWidth)/2-profileButtonWidth-profileButtonSpacing/2,
+		float32(g.screenHeight)-profileButtonHeight-20,
+		profileButtonWidth,
+		profileButtonHeight,
+		"New Profile",
+		28,
+		g.menu.font,
+	)
+	s.backButton = NewMenuButton(
+		float32(g.screenWidth)/2+profileButtonSpacing/2,
+		float32(g.screenHeight)-profileButtonHeight-20,
+		profileButtonWidth,
+		profileButtonHeight,
+		"Back",
+		28,
+		g.menu.font,
+	)
+	return s
+}
+
+// layout places a button for each profile, marking the active one
+func (s *profilesScene) layout() {
+	g := s.g
+	startY := float32(g.screenHeight) * 0.18
+	x := float32(g.screenWidth)/2 - profileButtonWidth/2
+
+	s.profileButtons = s.profileButtons[:0]
+	for i, profile := range g.profiles.Profiles {
+		text := profile.Name
+		if i == g.profiles.Active {
+			text = "> " + text + " <"
+		}
+		y := startY + float32(i)*(profileButtonHeight+profileButtonSpacing)
+		s.profileButtons = append(s.profileButtons, NewMenuButton(x, y, profileButtonWidth, profileButtonHeight, text, 28, g.menu.font))
+	}
+}
+
+// switchTo makes a profile the active one
+func (s *profilesScene) switchTo(index int) {
+	s.g.switchProfile(index)
+	s.switched = true
+	s.status = ""
+	s.layout()
+	announceFocus("Profile: " + s.g.profiles.Current().Name)
+}
+
+// add makes a profile named name and switches to it
+func (s *profilesScene) add(name string) {
+	g := s.g
+	index, err := g.profiles.Add(name)
+	if err != nil {
+		fmt.Println("Failed to add profile:", err)
+		s.status = profileError(err)
+		return
+	}
+	// A new profile starts from the defaults, under its own name
+	settings := config.Default()
+	settings.PlayerName = name
+	if err := config.Save(g.profiles.Profiles[index].Dir, settings); err != nil {
+		fmt.Println("Failed to save settings:", err)
+	}
+	s.switchTo(index)
+}
+
+// close goes back to the main menu, laid out anew if the profile changed
+func (s *profilesScene) close() {
+	if s.switched {
+		s.g.switchState(StateMainMenu)
+		return
+	}
+	s.g.closeScene(StateMainMenu)
+}
+
+func (s *profilesScene) Update(dt float32) {
+	g := s.g
+	g.audio.UpdateMusic()
+	if rl.IsKeyReleased(rl.KeyEscape) {
+		s.close()
+		return
+	}
+
+	mousePoint := rl.GetMousePosition()
+	for i := range s.profileButtons {
+		if g.clicked(&s.profileButtons[i], mousePoint) {
+			s.switchTo(i)
+			return
+		}
+	}
+	switch {
+	case g.clicked(&s.newButton, mousePoint):
+		if len(g.profiles.Profiles) >= profiles.MaxProfiles {
+			s.status = profileError(profiles.ErrFull)
+			break
+		}
+		g.scenes.Push(g.newNameScene("NEW PROFILE", "Name the profile", "", true, s.add))
+	case g.clicked(&s.backButton, mousePoint):
+		s.close()
+	}
+}
+
+func (s *profilesScene) Draw() {
+	g := s.g
+	rl.ClearBackground(menuTheme.Menu)
+	g.menu.updateBackground()
+	g.drawCenteredText("PROFILES", float32(g.screenHeight)*0.06, 40, rl.DarkGreen)
+	for i := range s.profileButtons {
+		s.profileButtons[i].Draw()
+	}
+	note := "Each profile keeps its own settings, stats and achievements"
+	color := rl.Gray
+	if s.status != "" {
+		note, color = s.status, rl.Maroon
+	}
+	g.drawCenteredText(note, s.newButton.rect.Y-30, 20, color)
+	s.newButton.Draw()
+	s.backButton.Draw()
+}
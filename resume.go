@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ztkent/snake/internal/achievements"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/levels"
+	"github.com/ztkent/snake/internal/savegame"
+)
+
+// saveGame keeps a game quit part way through, to continue from the main menu
+func (g *Game) saveGame(engine *game.Engine) {
+	random, err := engine.Random()
+	if err != nil {
+		fmt.Println("Failed to save the game:", err)
+		return
+	}
+	run := &savegame.Run{
+		Level:      g.level.Name,
+		Difficulty: g.difficulty.String(),
+		Board:      g.board.String(),
+		Transform:  transformName(g.transform),
+		Config:     engine.Config,
+		State:      engine.State,
+		Seed:       engine.Seed(),
+		Random:     random,
+	}
+	if err := savegame.Save(g.profiles.Current().Dir, run); err != nil {
+		fmt.Println("Failed to save the game:", err)
+	}
+}
+
+// deleteSavedGame drops the saved game once it has been played to the end
+func (g *Game) deleteSavedGame() {
+	if err := savegame.Delete(g.profiles.Current().Dir); err != nil {
+		fmt.Println("Failed to delete the saved game:", err)
+	}
+}
+
+// quitGame leaves a game before it ended, saving it to continue later. Quitting at the offer to
+// rewind a crash ends the game instead.
+func (g *Game) quitGame(engine *game.Engine, stats achievements.Run, resumed bool) {
+	if !engine.State.Over {
+		g.saveGame(engine)
+		return
+	}
+	if resumed {
+		g.deleteSavedGame()
+	}
+	g.recordRun(engine)
+	g.finishAchievements(stats)
+}
+
+// ResumeGame continues the saved game on the level, difficulty and board it was played on,
+// after a countdown. A save that can't be read is dropped.
+func (g *Game) ResumeGame() {
+	g.state = StateMainMenu
+	run, err := savegame.Load(g.profiles.Current().Dir)
+	if err != nil || run == nil {
+		fmt.Println("Failed to load the saved game:", err)
+		g.deleteSavedGame()
+		return
+	}
+	engine, err := game.Resume(run.Config, run.State, run.Seed, run.Random)
+	if err != nil {
+		fmt.Println("Failed to resume the saved game:", err)
+		g.deleteSavedGame()
+		return
+	}
+
+	g.level = levels.Find(run.Level)
+	g.difficulty = ParseDifficulty(run.Difficulty)
+	g.board = ParseBoardSize(run.Board)
+	g.transform = levels.ParseTransform(run.Transform)
+	g.mode = run.Config.Mode
+	g.solidEdges = run.Config.SolidEdges
+	g.state = StateGame
+	g.hookMods(engine)
+	g.playGame(engine, true)
+}
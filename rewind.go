@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+)
+
+// rewindText labels the rewind assist option
+func (g *Game) rewindText() string {
+	if g.rewind {
+		return "Rewind: On"
+	}
+	return "Rewind: Off"
+}
+
+// rewindToast says what a rewind cost and how many are left
+func rewindToast(engine *game.Engine) string {
+	return fmt.Sprintf("Rewound %ds: -%d points, %d left", game.RewindSeconds, game.RewindPenalty, game.RewindUses-engine.State.Rewinds)
+}
+
+// drawRewindPrompt darkens the crash and offers to rewind it, while the game waits on the choice
+func (g *Game) drawRewindPrompt(engine *game.Engine) {
+	if !engine.CanRewind() {
+		return
+	}
+	rl.DrawRectangle(0, 0, g.screenWidth, g.screenHeight, rl.Fade(rl.Black, 0.5))
+	y := float32(g.screenHeight)/2 - 60
+	g.drawCenteredText(deathMessage(engine.State.Cause), y, 36, rl.Red)
+	g.drawCenteredText(
+		fmt.Sprintf("Press R to rewind %d seconds (-%d points, %d left)", game.RewindSeconds, game.RewindPenalty, game.RewindUses-engine.State.Rewinds),
+		y+56, 24, rl.White,
+	)
+	g.drawCenteredText("Enter to end the game", y+90, 20, rl.LightGray)
+}
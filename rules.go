@@ -0,0 +1,19 @@
+package main
+
+import "fmt"
+
+// maxGrowth is the most segments a food can grow the snake by
+const maxGrowth = 3
+
+// bombRuleText labels the option for what hitting a bomb does
+func (g *Game) bombRuleText() string {
+	if g.shrinkOnBomb {
+		return "Bombs: Shrink"
+	}
+	return "Bombs: Deadly"
+}
+
+// growthText labels the growth per food option
+func (g *Game) growthText() string {
+	return fmt.Sprintf("Growth: %d", g.growth)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Scene is a screen driven a frame at a time by the main loop. Update handles the frame's input
+// and moves the scene on by dt seconds, Draw renders it into the current frame.
+type Scene interface {
+	Update(dt float32)
+	Draw()
+}
+
+// overlay is a scene drawn over the one below it, rather than hiding it
+type overlay interface {
+	Scene
+	overlay()
+}
+
+// sceneStack holds the open scenes, the top one is updated and drawn over any it overlays
+type sceneStack struct {
+	scenes []Scene
+}
+
+func (s *sceneStack) Push(scene Scene) {
+	s.scenes = append(s.scenes, scene)
+}
+
+// Pop closes the top scene, uncovering the one below
+func (s *sceneStack) Pop() {
+	if len(s.scenes) > 0 {
+		s.scenes = s.scenes[:len(s.scenes)-1]
+	}
+}
+
+// Clear closes every scene
+func (s *sceneStack) Clear() {
+	s.scenes = s.scenes[:0]
+}
+
+// Top returns the scene being updated, nil when there are none
+func (s *sceneStack) Top() Scene {
+	if len(s.scenes) == 0 {
+		return nil
+	}
+	return s.scenes[len(s.scenes)-1]
+}
+
+func (s *sceneStack) Empty() bool {
+	return len(s.scenes) == 0
+}
+
+func (s *sceneStack) Update(dt float32) {
+	if top := s.Top(); top != nil {
+		top.Update(dt)
+	}
+}
+
+// Draw draws the top scene over every overlay under it, and the first scene that isn't one
+func (s *sceneStack) Draw() {
+	bottom := len(s.scenes) - 1
+	for bottom > 0 {
+		if _, ok := s.scenes[bottom].(overlay); !ok {
+			break
+		}
+		bottom--
+	}
+	for _, scene := range s.scenes[max(0, bottom):] {
+		scene.Draw()
+	}
+}
+
+// runScenes runs one frame of the open scenes: the top one is updated, then the stack is drawn.
+// Settings are saved whenever the top scene changes, as screens change them as they go.
+func (g *Game) runScenes() {
+	g.updateFramePacing()
+	top := g.scenes.Top()
+	g.scenes.Update(rl.GetFrameTime())
+	if g.scenes.Top() != top {
+		g.saveSettings()
+	}
+	if g.scenes.Empty() {
+		return
+	}
+
+	g.beginFrame()
+	g.scenes.Draw()
+	g.endFrame()
+}
+
+// openScenes runs its own stack of scenes until they have all closed. It lets screens that are
+// still blocking loops, like the game screens, open scenes such as the pause overlay.
+func (g *Game) openScenes(scenes ...Scene) {
+	outer := g.scenes
+	g.scenes = sceneStack{scenes: scenes}
+	for !g.scenes.Empty() && !rl.WindowShouldClose() {
+		g.runScenes()
+	}
+	g.scenes = outer
+}
+
+// switchState closes every scene and goes on to a screen that runs its own loop
+func (g *Game) switchState(state GameState) {
+	g.state = state
+	g.scenes.Clear()
+}
+
+// closeScene closes the top scene. The state is the screen being returned to, and is opened
+// anew if no scene is left under this one.
+func (g *Game) closeScene(state GameState) {
+	g.scenes.Pop()
+	g.state = state
+}
+
+// clicked highlights a button while the mouse is over it, and reports whether it was clicked
+func (g *Game) clicked(button *MenuButton, mousePoint rl.Vector2) bool {
+	if !button.IsHovered(mousePoint) {
+		button.color = rl.LightGray
+		return false
+	}
+	button.color = rl.Gray
+	return g.menu.handleButtonClick()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/input"
+)
+
+// ControlScheme is how the player steers the snake
+type ControlScheme int
+
+const (
+	SchemeAbsolute  ControlScheme = iota // Each key heads that way on screen
+	SchemeRelative                       // Left and right turn from the snake's heading, up and down do nothing
+	SchemeMouse                          // The snake heads for the mouse pointer
+	SchemeOneSwitch                      // Any one input, a direction key, Space, a click or the gamepad's A, turns clockwise
+	ControlSchemeCount
+)
+
+var controlSchemeNames = [ControlSchemeCount]string{"Absolute", "Relative", "Mouse", "One Switch"}
+
+func (s ControlScheme) String() string {
+	return controlSchemeNames[s]
+}
+
+// ParseControlScheme returns the scheme with the given name, defaulting to Absolute
+func ParseControlScheme(name string) ControlScheme {
+	for i, schemeName := range controlSchemeNames {
+		if schemeName == name {
+			return ControlScheme(i)
+		}
+	}
+	return SchemeAbsolute
+}
+
+// Next cycles to the following scheme, wrapping back to Absolute
+func (s ControlScheme) Next() ControlScheme {
+	return (s + 1) % ControlSchemeCount
+}
+
+// absoluteDirections are the direction each direction action heads on screen
+var absoluteDirections = [...]game.Direction{
+	input.ActionUp:    game.Up,
+	input.ActionDown:  game.Down,
+	input.ActionLeft:  game.Left,
+	input.ActionRight: game.Right,
+}
+
+// schemeTurns returns the turns the player asked for this frame under their control scheme, for
+// a snake with its head at head going heading once its queued turns are taken
+func (g *Game) schemeTurns(head game.Point, heading game.Direction) []game.Direction {
+	var turns []game.Direction
+	switch g.scheme {
+	case SchemeRelative:
+		// A quarter turn left or right of where it's heading, after any turn before it
+		if g.controls.Pressed(input.ActionLeft) {
+			heading = heading.TurnLeft()
+			turns = append(turns, heading)
+		}
+		if g.controls.Pressed(input.ActionRight) {
+			turns = append(turns, heading.TurnRight())
+		}
+	case SchemeMouse:
+		if turn, ok := g.mouseTurn(head, heading); ok {
+			turns = append(turns, turn)
+		}
+	case SchemeOneSwitch:
+		if g.switchPressed() {
+			turns = append(turns, heading.TurnRight())
+		}
+	default:
+		for action, direction := range absoluteDirections {
+			if g.controls.Pressed(input.Action(action)) {
+				turns = append(turns, direction)
+			}
+		}
+	}
+	return turns
+}
+
+// mouseTurn returns the way to turn to head for the board cell under the mouse: along whichever
+// axis it is further away on, or the other if that would reverse onto the snake
+func (g *Game) mouseTurn(head game.Point, heading game.Direction) (game.Direction, bool) {
+	target := g.boardMouseCell()
+	dx, dy := target.X-head.X, target.Y-head.Y
+	horizontal := game.Direction{X: sign(dx)}
+	vertical := game.Direction{Y: sign(dy)}
+	if abs(dy) > abs(dx) {
+		horizontal, vertical = vertical, horizontal
+	}
+	for _, turn := range [...]game.Direction{horizontal, vertical} {
+		if turn != (game.Direction{}) && !turn.Opposite(heading) {
+			return turn, turn != heading
+		}
+	}
+	return game.Direction{}, false
+}
+
+// switchPressed reports whether the one switch was pressed: any direction key, Space, a click or
+// the gamepad's A button. Space is left alone when a layout binds it, so the WASD boost doesn't
+// turn the snake as well.
+func (g *Game) switchPressed() bool {
+	for action := range absoluteDirections {
+		if g.controls.Pressed(input.Action(action)) {
+			return true
+		}
+	}
+	if !g.controls.IsBound(rl.KeySpace) && rl.IsKeyPressed(rl.KeySpace) {
+		return true
+	}
+	return rl.IsMouseButtonPressed(rl.MouseLeftButton) || rl.IsGamepadButtonPressed(0, rl.GamepadButtonRightFaceDown)
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
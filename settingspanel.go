@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/ui"
+)
+
+// settingsPanelRows is how many rows a settings panel takes
+const settingsPanelRows = 3
+
+// settingsPanel holds the volume, music and steering controls. It can be placed in any screen,
+// so the settings menu and the pause screen share it.
+type settingsPanel struct {
+	volume      *ui.Slider
+	music       *ui.Checkbox
+	musicVolume *ui.Slider
+	sfxVolume   *ui.Slider
+	scheme      *ui.Dropdown
+	mute        *ui.Checkbox
+}
+
+// uiStyle is the widget look for the menus, at the given font size
+func (g *Game) uiStyle(fontSize float32) ui.Style {
+	return ui.DefaultStyle(g.menu.font, menuFont(fontSize), float32(g.screenWidth), float32(g.screenHeight))
+}
+
+// newSettingsPanel lays out a panel from x, y: master volume and music side by side, the music
+// and sound effect volumes below them, then steering and mute
+func (g *Game) newSettingsPanel(x, y, width, rowHeight, spacing float32) settingsPanel {
+	style := g.uiStyle(30)
+	half := width/2 - spacing/2
+
+	volume := ui.NewSlider(style, rl.NewRectangle(x, y, half, rowHeight), 0, 100, 1, g.volume, func(value float32) string {
+		return fmt.Sprintf("Master: %0.f%%", value)
+	})
+	volume.OnChange = func(value float32) {
+		g.volume = value
+		g.audio.SetMasterVolume(value)
+	}
+
+	music := ui.NewCheckbox(style, rl.NewRectangle(x+half+spacing, y, half, rowHeight), "Music", g.audio.MusicEnabled)
+	music.OnChange = g.audio.SetMusicEnabled
+
+	musicVolume := ui.NewSlider(style, rl.NewRectangle(x, y+rowHeight+spacing, half, rowHeight), 0, 100, 1, g.musicVolume, func(value float32) string {
+		return fmt.Sprintf("Music: %0.f%%", value)
+	})
+	musicVolume.OnChange = func(value float32) {
+		g.musicVolume = value
+		g.audio.SetMusicVolume(value)
+	}
+
+	sfxVolume := ui.NewSlider(style, rl.NewRectangle(x+half+spacing, y+rowHeight+spacing, half, rowHeight), 0, 100, 1, g.sfxVolume, func(value float32) string {
+		return fmt.Sprintf("SFX: %0.f%%", value)
+	})
+	sfxVolume.OnChange = func(value float32) {
+		g.sfxVolume = value
+		g.audio.SetSfxVolume(value)
+	}
+
+	schemes := make([]string, ControlSchemeCount)
+	for i := range schemes {
+		schemes[i] = ControlScheme(i).String()
+	}
+	scheme := ui.NewDropdown(style, rl.NewRectangle(x, y+2*(rowHeight+spacing), half, rowHeight), "Steering", schemes, int(g.scheme))
+	scheme.OnChange = func(index int) {
+		g.scheme = ControlScheme(index)
+	}
+
+	mute := ui.NewCheckbox(style, rl.NewRectangle(x+half+spacing, y+2*(rowHeight+spacing), half, rowHeight), "Mute (M)", g.audio.Muted)
+	mute.OnChange = g.audio.SetMuted
+
+	return settingsPanel{volume: volume, music: music, musicVolume: musicVolume, sfxVolume: sfxVolume, scheme: scheme, mute: mute}
+}
+
+// refresh picks up settings changed from outside the panel, like muting with M
+func (p *settingsPanel) refresh(g *Game) {
+	p.mute.Checked = g.audio.Muted
+}
+
+// widgets returns the panel's controls, to add to the group of the screen it is on
+func (p *settingsPanel) widgets() []ui.Widget {
+	return []ui.Widget{p.volume, p.music, p.musicVolume, p.sfxVolume, p.scheme, p.mute}
+}
@@ -4,8 +4,27 @@ import (
 	"fmt"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/achievements"
+	"github.com/ztkent/snake/internal/ai"
+	"github.com/ztkent/snake/internal/assets"
 	"github.com/ztkent/snake/internal/audio"
+	"github.com/ztkent/snake/internal/campaign"
+	"github.com/ztkent/snake/internal/config"
+	"github.com/ztkent/snake/internal/cosmetics"
+	"github.com/ztkent/snake/internal/credits"
+	"github.com/ztkent/snake/internal/daily"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/ghost"
 	"github.com/ztkent/snake/internal/highscores"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/leaderboard"
+	"github.com/ztkent/snake/internal/levels"
+	"github.com/ztkent/snake/internal/mods"
+	"github.com/ztkent/snake/internal/particles"
+	"github.com/ztkent/snake/internal/postfx"
+	"github.com/ztkent/snake/internal/profiles"
+	"github.com/ztkent/snake/internal/render"
+	"github.com/ztkent/snake/internal/stats"
 )
 
 // GameState represents the current state of the game
@@ -18,51 +37,123 @@ const (
 	StateGameOver
 	StatePaused
 	StateHighScores // Add new state
+	StateVersus
+	StateAbout
+	StateControls
+	StateLevelSelect
+	StateAchievements
+	StateWatchAI
+	StateAttract
+	StateVersusSelect
+	StateVsAI
+	StateCampaign
+	StateCampaignStage
+	StateDaily
+	StateDailyGame
+	StateAppearance
+	StateStats
+	StateResume
+	StateHostLAN
+	StateJoinLAN
+	StateTournament
+	StateAccessibility
+	StateProfiles
+	StateFirstRun
+	StateBalance
 )
 
 const (
-	gridSize     = 20  // Size of each grid cell
-	initialSpeed = 200 // Pixels per second
-)
+	gridSize = 20 // Size of each grid cell in board coordinates, before the board is scaled to fit
+	tickRate = 15 // Simulation ticks per second on Normal difficulty
 
-type Direction struct {
-	X float32
-	Y float32
-}
+	maxFrameTime = 0.25 // Longest frame fed to the simulation, in seconds
 
-type GameSnake struct {
-	segments  []rl.Vector2
-	direction Direction
-	speed     float32
-	size      float32
-}
-
-type Food struct {
-	position rl.Vector2
-	size     float32
-}
-type Bomb struct {
-	position rl.Vector2
-	size     float32
-}
+	minTimeScale = 0.25 // Slowest dev-mode simulation speed
+	maxTimeScale = 8.0  // Fastest dev-mode simulation speed
+)
 
 // Game handles core game state
 type Game struct {
-	state        GameState
-	volume       float32
-	screenWidth  int32
-	screenHeight int32
-	running      bool
-	menu         *MenuState
-	score        Score
-	highScores   []highscores.HighScore
-	audio        *audio.AudioManager
+	state          GameState
+	volume         float32 // Master volume, 0-100
+	musicVolume    float32
+	sfxVolume      float32
+	typing         bool // A screen is reading raw keys, so hotkeys like M for mute are off
+	screenWidth    int32
+	screenHeight   int32
+	running        bool
+	menu           *MenuState
+	score          Score
+	highScores     []highscores.HighScore
+	credits        []credits.Credit
+	audio          *audio.AudioManager
+	assets         *assets.Manager // Owns every loaded font, texture and sound
+	postfx         *postfx.Pipeline
+	difficulty     Difficulty
+	devMode        bool
+	debugMode      bool // Started with -debug, F3 shows the debug overlay
+	debug          debugOverlay
+	console        console
+	playing        *game.Engine  // Game being played this frame, nil in menus
+	seed           uint64        // Seed for every game from -seed, 0 for a new one each game
+	launch         launchOptions // The command-line flags, whose settings aren't saved
+	backgrounded   bool          // Window is minimized or hidden, frame rate is throttled
+	timeScale      float32       // Simulation speed multiplier, adjustable in dev mode
+	playerName     string        // Last name entered for a high score
+	controls       *input.InputMap
+	level          levels.Level
+	canvas         rl.RenderTexture2D // Fixed size render target, scaled to the window each frame
+	settings       config.Settings    // As last loaded or saved
+	achievements   achievements.Progress
+	stats          stats.Stats         // Lifetime totals
+	profiles       profiles.List       // The player profiles, settings, stats and achievements being the active one's
+	leaderboard    *leaderboard.Client // Nil unless a leaderboard server is set
+	aiSkill        ai.Skill            // How well the rival snake plays in Vs AI
+	board          BoardSize
+	livesMode      bool             // Games start with livesModeLives lives
+	solidEdges     bool             // The board edges are deadly instead of wrapping, in Play and Vs AI
+	growth         int              // Segments grown per food, in Play and Vs AI
+	shrinkOnBomb   bool             // Bombs cost segments and points instead of the game, in Play and Vs AI
+	rewind         bool             // Crashes in Play can be rewound a few seconds, see game.RewindUses
+	twist          BoardTwist       // How Play turns the level round each run
+	transform      levels.Transform // How the level is turned round in the run being played
+	lanAddress     string           // Host last joined for a LAN game
+	lanCoop        bool             // The LAN match hosted is co-op rather than versus
+	mods           []*mods.Mod      // Rule variants from the mods folder, applied to every game of Play
+	mode           game.Mode
+	campaign       campaign.Progress
+	campaignStage  int // Campaign stage being played
+	daily          daily.Record
+	toasts         []toast           // Achievement unlocks waiting to be shown
+	particles      *particles.System // Sparks over the board, such as a golden apple burst
+	noParticles    bool              // Nothing is emitted over the board, for motion-sensitive players
+	noFlashing     bool              // Warnings stay steady instead of flashing, see blinking
+	gameSpeed      float32           // Scale on the speed of games played on this machine, see minGameSpeed
+	scoreMilestone int               // Last score milestone spoken, see announceScore
+	camFX          cameraEffects     // Screen shake and hit-stop
+	countdown      float32           // Seconds left holding play before it starts, see startCountdown
+	skin           cosmetics.Skin
+	hat            cosmetics.Hat
+	theme          cosmetics.Theme
+	skinSprites    map[string]rl.Texture2D // Loaded sprite sheets by path
+	thumbnails     map[string]rl.Texture2D // High score photo finishes by path, loaded when first shown
+	stepped        bool                    // Draw the snake a cell per tick instead of gliding
+	atlas          *render.Atlas           // Nil if the atlas failed to load, shapes are drawn instead
+	hudLayout      HUDLayout
+	boardPattern   BoardPattern
+	patternCache   patternCache // The board pattern drawn into a texture
+	hudCorner      HUDCorner
+	cursor         CursorStyle
+	scheme         ControlScheme
+	scenes         sceneStack // Open scenes, screens not yet written as scenes run their own loops
 }
 
 type Score struct {
-	points    int
-	duration  float32
-	startTime float32
+	points   int
+	duration float32
+	length   int // Snake length
+	maxCombo int // Longest combo of the run
+	cause    game.Cause
 }
 
 // StartGame implements the main game loop for snake game:
@@ -76,14 +167,15 @@ type Score struct {
 //
 // Input Handling:
 // - Window close (X) detection for game exit
-// - Arrow key detection for snake direction changes
+// - Bound direction keys (arrows by default), WASD or a gamepad change the snake direction
 // - Prevents 180° turns by checking opposite direction
 //
-// Game State Updates (15 FPS lock):
+// Game State Updates (tick rate set by the difficulty, 15 FPS on Normal):
+// - Rules run in the internal/game engine, this loop feeds it input and elapsed time
 // - Calculates new head position based on current direction
 // - Handles screen wrapping when snake crosses borders
 // - Checks for collisions with:
-//   - Snake's own body (game over condition)
+//   - Snake's own body and level walls (game over condition)
 //   - Food (triggers growth and score increment)
 //
 // - Updates snake movement:
@@ -92,15 +184,20 @@ type Score struct {
 //   - Or keeps tail (when growing from food)
 //
 // Time Management:
-// - Tracks total game duration
-// - Maintains consistent game speed (15 FPS)
-// - Adjusts for any pause time
+// - Tracks total game duration in simulation ticks
+// - Starts at the difficulty tick rate and speeds up with each point, up to a cap
+// - Fixed timestep: frame time accumulates in the engine, which runs every tick it holds
+// - The snake is drawn interpolated between ticks so it moves smoothly at any tick rate
+// - In dev mode, [ and ] scale the simulation speed (0.25x-8x)
 //
 // Rendering (60 FPS):
+// - Routes the frame through the post-processing pass when enabled
 // - Clears screen with dark gray background
 // - Draws current score in top right
-// - Shows game duration below score
+// - Shows game duration and speed below score
+// - Pulses a red heartbeat border while the danger level is high
 // - Renders food as red square
+// - Renders power-ups as lettered cells, with active effects and timers in the bottom left
 // - Draws snake with:
 //   - Green body segments
 //   - Dark green head
@@ -110,300 +207,342 @@ type Score struct {
 // - Player closes window (returns to main menu)
 // - Snake collides with itself (triggers game over screen)
 func (g *Game) StartGame() {
+	// The engine starts the snake in the middle of the board and spawns the first food
+	// The level is turned round, if at all, when it is laid out for the run
+	seed := g.newSeed()
+	g.transform = g.twist.transform(seed)
+	level := g.level.Transformed(g.transform)
+	width, height := g.boardSize()
+	config := g.difficulty.Config(width, height)
+	config.Walls = level.Walls(width, height)
+	config.Portals = level.Portals(width, height)
+	if g.livesMode {
+		config.Lives = livesModeLives
+	}
+	config.Mode = g.mode
+	config.SolidEdges = g.solidEdges
+	config.Growth = g.growth
+	config.ShrinkOnBomb = g.shrinkOnBomb
+	config.Rewind = g.rewind
+	g.configureMods(&config)
+	engine := game.NewEngine(config, seed)
+	g.hookMods(engine)
+	g.playGame(engine, false)
+}
+
+// playGame runs the game loop on a new or resumed game until it ends or is quit. Quitting before
+// the end saves the game to continue later, and a resumed game's save is deleted once it ends.
+func (g *Game) playGame(engine *game.Engine, resumed bool) {
 	// Start the game music
-	g.audio.SetVolume(g.volume)
+	g.audio.SetMasterVolume(g.volume)
 	g.audio.PlayMusic(&g.audio.GameMusic)
 
 	// Initialize score
 	g.score = Score{
-		points:    0,
-		startTime: float32(rl.GetTime()),
-		duration:  0,
+		points:   engine.State.Points,
+		duration: engine.Duration(),
 	}
+	g.timeScale = 1
 
-	// Initialize snake in the middle of the screen
-	snake := GameSnake{
-		segments: []rl.Vector2{
-			{X: float32(g.screenWidth / 2), Y: float32(g.screenHeight / 2)},
-			{X: float32(g.screenWidth/2) - gridSize, Y: float32(g.screenHeight / 2)},
-		},
-		direction: Direction{X: 1, Y: 0},
-		speed:     initialSpeed,
-		size:      gridSize,
-	}
+	config := engine.Config
+	danger := dangerMeter{}
+	g.particles.Clear()
+	g.camFX.Reset()
+	g.startCountdown()
 
-	foods := make([]Food, 0)
-	bombs := make([]Bomb, 0)
-	lastUpdateTime := float32(0)
-	pauseStartTime := float32(0)
-	totalPauseTime := float32(0)
+	// Race the best run on this level, while recording this one
+	best := g.loadGhost()
+	run := ghost.NewRun(g.ghostLevel(), g.difficulty.String(), g.board.String())
+	stats := achievements.Run{}
+	declined := false // Rewinding the crash was turned down, so the game is over
 
 	for {
-		// Update music at consistent intervals
-		currentTime := rl.GetTime()
-		deltaTime := float32(currentTime) - lastUpdateTime
-		if deltaTime >= 1.0/60.0 { // Update at 60Hz
-			g.audio.UpdateMusic()
-		}
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
 
-		if rl.IsKeyPressed(rl.KeyEscape) {
+		// The game only stays over here while offering to rewind the crash: R rewinds it, and
+		// Enter or pause ends the game
+		if rl.WindowShouldClose() {
+			g.quitGame(engine, stats, resumed)
+			g.state = StateMainMenu
+			g.running = false
+			return
+		} else if engine.State.Over {
+			switch {
+			case !declined && rl.IsKeyPressed(rl.KeyR) && engine.Rewind():
+				g.camFX.Reset()
+				g.startCountdown()
+				g.toasts = append(g.toasts, toast{text: rewindToast(engine), remaining: toastTime})
+			case rl.IsKeyPressed(rl.KeyEnter) || g.controls.Pressed(input.ActionPause):
+				declined = true
+			}
+		} else if g.controls.Pressed(input.ActionPause) {
 			g.state = StatePaused
-			pauseStartTime = float32(rl.GetTime())
-			if !g.openPauseScreen() {
+			if !g.openPauseScreen(&engine.State) {
+				g.quitGame(engine, stats, resumed)
 				return // Exit to main menu if 'exit' is selected
 			}
-			// Calculate pause duration and adjust times
-			totalPauseTime += float32(rl.GetTime()) - pauseStartTime
-			lastUpdateTime = float32(rl.GetTime())
 			continue
-		} else if rl.WindowShouldClose() {
-			g.state = StateMainMenu
-			g.running = false
-			return
 		}
 
 		// Handle input
-		if rl.IsKeyPressed(rl.KeyUp) && snake.direction.Y != 1 {
-			snake.direction = Direction{X: 0, Y: -1}
-		}
-		if rl.IsKeyPressed(rl.KeyDown) && snake.direction.Y != -1 {
-			snake.direction = Direction{X: 0, Y: 1}
-		}
-		if rl.IsKeyPressed(rl.KeyLeft) && snake.direction.X != 1 {
-			snake.direction = Direction{X: -1, Y: 0}
-		}
-		if rl.IsKeyPressed(rl.KeyRight) && snake.direction.X != -1 {
-			snake.direction = Direction{X: 1, Y: 0}
-		}
-
-		currentTime = rl.GetTime()
-		deltaTime = float32(currentTime) - lastUpdateTime
-
-		if deltaTime >= 1.0/15.0 { // 15 FPS lock
-			// Update snake position
-			newHead := rl.Vector2{
-				X: snake.segments[0].X + snake.direction.X*snake.size,
-				Y: snake.segments[0].Y + snake.direction.Y*snake.size,
-			}
-
-			// Handle screen wrapping
-			newHead = g.wrapPosition(newHead, snake.size)
-
-			// Check self-collision
-			if g.checkSelfCollision(newHead, snake.segments) {
-				g.audio.PlaySound(&g.audio.GameOverSFX)
-				g.state = StateGameOver
-				g.audio.PlayMusic(&g.audio.MenuMusic)
-				return
-			}
+		g.handleSnakeInput(engine)
 
-			// Check bomb collision with all bombs
-			for _, bomb := range bombs {
-				if g.checkBombCollision(newHead, snake.size, bomb) {
-					g.audio.PlaySound(&g.audio.GameOverSFX)
-					g.state = StateGameOver
-					g.audio.PlayMusic(&g.audio.MenuMusic)
-					return
-				}
+		// Dev mode time scaling
+		if g.devMode {
+			if rl.IsKeyPressed(rl.KeyLeftBracket) {
+				g.timeScale = max(minTimeScale, g.timeScale/2)
 			}
-
-			// Check food collision with all food pieces
-			eaten := -1
-			for i, food := range foods {
-				if g.checkFoodCollision(newHead, snake.size, food) {
-					g.score.points++
-					g.audio.PlaySound(&g.audio.CollectSFX)
-					snake.segments = append([]rl.Vector2{newHead}, snake.segments...)
-					eaten = i
-					break
-				}
-			}
-
-			// Remove eaten food
-			if eaten >= 0 {
-				foods = append(foods[:eaten], foods[eaten+1:]...)
+			if rl.IsKeyPressed(rl.KeyRightBracket) {
+				g.timeScale = min(maxTimeScale, g.timeScale*2)
 			}
+		}
 
-			// Spawn new food if none exists
-			if len(foods) == 0 {
-				currentGameTime := float32(rl.GetTime()) - g.score.startTime - totalPauseTime
-				g.spawnFoodAndBombs(&foods, &bombs, snake.segments, currentGameTime)
+		// Run every tick due since the last frame, frozen while backgrounded and scaled by the game
+		// speed and in dev mode
+		ticks := engine.State.Ticks
+		delta := g.camFX.Step(g.holdForCountdown(g.simulationDelta(backgrounded))) * g.gameSpeed * g.timeScale
+		events := engine.Update(delta)
+		g.playEventSounds(events, &engine.State)
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		g.score.points = engine.State.Points
+		g.score.duration = engine.Duration()
+		g.score.length = len(engine.State.Snake.Segments)
+		g.score.maxCombo = engine.State.MaxCombo
+		g.score.cause = engine.State.Cause
+		run.Record(&engine.State, engine.Duration())
+		trackRun(&stats, events, &engine.State)
+		if engine.State.Over && (declined || !engine.CanRewind()) && !g.hatFlying() {
+			// A resumed game's ghost is missing its start, so it can't be raced
+			if !resumed {
+				g.saveGhost(run, best)
 			} else {
-				// Move snake
-				snake.segments = append([]rl.Vector2{newHead}, snake.segments[:len(snake.segments)-1]...)
+				g.deleteSavedGame()
 			}
+			g.recordRun(engine)
+			g.finishAchievements(stats)
+			g.state = StateGameOver
+			g.audio.PlayMusic(&g.audio.MenuMusic)
+			return
+		}
+		if engine.State.Ticks != ticks {
+			// Update danger level for the heartbeat overlay
+			danger.update(computeDanger(engine))
+			g.checkAchievements(stats)
+		}
 
-			lastUpdateTime = float32(currentTime)
+		g.updateDebug(engine)
 
-			// Update duration (subtracting total pause time)
-			g.score.duration = float32(rl.GetTime()) - g.score.startTime - totalPauseTime
+		// Hot-reload the custom shader in dev mode
+		if g.devMode {
+			g.postfx.ReloadCustomIfChanged()
 		}
-
-		rl.BeginDrawing()
-		rl.ClearBackground(rl.DarkGray)
-
-		// Draw score
-		scoreText := fmt.Sprintf("Score: %d", g.score.points)
-		durationText := fmt.Sprintf("Time: %.1fs", g.score.duration)
-		fontSize := float32(20)
-
-		// Draw score
-		scoreSize := rl.MeasureTextEx(g.menu.font, scoreText, fontSize, 1)
-		rl.DrawTextEx(
-			g.menu.font,
-			scoreText,
-			rl.Vector2{
-				X: float32(g.screenWidth) - scoreSize.X - 10,
-				Y: 10,
-			},
-			fontSize,
-			1,
-			rl.White,
-		)
-
-		// Draw duration below score
-		durationSize := rl.MeasureTextEx(g.menu.font, durationText, fontSize, 1)
-		rl.DrawTextEx(
-			g.menu.font,
-			durationText,
-			rl.Vector2{
-				X: float32(g.screenWidth) - durationSize.X - 10,
-				Y: scoreSize.Y + 15,
-			},
-			fontSize,
-			1,
-			rl.White,
-		)
-
-		// Draw all food pieces
-		for _, food := range foods {
-			rl.DrawRectangleV(food.position, rl.Vector2{X: food.size, Y: food.size}, rl.Gold)
+		g.postfx.SetScore(g.score.points)
+
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		// Draw the ghost under food, bombs and snake, with the HUD over the board
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawGhost(best, engine.Duration())
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		g.drawArena(engine)
+		if config.SolidEdges {
+			g.drawSolidEdges()
 		}
+		rl.EndMode2D()
 
-		// Draw all bombs
-		for _, bomb := range bombs {
-			rl.DrawRectangleV(bomb.position, rl.Vector2{X: bomb.size, Y: bomb.size}, rl.Red)
+		// Show the mods changing the rules, a changed game speed, and the time scale with the stats
+		// in dev mode
+		var extraLines []hudLine
+		if len(g.mods) > 0 {
+			extraLines = append(extraLines, hudLine{text: g.modsText(), color: rl.Violet})
 		}
-
-		// Draw snake
-		g.drawSnake(snake)
-		rl.EndDrawing()
+		if g.gameSpeed != 1 {
+			extraLines = append(extraLines, hudLine{text: speedLabel(g.gameSpeed), color: rl.SkyBlue})
+		}
+		if g.devMode {
+			extraLines = append(extraLines, hudLine{text: fmt.Sprintf("Time scale: x%.2f", g.timeScale), color: rl.Yellow})
+		}
+		g.drawHUD(engine, extraLines...)
+		g.drawDangerOverlay(&danger)
+		g.drawRewindPrompt(engine)
+		g.drawToasts()
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
 	}
 }
 
-func (g *Game) wrapPosition(pos rl.Vector2, size float32) rl.Vector2 {
-	if pos.X >= float32(g.screenWidth) {
-		pos.X = 0
-	} else if pos.X < 0 {
-		pos.X = float32(g.screenWidth) - size
+// handleSnakeInput turns the snake as the control scheme has it, with the bound direction keys,
+// WASD, the gamepad or the mouse, and boosts it while the boost key is held
+func (g *Game) handleSnakeInput(engine *game.Engine) {
+	g.controls.Update()
+	engine.Boost(g.controls.Down(input.ActionBoost))
+	for _, turn := range g.schemeTurns(engine.State.Snake.Head(), engine.Heading()) {
+		engine.Input(turn)
 	}
-	if pos.Y >= float32(g.screenHeight) {
-		pos.Y = 0
-	} else if pos.Y < 0 {
-		pos.Y = float32(g.screenHeight) - size
-	}
-	return pos
 }
 
-func (g *Game) checkSelfCollision(head rl.Vector2, segments []rl.Vector2) bool {
-	for i := 1; i < len(segments); i++ {
-		if head.X == segments[i].X && head.Y == segments[i].Y {
-			return true
-		}
+// boardSize returns the board dimensions in cells
+func (g *Game) boardSize() (int, int) {
+	cells := boardCells[g.board]
+	return cells.X, cells.Y
+}
+
+// simulationDelta returns the frame time to feed the engine: none while the window is backgrounded,
+// and capped so a stall can't trigger a burst of catch-up ticks
+func (g *Game) simulationDelta(backgrounded bool) float32 {
+	if backgrounded {
+		return 0
 	}
-	return false
+	return min(rl.GetFrameTime(), maxFrameTime)
 }
 
-func (g *Game) checkFoodCollision(head rl.Vector2, size float32, food Food) bool {
-	return rl.CheckCollisionRecs(
-		rl.NewRectangle(head.X, head.Y, size, size),
-		rl.NewRectangle(food.position.X, food.position.Y, food.size, food.size),
-	)
+// cellPosition returns the top-left pixel of a board cell
+func cellPosition(p game.Point) rl.Vector2 {
+	return rl.Vector2{X: float32(p.X * gridSize), Y: float32(p.Y * gridSize)}
 }
 
-func (g *Game) checkBombCollision(head rl.Vector2, size float32, bomb Bomb) bool {
-	return rl.CheckCollisionRecs(
-		rl.NewRectangle(head.X, head.Y, size, size),
-		rl.NewRectangle(bomb.position.X, bomb.position.Y, bomb.size, bomb.size),
-	)
+// snakePixels returns the top-left pixel of each segment, as of the last tick
+func snakePixels(snake game.Snake) []rl.Vector2 {
+	positions := make([]rl.Vector2, len(snake.Segments))
+	for i, segment := range snake.Segments {
+		positions[i] = cellPosition(segment)
+	}
+	return positions
 }
-func (g *Game) drawSnake(snake GameSnake) {
-	for i, segment := range snake.segments {
-		if i == 0 {
-			// Draw head
-			rl.DrawRectangleV(segment, rl.Vector2{X: snake.size, Y: snake.size}, rl.DarkGreen)
-		} else {
-			// Draw body segments
-			rl.DrawRectangleV(segment, rl.Vector2{X: snake.size, Y: snake.size}, rl.Green)
-		}
+
+// snakePositions returns where to draw the player's snake, gliding between cells or, with
+// stepped movement on, jumping a cell per tick like the classic game
+func (g *Game) snakePositions(engine *game.Engine) []rl.Vector2 {
+	if g.stepped {
+		return snakePixels(engine.State.Snake)
 	}
+	return interpolatedSnake(engine)
 }
 
-func (g *Game) spawnFoodAndBombs(foods *[]Food, bombs *[]Bomb, snakeSegments []rl.Vector2, currentGameTime float32) {
-	gridWidth := g.screenWidth / int32(gridSize)
-	gridHeight := g.screenHeight / int32(gridSize)
+// interpolatedSnake returns the segment positions blended between the last two ticks by the
+// engine's tick progress, so the snake glides instead of jumping a cell per tick.
+// Segments that wrapped across the board edge snap instead of sliding across the screen.
+func interpolatedSnake(engine *game.Engine) []rl.Vector2 {
+	alpha := engine.Alpha()
+	previous := engine.Previous()
+	segments := engine.State.Snake.Segments
+	positions := make([]rl.Vector2, len(segments))
+	for i, segment := range segments {
+		from := segment
+		if i < len(previous) {
+			from = previous[i]
+		} else if len(previous) > 0 {
+			// A new tail segment from growing starts on the old tail
+			from = previous[len(previous)-1]
+		}
 
-	// Calculate food and bomb counts
-	foodCount := int(currentGameTime/10) + 1
-	if foodCount > 6 {
-		foodCount = 6
+		to := cellPosition(segment)
+		if abs(segment.X-from.X)+abs(segment.Y-from.Y) > 1 {
+			positions[i] = to
+			continue
+		}
+		start := cellPosition(from)
+		positions[i] = rl.Vector2{
+			X: start.X + (to.X-start.X)*alpha,
+			Y: start.Y + (to.Y-start.Y)*alpha,
+		}
 	}
+	return positions
+}
 
-	bombCount := 0
-	if foodCount > 1 {
-		bombCount = foodCount / 2
+// drawBoard draws every object on the playing field, without the HUD, with the snake segments at
+// the given pixel positions
+func (g *Game) drawBoard(state *game.State, snake []rl.Vector2) {
+	size := rl.Vector2{X: gridSize, Y: gridSize}
+	g.drawEntities(state.Entities)
+	for _, explosion := range state.Explosions {
+		drawExplosion(explosion)
 	}
 
-	// Create array to track occupied positions
-	occupied := make(map[string]bool)
-	for _, segment := range snakeSegments {
-		key := fmt.Sprintf("%d,%d", int(segment.X), int(segment.Y))
-		occupied[key] = true
+	// Draw level walls
+	for _, wall := range state.Walls {
+		rl.DrawRectangleV(cellPosition(wall), size, g.theme.Wall)
 	}
 
-	// Clear existing food and bombs
-	*foods = make([]Food, 0, foodCount)
-	*bombs = make([]Bomb, 0, bombCount)
-
-	// Spawn food first
-	for len(*foods) < foodCount {
-		x := float32(rl.GetRandomValue(0, gridWidth-1)) * gridSize
-		y := float32(rl.GetRandomValue(0, gridHeight-1)) * gridSize
-
-		key := fmt.Sprintf("%d,%d", int(x), int(y))
-		if !occupied[key] {
-			*foods = append(*foods, Food{
-				position: rl.Vector2{X: x, Y: y},
-				size:     gridSize,
-			})
-			occupied[key] = true
-
-			// Mark adjacent cells as occupied for bomb spacing
-			for dx := -1; dx <= 1; dx++ {
-				for dy := -1; dy <= 1; dy++ {
-					nx := int(x) + dx*int(gridSize)
-					ny := int(y) + dy*int(gridSize)
-					adjKey := fmt.Sprintf("%d,%d", nx, ny)
-					occupied[adjKey] = true
-				}
-			}
+	// Draw rival snakes, a step at a time
+	for _, rival := range state.Rivals {
+		g.drawSkinned(rivalSkin, g.snakePieces(rival.Snake), snakePixels(rival.Snake))
+	}
+
+	// Draw snake, ringed while shielded and blinking while invulnerable. A downed co-op snake is
+	// off the board.
+	if !state.Down && (state.Invulnerable == 0 || !g.blinking(8)) {
+		g.drawSnake(state.Snake, snake)
+		// A crashed snake's hat has been knocked off
+		if !state.Over {
+			g.drawHat(g.hat, hatOnHead(snake[0]), 0)
 		}
 	}
+	if !state.Down && state.HasEffect(game.PowerUpShield) {
+		head := snake[0]
+		rl.DrawRectangleLinesEx(rl.NewRectangle(head.X-3, head.Y-3, gridSize+6, gridSize+6), 2, powerUpColors[game.PowerUpShield])
+	}
+	g.particles.Draw()
+	for _, body := range g.particles.Bodies() {
+		g.drawHat(g.hat, body.Position, body.Angle)
+	}
+}
 
-	// Then spawn bombs
-	if bombCount > 0 {
-		for len(*bombs) < bombCount {
-			x := float32(rl.GetRandomValue(0, gridWidth-1)) * gridSize
-			y := float32(rl.GetRandomValue(0, gridHeight-1)) * gridSize
-
-			key := fmt.Sprintf("%d,%d", int(x), int(y))
-			if !occupied[key] {
-				*bombs = append(*bombs, Bomb{
-					position: rl.Vector2{X: x, Y: y},
-					size:     gridSize,
-				})
-				occupied[key] = true
-			}
+// playEventSounds plays the sound for each engine event from a frame's ticks, with the game
+// over sound depending on what the snake died to, and speaks the score at its milestones
+func (g *Game) playEventSounds(events []game.Event, state *game.State) {
+	for _, event := range events {
+		switch event {
+		case game.EventAte, game.EventShieldUsed:
+			g.audio.Play(audio.EventCollect)
+		case game.EventPowerUp:
+			g.audio.PlayOr(audio.EventPowerUp, audio.EventCollect)
+		case game.EventTurned:
+			g.audio.Play(audio.EventTurn)
+		case game.EventFuseTick:
+			g.audio.Play(audio.EventFuse)
+		case game.EventDied:
+			g.audio.Play(g.deathSound(state.Cause))
+		case game.EventLifeLost, game.EventTimeUp:
+			g.audio.Play(audio.EventGameOver)
+		case game.EventExploded, game.EventShrunk:
+			g.audio.Play(audio.EventExplosion)
+		case game.EventAteGolden:
+			g.audio.Play(audio.EventGolden)
+		case game.EventArenaShrank:
+			g.audio.PlayOr(audio.EventCrash, audio.EventExplosion)
+		case game.EventPoisoned:
+			g.audio.Play(audio.EventPoison)
+		case game.EventDowned:
+			g.audio.Play(g.deathSound(state.Cause))
+		case game.EventRevived:
+			g.audio.PlayOr(audio.EventPowerUp, audio.EventCollect)
 		}
 	}
+	g.announceScore(state.Points)
+}
+
+// drawSnake draws the player's snake in the chosen skin and the theme's snake colors, with its segments at the given pixels
+func (g *Game) drawSnake(snake game.Snake, segments []rl.Vector2) {
+	g.drawSkinned(g.theme.Skinned(g.skin), g.snakePieces(snake), segments)
+}
+
+// snakePieces picks the atlas tile for each of a snake's segments on the current board
+func (g *Game) snakePieces(snake game.Snake) []render.Piece {
+	width, height := g.boardSize()
+	return render.SnakePieces(snake, width, height)
+}
+
+// drawApple draws a piece of food in the cell at position, from the atlas if there is one
+func (g *Game) drawApple(position rl.Vector2, color rl.Color) {
+	if g.atlas != nil {
+		g.atlas.Draw(render.TileApple, rl.NewRectangle(position.X, position.Y, gridSize, gridSize), 0, color)
+		return
+	}
+	rl.DrawRectangleV(position, rl.Vector2{X: gridSize, Y: gridSize}, color)
 }
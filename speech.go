@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ztkent/snake/internal/speech"
+)
+
+// speechMilestone is the points between spoken score announcements
+const speechMilestone = 25
+
+// speaker reads the menus and the game aloud while speech is on. Like menuTheme, buttons
+// announce themselves without the game at hand, so it is kept here.
+var speaker = &speech.Speaker{}
+
+// spokenFocus is the label of the button last announced
+var spokenFocus string
+
+// announceFocus speaks the label of the button given keyboard focus, and again if it changes
+// while focused, as a toggle's does
+func announceFocus(text string) {
+	if text == spokenFocus {
+		return
+	}
+	spokenFocus = text
+	speaker.Say(text)
+}
+
+// announceScore speaks the score each time it passes a milestone. A new game starts below the
+// last one's milestone, so nothing needs resetting between games.
+func (g *Game) announceScore(points int) {
+	milestone := points / speechMilestone
+	if milestone > g.scoreMilestone {
+		speaker.Say(fmt.Sprintf("Score %d", points))
+	}
+	g.scoreMilestone = milestone
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/levels"
+	"github.com/ztkent/snake/internal/stats"
+)
+
+// balanceBars is how many settings the balance report shows, hardest first
+const balanceBars = 10
+
+// recordGame adds a game that ended, or was quit, to the lifetime stats
+func (g *Game) recordGame(engine *game.Engine) {
+	g.recordStats(engine, nil)
+}
+
+// recordRun adds a game played from the player's own settings to the lifetime stats, along with
+// those settings for the balance report. Daily, campaign and other games choose their own, so
+// they only count towards the totals.
+func (g *Game) recordRun(engine *game.Engine) {
+	g.recordStats(engine, g.runSetup(&engine.Config))
+}
+
+func (g *Game) recordStats(engine *game.Engine, setup []string) {
+	state := &engine.State
+	g.stats.Record(stats.Game{
+		Score:    state.Points,
+		Food:     state.Eaten,
+		Duration: engine.Duration(),
+		Length:   len(state.Snake.Segments),
+		Cause:    causeName(state.Cause),
+		Bombed:   state.Cause == game.CauseBomb || state.Cause == game.CauseBlast,
+		Setup:    setup,
+	})
+	if err := stats.Save(g.profiles.Current().Dir, g.stats); err != nil {
+		fmt.Println("Failed to save stats:", err)
+	}
+}
+
+// runSetup lists what a run was played with, one setting each, as the balance report groups them.
+// Rules left at their defaults are left out.
+func (g *Game) runSetup(config *game.Config) []string {
+	setup := []string{
+		"Mode: " + config.Mode.String(),
+		"Difficulty: " + g.difficulty.String(),
+		"Level: " + g.level.Name,
+		"Board: " + g.board.String(),
+	}
+	if g.transform != levels.TransformNone {
+		setup = append(setup, "Twist: "+g.transform.String())
+	}
+	if config.SolidEdges {
+		setup = append(setup, "Solid edges")
+	}
+	if config.ShrinkOnBomb {
+		setup = append(setup, "Bombs: Shrink")
+	}
+	if config.Growth > 1 {
+		setup = append(setup, fmt.Sprintf("Growth: %d", config.Growth))
+	}
+	if config.Lives > 1 {
+		setup = append(setup, fmt.Sprintf("Lives: %d", config.Lives))
+	}
+	if speed := g.speedPercent(); speed != 0 {
+		setup = append(setup, fmt.Sprintf("Speed: %d%%", speed))
+	}
+	return setup
+}
+
+// playTime formats seconds played as hours and minutes, or minutes and seconds under an hour
+func playTime(seconds float32) string {
+	total := int(seconds)
+	if total >= 3600 {
+		return fmt.Sprintf("%dh %02dm", total/3600, total/60%60)
+	}
+	return fmt.Sprintf("%dm %02ds", total/60, total%60)
+}
+
+// openStatsScreen shows the lifetime totals, deaths by cause and a chart of recent scores
+func (g *Game) openStatsScreen() {
+	buttonWidth := float32(200)
+	buttonHeight := float32(50)
+
+	spacing := float32(10)
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth-spacing/2,
+		float32(g.screenHeight)*0.8,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		30,
+		g.menu.font,
+	)
+	balanceButton := NewMenuButton(
+		float32(g.screenWidth)/2+spacing/2,
+		float32(g.screenHeight)*0.8,
+		buttonWidth,
+		buttonHeight,
+		"Balance",
+		30,
+		g.menu.font,
+	)
+
+	totals := []string{
+		fmt.Sprintf("Games played: %d", g.stats.Games),
+		fmt.Sprintf("Food eaten: %d", g.stats.Food),
+		fmt.Sprintf("Time played: %s", playTime(g.stats.Time)),
+		fmt.Sprintf("Longest snake: %d", g.stats.Longest),
+		fmt.Sprintf("Best score: %d", g.stats.Best),
+		fmt.Sprintf("Recent average: %.1f", g.stats.Average()),
+	}
+	deaths := []string{"Deaths"}
+	for cause := game.CauseWall; cause < game.CauseCount; cause++ {
+		deaths = append(deaths, fmt.Sprintf("%s: %d", cause, g.stats.Deaths[cause.String()]))
+	}
+
+	fontSize := float32(20)
+	lineHeight := float32(26)
+	top := float32(g.screenHeight) * 0.24
+	chart := rl.NewRectangle(float32(g.screenWidth)*0.55, top+lineHeight, float32(g.screenWidth)*0.4, float32(g.screenHeight)*0.42)
+
+	for {
+		g.updateFramePacing()
+
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		if g.clicked(&backButton, mousePoint) {
+			g.state = StateMainMenu
+			return
+		}
+		if g.clicked(&balanceButton, mousePoint) {
+			g.state = StateBalance
+			return
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+
+		g.drawCenteredText("STATS", float32(g.screenHeight)*0.05, 60, rl.DarkGreen)
+		for i, line := range totals {
+			rl.DrawTextEx(g.menu.font, line, rl.Vector2{X: float32(g.screenWidth) * 0.05, Y: top + float32(i)*lineHeight}, fontSize, 1, rl.DarkGray)
+		}
+		for i, line := range deaths {
+			color := rl.DarkGray
+			if i == 0 {
+				color = rl.Maroon
+			}
+			rl.DrawTextEx(g.menu.font, line, rl.Vector2{X: float32(g.screenWidth) * 0.35, Y: top + float32(i)*lineHeight}, fontSize, 1, color)
+		}
+		g.drawScoreChart(chart, fontSize)
+
+		backButton.Draw()
+		balanceButton.Draw()
+		g.endFrame()
+	}
+}
+
+// openBalanceScreen charts which settings go with the hardest runs in the run history, for
+// tuning spawn tables and difficulties. Each click on the metric button ranks by the next one.
+func (g *Game) openBalanceScreen() {
+	buttonWidth := float32(260)
+	buttonHeight := float32(50)
+	spacing := float32(10)
+	metric := stats.MetricDuration
+	bars := stats.Report(g.stats.History, metric)
+
+	metricButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth-spacing/2,
+		float32(g.screenHeight)*0.8,
+		buttonWidth,
+		buttonHeight,
+		metric.String(),
+		30,
+		g.menu.font,
+	)
+	backButton := NewMenuButton(
+		float32(g.screenWidth)/2+spacing/2,
+		float32(g.screenHeight)*0.8,
+		buttonWidth,
+		buttonHeight,
+		"Back",
+		30,
+		g.menu.font,
+	)
+
+	fontSize := float32(20)
+	top := float32(g.screenHeight) * 0.2
+	chart := rl.NewRectangle(float32(g.screenWidth)*0.05, top, float32(g.screenWidth)*0.9, float32(g.screenHeight)*0.55)
+
+	for {
+		g.updateFramePacing()
+
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateStats
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		if g.clicked(&backButton, mousePoint) {
+			g.state = StateStats
+			return
+		}
+		if g.clicked(&metricButton, mousePoint) {
+			metric = metric.Next()
+			metricButton.text = metric.String()
+			bars = stats.Report(g.stats.History, metric)
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+
+		g.drawCenteredText("BALANCE REPORT", float32(g.screenHeight)*0.05, 60, rl.DarkGreen)
+		subtitle := fmt.Sprintf("Settings with the %s, from the last %d runs", metricSubjects[metric], len(g.stats.History))
+		g.drawCenteredText(subtitle, float32(g.screenHeight)*0.13, fontSize, rl.DarkGray)
+		g.drawBalanceChart(chart, bars, metric, fontSize)
+
+		metricButton.Draw()
+		backButton.Draw()
+		g.endFrame()
+	}
+}
+
+// metricSubjects finish the balance report's subtitle for each metric
+var metricSubjects = [stats.MetricCount]string{"shortest runs", "most bomb deaths", "lowest scores"}
+
+// drawBalanceChart draws the report's hardest settings as bars across bounds, one a row, each
+// labelled with its setting on the left and its value and number of runs on the right
+func (g *Game) drawBalanceChart(bounds rl.Rectangle, bars []stats.Bar, metric stats.Metric, fontSize float32) {
+	if len(bars) == 0 {
+		text := fmt.Sprintf("Play each setting at least %d times to rank it", stats.MinRuns)
+		size := rl.MeasureTextEx(g.menu.font, text, fontSize, 1)
+		rl.DrawTextEx(g.menu.font, text, rl.Vector2{X: bounds.X + bounds.Width/2 - size.X/2, Y: bounds.Y + bounds.Height/2 - size.Y/2}, fontSize, 1, rl.Gray)
+		return
+	}
+	bars = bars[:min(len(bars), balanceBars)]
+
+	largest := float32(0)
+	for _, bar := range bars {
+		largest = max(largest, bar.Value)
+	}
+	if largest == 0 {
+		largest = 1
+	}
+
+	// The labels and values take a quarter of the width each side of the bars
+	labelWidth := bounds.Width * 0.25
+	barWidth := bounds.Width - 2*labelWidth
+	row := bounds.Height / balanceBars
+	for i, bar := range bars {
+		y := bounds.Y + float32(i)*row
+		rl.DrawTextEx(g.menu.font, bar.Setting, rl.Vector2{X: bounds.X, Y: y + row/2 - fontSize/2}, fontSize, 1, rl.DarkGray)
+		width := barWidth * bar.Value / largest
+		color := rl.DarkGreen
+		if i == 0 {
+			color = rl.Maroon
+		}
+		rl.DrawRectangleRec(rl.NewRectangle(bounds.X+labelWidth, y+row*0.15, width, row*0.7), color)
+		value := fmt.Sprintf("%s  (%d runs)", balanceValue(bar.Value, metric), bar.Runs)
+		rl.DrawTextEx(g.menu.font, value, rl.Vector2{X: bounds.X + labelWidth + width + 8, Y: y + row/2 - fontSize/2}, fontSize, 1, rl.Gray)
+	}
+}
+
+// balanceValue formats a setting's value in the balance report as the metric measures it
+func balanceValue(value float32, metric stats.Metric) string {
+	switch metric {
+	case stats.MetricBombed:
+		return fmt.Sprintf("%.0f%%", value*100)
+	case stats.MetricScore:
+		return fmt.Sprintf("%.1f", value)
+	}
+	return playTime(value)
+}
+
+// drawScoreChart draws the recent scores as bars, oldest on the left, under a title in the space above bounds
+func (g *Game) drawScoreChart(bounds rl.Rectangle, fontSize float32) {
+	recent := g.stats.Recent
+	title := fmt.Sprintf("Last %d scores", len(recent))
+	rl.DrawTextEx(g.menu.font, title, rl.Vector2{X: bounds.X, Y: bounds.Y - fontSize - 6}, fontSize, 1, rl.DarkGray)
+	rl.DrawRectangleLinesEx(bounds, 1, rl.LightGray)
+	if len(recent) == 0 {
+		text := "No games yet"
+		size := rl.MeasureTextEx(g.menu.font, text, fontSize, 1)
+		rl.DrawTextEx(g.menu.font, text, rl.Vector2{X: bounds.X + bounds.Width/2 - size.X/2, Y: bounds.Y + bounds.Height/2 - size.Y/2}, fontSize, 1, rl.Gray)
+		return
+	}
+
+	best := 1
+	for _, score := range recent {
+		best = max(best, score)
+	}
+	rl.DrawTextEx(g.menu.font, fmt.Sprintf("%d", best), rl.Vector2{X: bounds.X + 4, Y: bounds.Y + 4}, 16, 1, rl.Gray)
+
+	// Bars keep their width for the full run of recent scores so the chart doesn't stretch
+	slot := bounds.Width / stats.RecentScores
+	for i, score := range recent {
+		height := (bounds.Height - 24) * float32(score) / float32(best)
+		bar := rl.NewRectangle(bounds.X+float32(i)*slot+2, bounds.Y+bounds.Height-height, slot-4, height)
+		color := rl.DarkGreen
+		if i == len(recent)-1 {
+			color = rl.Lime
+		}
+		rl.DrawRectangleRec(bar, color)
+	}
+}
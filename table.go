@@ -0,0 +1,200 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// TableColumn describes one column of a Table
+type TableColumn struct {
+	title      string
+	width      float32 // Fraction of the table width
+	alignRight bool    // Right-align cells, used for numbers
+}
+
+// Table draws rows of text in aligned columns with a header, alternating row backgrounds
+// and mouse wheel scrolling when there are more rows than fit. A selectable table also lets
+// a row be picked with a click or Up/Down.
+type Table struct {
+	rect      rl.Rectangle
+	columns   []TableColumn
+	rows      [][]string
+	rowHeight float32
+	fontSize  float32
+	font      rl.Font
+	scroll    int // Index of the first visible row
+
+	selectable bool
+	selected   int // Index of the picked row, -1 for none
+}
+
+func NewTable(x, y, width, height float32, columns []TableColumn, fontSize float32, font rl.Font) Table {
+	return Table{
+		rect:      rl.NewRectangle(x, y, width, height),
+		columns:   columns,
+		rows:      make([][]string, 0),
+		rowHeight: fontSize * 1.4,
+		fontSize:  fontSize,
+		font:      font,
+		selected:  -1,
+	}
+}
+
+// SetRows replaces the table contents, one string per column in each row
+func (t *Table) SetRows(rows [][]string) {
+	t.rows = rows
+	t.scroll = min(t.scroll, t.maxScroll())
+	t.selected = -1
+}
+
+// Selected returns the index of the picked row, -1 if there is none
+func (t *Table) Selected() int {
+	return t.selected
+}
+
+// selectRow picks a row and scrolls just far enough to show it
+func (t *Table) selectRow(index int) {
+	t.selected = max(0, min(len(t.rows)-1, index))
+	if t.selected < t.scroll {
+		t.scroll = t.selected
+	} else if t.selected >= t.scroll+t.visibleRows() {
+		t.scroll = t.selected - t.visibleRows() + 1
+	}
+}
+
+// HandleClick picks the row under the mouse when it is clicked
+func (t *Table) HandleClick(mousePoint rl.Vector2) {
+	if !t.selectable || !rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+		return
+	}
+	body := rl.NewRectangle(t.rect.X, t.rect.Y+t.rowHeight, t.rect.Width, t.rect.Height-t.rowHeight)
+	if !rl.CheckCollisionPointRec(mousePoint, body) {
+		return
+	}
+	index := t.scroll + int((mousePoint.Y-body.Y)/t.rowHeight)
+	if index < len(t.rows) && index < t.scroll+t.visibleRows() {
+		t.selected = index
+	}
+}
+
+// visibleRows returns how many rows fit below the header
+func (t *Table) visibleRows() int {
+	return max(0, int((t.rect.Height-t.rowHeight)/t.rowHeight))
+}
+
+func (t *Table) maxScroll() int {
+	return max(0, len(t.rows)-t.visibleRows())
+}
+
+// HandleScroll scrolls the rows with the mouse wheel while the table is hovered
+func (t *Table) HandleScroll(mousePoint rl.Vector2) {
+	if !rl.CheckCollisionPointRec(mousePoint, t.rect) {
+		return
+	}
+	wheel := rl.GetMouseWheelMove()
+	if wheel > 0 {
+		t.scroll = max(0, t.scroll-1)
+	} else if wheel < 0 {
+		t.scroll = min(t.maxScroll(), t.scroll+1)
+	}
+}
+
+// HandleKeys scrolls the rows a line at a time with Up/Down and a page at a time with Page Up/Page Down.
+// In a selectable table Up/Down move the selection instead.
+func (t *Table) HandleKeys() {
+	if t.selectable && len(t.rows) > 0 {
+		menuFocus.HoldArrows()
+		switch {
+		case rl.IsKeyPressed(rl.KeyUp):
+			if t.selected < 0 {
+				t.selected = len(t.rows)
+			}
+			t.selectRow(t.selected - 1)
+			return
+		case rl.IsKeyPressed(rl.KeyDown):
+			t.selectRow(t.selected + 1)
+			return
+		}
+	}
+
+	// The arrows scroll rather than move between buttons, while there is anything to scroll
+	if t.maxScroll() > 0 {
+		menuFocus.HoldArrows()
+	}
+	step := 0
+	switch {
+	case rl.IsKeyPressed(rl.KeyUp):
+		step = -1
+	case rl.IsKeyPressed(rl.KeyDown):
+		step = 1
+	case rl.IsKeyPressed(rl.KeyPageUp):
+		step = -t.visibleRows()
+	case rl.IsKeyPressed(rl.KeyPageDown):
+		step = t.visibleRows()
+	}
+	t.scroll = max(0, min(t.maxScroll(), t.scroll+step))
+}
+
+func (t *Table) Draw() {
+	// Header
+	t.drawRow(t.headerCells(), t.rect.Y, rl.DarkGreen)
+	rl.DrawLineEx(
+		rl.Vector2{X: t.rect.X, Y: t.rect.Y + t.rowHeight},
+		rl.Vector2{X: t.rect.X + t.rect.Width, Y: t.rect.Y + t.rowHeight},
+		2,
+		rl.DarkGreen,
+	)
+
+	// Rows with alternating backgrounds
+	end := min(len(t.rows), t.scroll+t.visibleRows())
+	for i := t.scroll; i < end; i++ {
+		y := t.rect.Y + float32(i-t.scroll+1)*t.rowHeight
+		switch {
+		case i == t.selected:
+			rl.DrawRectangleRec(rl.NewRectangle(t.rect.X, y, t.rect.Width, t.rowHeight), rl.Fade(rl.Green, 0.3))
+		case i%2 == 0:
+			rl.DrawRectangleRec(rl.NewRectangle(t.rect.X, y, t.rect.Width, t.rowHeight), rl.Color{R: 0, G: 0, B: 0, A: 15})
+		}
+		t.drawRow(t.rows[i], y, rl.DarkGray)
+	}
+
+	// Scrollbar when the rows overflow
+	if t.maxScroll() > 0 {
+		trackY := t.rect.Y + t.rowHeight
+		trackHeight := t.rect.Height - t.rowHeight
+		thumbHeight := trackHeight * float32(t.visibleRows()) / float32(len(t.rows))
+		thumbY := trackY + (trackHeight-thumbHeight)*float32(t.scroll)/float32(t.maxScroll())
+		rl.DrawRectangleRec(rl.NewRectangle(t.rect.X+t.rect.Width+4, thumbY, 4, thumbHeight), rl.Gray)
+	}
+}
+
+func (t *Table) headerCells() []string {
+	cells := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		cells[i] = column.title
+	}
+	return cells
+}
+
+func (t *Table) drawRow(cells []string, y float32, color rl.Color) {
+	x := t.rect.X
+	for i, column := range t.columns {
+		width := t.rect.Width * column.width
+		if i < len(cells) {
+			size := fitFontSize(t.font, cells[i], t.fontSize, width-textPadding*2)
+			textSize := rl.MeasureTextEx(t.font, cells[i], size, 1)
+			textX := x + textPadding
+			if column.alignRight {
+				textX = x + width - textPadding - textSize.X
+			}
+			rl.DrawTextEx(
+				t.font,
+				cells[i],
+				rl.Vector2{X: textX, Y: y + (t.rowHeight-textSize.Y)/2},
+				size,
+				1,
+				color,
+			)
+		}
+		x += width
+	}
+}
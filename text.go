@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	minFontSize = 12 // Text is never shrunk below this size, it wraps instead
+	textPadding = 8  // Horizontal space kept clear inside widget bounds
+)
+
+// fitFontSize returns the largest size, up to fontSize, at which text fits within maxWidth.
+// It stops at minFontSize even if the text still doesn't fit.
+func fitFontSize(font rl.Font, text string, fontSize, maxWidth float32) float32 {
+	size := fontSize
+	for size > minFontSize && rl.MeasureTextEx(font, text, size, 1).X > maxWidth {
+		size--
+	}
+	return size
+}
+
+// wrapText splits text on spaces into lines that fit within maxWidth at fontSize.
+// A single word wider than maxWidth gets a line of its own.
+func wrapText(font rl.Font, text string, fontSize, maxWidth float32) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{text}
+	}
+
+	lines := make([]string, 0, 1)
+	line := words[0]
+	for _, word := range words[1:] {
+		candidate := line + " " + word
+		if rl.MeasureTextEx(font, candidate, fontSize, 1).X > maxWidth {
+			lines = append(lines, line)
+			line = word
+		} else {
+			line = candidate
+		}
+	}
+	return append(lines, line)
+}
+
+// drawTextFit draws text centered in bounds, shrinking the font until it fits the width
+// and wrapping onto several lines if it is still too wide at the minimum size
+func drawTextFit(font rl.Font, text string, bounds rl.Rectangle, fontSize float32, color rl.Color) {
+	maxWidth := bounds.Width - textPadding*2
+	size := fitFontSize(font, text, fontSize, maxWidth)
+
+	lines := []string{text}
+	if rl.MeasureTextEx(font, text, size, 1).X > maxWidth {
+		lines = wrapText(font, text, size, maxWidth)
+	}
+
+	lineHeight := rl.MeasureTextEx(font, text, size, 1).Y
+	y := bounds.Y + (bounds.Height-lineHeight*float32(len(lines)))/2
+	for i, line := range lines {
+		lineSize := rl.MeasureTextEx(font, line, size, 1)
+		rl.DrawTextEx(
+			font,
+			line,
+			rl.Vector2{
+				X: bounds.X + (bounds.Width-lineSize.X)/2,
+				Y: y + float32(i)*lineHeight,
+			},
+			size,
+			1,
+			color,
+		)
+	}
+}
+
+// drawCenteredText draws a single label centered horizontally on screen at y,
+// auto-fit to the screen width and scaled about its middle. Menu text in dark gray, or gray for
+// hints, takes the menu theme's text color.
+func (g *Game) drawCenteredText(text string, y, fontSize float32, color rl.Color) {
+	switch color {
+	case rl.DarkGray:
+		color = menuTheme.Text
+	case rl.Gray:
+		color = rl.Fade(menuTheme.Text, 0.7)
+	}
+	size := menuFont(fontSize)
+	bounds := rl.NewRectangle(0, y-(size-fontSize)/2, float32(g.screenWidth), size)
+	drawTextFit(g.menu.font, text, bounds, size, color)
+}
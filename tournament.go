@@ -0,0 +1,378 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/highscores"
+	"github.com/ztkent/snake/internal/input"
+	"github.com/ztkent/snake/internal/tournament"
+)
+
+const (
+	bracketFontSize  = 18
+	bracketRowHeight = 22 // Height of one player's row in a match box
+)
+
+// openTournament runs a hot-seat knockout tournament. The players are named first, then take turns
+// at a Timed game on the selected level and difficulty, both players of a match on the same seed,
+// with the bracket shown between turns.
+func (g *Game) openTournament() {
+	players, ok := g.openTournamentSetup()
+	if !ok {
+		g.state = StateVersusSelect
+		return
+	}
+	g.state = StateMainMenu
+
+	t := tournament.New(players, g.newSeed())
+	for {
+		match, player, ok := t.Next()
+		if !ok {
+			break
+		}
+		name := t.Players[match.Players[player]]
+		if !g.openBracket(t, name+"'s turn", "Play") {
+			return
+		}
+		score, ok := g.playTournamentTurn(name, match.Seed)
+		if !ok {
+			return
+		}
+		t.Record(score)
+	}
+
+	champion, _ := t.Champion()
+	g.openBracket(t, strings.ToUpper(t.Players[champion])+" WINS!", "Back to Menu")
+}
+
+// openTournamentSetup picks how many players there are and their names. Clicking a name renames
+// that player. It returns false if the players back out.
+func (g *Game) openTournamentSetup() ([]string, bool) {
+	defer func() { g.typing = false }()
+
+	names := make([]string, tournament.MaxPlayers)
+	for i := range names {
+		names[i] = fmt.Sprintf("Player %d", i+1)
+	}
+	count := 4
+	editing := -1 // Name being typed, -1 for none
+
+	buttonWidth := float32(200)
+	buttonHeight := float32(40)
+	countButton := NewMenuButton(float32(g.screenWidth)/2-buttonWidth/2, float32(g.screenHeight)*0.22, buttonWidth, buttonHeight,
+		fmt.Sprintf("Players: %d", count), 28, g.menu.font)
+	startButton := NewMenuButton(float32(g.screenWidth)/2-buttonWidth-10, float32(g.screenHeight)*0.82, buttonWidth, buttonHeight,
+		"Start", 28, g.menu.font)
+	backButton := NewMenuButton(float32(g.screenWidth)/2+10, float32(g.screenHeight)*0.82, buttonWidth, buttonHeight,
+		"Back", 28, g.menu.font)
+
+	// Two columns of four name boxes
+	boxWidth := float32(240)
+	boxHeight := float32(36)
+	boxes := make([]rl.Rectangle, tournament.MaxPlayers)
+	for i := range boxes {
+		column, row := i/4, i%4
+		boxes[i] = rl.NewRectangle(
+			float32(g.screenWidth)/2-boxWidth-10+float32(column)*(boxWidth+20),
+			float32(g.screenHeight)*0.36+float32(row)*(boxHeight+8),
+			boxWidth,
+			boxHeight,
+		)
+	}
+
+	// finishEditing stops typing a name, putting back the default if it was left empty
+	finishEditing := func() {
+		names[editing] = strings.TrimSpace(names[editing])
+		if names[editing] == "" {
+			names[editing] = fmt.Sprintf("Player %d", editing+1)
+		}
+		editing = -1
+		g.typing = false
+	}
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return nil, false
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			if editing < 0 {
+				return nil, false
+			}
+			finishEditing()
+		}
+
+		if editing >= 0 {
+			name := []rune(names[editing])
+			for char := rl.GetCharPressed(); char > 0; char = rl.GetCharPressed() {
+				if len(name) < highscores.MaxNameLength && isNameChar(char) {
+					name = append(name, char)
+				}
+			}
+			if (rl.IsKeyPressed(rl.KeyBackspace) || rl.IsKeyPressedRepeat(rl.KeyBackspace)) && len(name) > 0 {
+				name = name[:len(name)-1]
+			}
+			names[editing] = string(name)
+			if rl.IsKeyPressed(rl.KeyEnter) {
+				finishEditing()
+			}
+		}
+
+		mousePoint := rl.GetMousePosition()
+		clicked := -1
+		for i := range count {
+			if rl.CheckCollisionPointRec(mousePoint, boxes[i]) && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+				clicked = i
+			}
+		}
+		if clicked >= 0 {
+			if editing >= 0 {
+				finishEditing()
+			}
+			editing = clicked
+			g.typing = true
+		}
+
+		for _, button := range []*MenuButton{&countButton, &startButton, &backButton} {
+			if !button.IsHovered(mousePoint) {
+				button.color = rl.LightGray
+				continue
+			}
+			button.color = rl.Gray
+			if !g.menu.handleButtonClick() {
+				continue
+			}
+			if editing >= 0 {
+				finishEditing()
+			}
+			switch button {
+			case &countButton:
+				count = count%tournament.MaxPlayers + 1
+				count = max(count, tournament.MinPlayers)
+				countButton.text = fmt.Sprintf("Players: %d", count)
+			case &startButton:
+				return names[:count], true
+			case &backButton:
+				return nil, false
+			}
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText("TOURNAMENT", float32(g.screenHeight)*0.08, 50, rl.DarkGreen)
+		countButton.Draw()
+		for i := range count {
+			rl.DrawRectangleRec(boxes[i], rl.LightGray)
+			outline := rl.DarkGray
+			text := names[i]
+			if i == editing {
+				outline = rl.DarkGreen
+				if int(rl.GetTime()*2)%2 == 0 {
+					text += "_"
+				}
+			}
+			rl.DrawRectangleLinesEx(boxes[i], 2, outline)
+			drawTextFit(g.menu.font, text, boxes[i], 24, rl.Black)
+		}
+		g.drawCenteredText("Click a name to change it", float32(g.screenHeight)*0.75, 18, rl.Gray)
+		startButton.Draw()
+		backButton.Draw()
+
+		g.endFrame()
+	}
+}
+
+// playTournamentTurn plays one player's Timed game on the match's seed and returns the score.
+// It returns false if the players quit from the pause screen or closed the window.
+func (g *Game) playTournamentTurn(name string, seed uint64) (int, bool) {
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+	defer g.audio.PlayMusic(&g.audio.MenuMusic)
+
+	width, height := g.boardSize()
+	config := g.difficulty.Config(width, height)
+	config.Walls = g.level.Walls(width, height)
+	config.Portals = g.level.Portals(width, height)
+	config.SolidEdges = g.solidEdges
+	config.Growth = g.growth
+	config.ShrinkOnBomb = g.shrinkOnBomb
+	config.Mode = game.ModeTimed
+	engine := game.NewEngine(config, seed)
+	g.particles.Clear()
+	g.camFX.Reset()
+	g.startCountdown()
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		if g.controls.Pressed(input.ActionPause) {
+			if !g.openPauseScreen(&engine.State) {
+				g.recordGame(engine)
+				return 0, false
+			}
+			continue
+		} else if rl.WindowShouldClose() {
+			g.recordGame(engine)
+			g.running = false
+			return 0, false
+		}
+
+		g.handleSnakeInput(engine)
+
+		delta := g.camFX.Step(g.holdForCountdown(g.simulationDelta(backgrounded))) * g.gameSpeed
+		events := engine.Update(delta)
+		g.playEventSounds(events, &engine.State)
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		if engine.State.Over {
+			g.recordGame(engine)
+			return engine.State.Points, true
+		}
+
+		g.updateDebug(engine)
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		if config.SolidEdges {
+			g.drawSolidEdges()
+		}
+		rl.EndMode2D()
+		g.drawHUD(engine, hudLine{text: name, color: g.theme.HUD})
+
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// openBracket shows the bracket under a title, with a button to carry on. It returns false if the
+// players leave the tournament with Escape or close the window.
+func (g *Game) openBracket(t *tournament.Tournament, titleText, buttonText string) bool {
+	buttonWidth := float32(240)
+	buttonHeight := float32(44)
+	button := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		float32(g.screenHeight)*0.86,
+		buttonWidth,
+		buttonHeight,
+		buttonText,
+		28,
+		g.menu.font,
+	)
+
+	// Ignore the click that opened this screen
+	g.menu.buttonReleased = !rl.IsMouseButtonDown(rl.MouseLeftButton)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return false
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			return false
+		}
+		if rl.IsKeyPressed(rl.KeyEnter) {
+			return true
+		}
+
+		if button.IsHovered(rl.GetMousePosition()) {
+			button.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				return true
+			}
+		} else {
+			button.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText(titleText, float32(g.screenHeight)*0.05, 44, rl.DarkGreen)
+		g.drawBracket(t, rl.NewRectangle(20, float32(g.screenHeight)*0.17, float32(g.screenWidth)-40, float32(g.screenHeight)*0.66))
+		button.Draw()
+
+		g.endFrame()
+	}
+}
+
+// drawBracket draws a column of match boxes per round in bounds, rounds not drawn yet as boxes
+// waiting on their players. Each match's winner is shown in green once it is decided.
+func (g *Game) drawBracket(t *tournament.Tournament, bounds rl.Rectangle) {
+	first := len(t.Rounds[0])
+	rounds := 1
+	for size := first; size > 1; size /= 2 {
+		rounds++
+	}
+
+	columnWidth := bounds.Width / float32(rounds)
+	boxWidth := min(columnWidth-20, 220)
+	boxHeight := float32(bracketRowHeight * 2)
+	for round := range rounds {
+		matches := first >> round
+		slotHeight := bounds.Height / float32(matches)
+		x := bounds.X + float32(round)*columnWidth + (columnWidth-boxWidth)/2
+		for i := range matches {
+			box := rl.NewRectangle(x, bounds.Y+(float32(i)+0.5)*slotHeight-boxHeight/2, boxWidth, boxHeight)
+
+			// Join the box to the one its winner goes on to
+			if round < rounds-1 {
+				middle := box.Y + boxHeight/2
+				next := bounds.Y + (float32(i/2)+0.5)*slotHeight*2
+				edge := box.X + boxWidth
+				joint := edge + (columnWidth-boxWidth)/2
+				rl.DrawLineEx(rl.Vector2{X: edge, Y: middle}, rl.Vector2{X: joint, Y: middle}, 2, rl.Gray)
+				rl.DrawLineEx(rl.Vector2{X: joint, Y: middle}, rl.Vector2{X: joint, Y: next}, 2, rl.Gray)
+				rl.DrawLineEx(rl.Vector2{X: joint, Y: next}, rl.Vector2{X: joint + (columnWidth-boxWidth)/2, Y: next}, 2, rl.Gray)
+			}
+
+			rl.DrawRectangleRec(box, rl.Fade(rl.LightGray, 0.9))
+			rl.DrawRectangleLinesEx(box, 2, rl.DarkGray)
+			if round >= len(t.Rounds) {
+				drawTextFit(g.menu.font, "TBD", box, bracketFontSize, rl.Gray)
+				continue
+			}
+			g.drawBracketMatch(t, &t.Rounds[round][i], box)
+		}
+	}
+}
+
+// drawBracketMatch draws a match's two players and the scores of the turns they have played
+func (g *Game) drawBracketMatch(t *tournament.Tournament, match *tournament.Match, box rl.Rectangle) {
+	for k, player := range match.Players {
+		row := rl.NewRectangle(box.X+textPadding, box.Y+float32(k)*bracketRowHeight, box.Width-2*textPadding, bracketRowHeight)
+		name, score := "Bye", ""
+		if player != tournament.Bye {
+			name = t.Players[player]
+		}
+		if match.Played > k {
+			score = fmt.Sprint(match.Scores[k])
+		}
+
+		color := rl.DarkGray
+		if match.Decided() {
+			color = rl.Gray
+			if match.Winner() == player {
+				color = rl.DarkGreen
+			}
+		}
+		rl.DrawTextEx(g.menu.font, name, rl.Vector2{X: row.X, Y: row.Y + 2}, bracketFontSize, 1, color)
+		scoreSize := rl.MeasureTextEx(g.menu.font, score, bracketFontSize, 1)
+		rl.DrawTextEx(g.menu.font, score, rl.Vector2{X: row.X + row.Width - scoreSize.X, Y: row.Y + 2}, bracketFontSize, 1, color)
+	}
+}
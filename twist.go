@@ -0,0 +1,71 @@
+package main
+
+import (
+	"github.com/ztkent/snake/internal/levels"
+)
+
+// BoardTwist picks how a run turns its level round: not at all, always the same way, or a
+// different way each run. Only the walls and portals move, the run's seed is kept.
+type BoardTwist int
+
+const (
+	TwistOff BoardTwist = iota
+	TwistMirror
+	TwistFlip
+	TwistRotate
+	TwistRandom // Mirror, flip or rotate, picked from the run's seed
+	BoardTwistCount
+)
+
+var boardTwistNames = [BoardTwistCount]string{"Off", "Mirror", "Flip", "Rotate", "Random"}
+
+func (t BoardTwist) String() string {
+	return boardTwistNames[t]
+}
+
+// ParseBoardTwist returns the twist with the given name, defaulting to Off
+func ParseBoardTwist(name string) BoardTwist {
+	for i, twistName := range boardTwistNames {
+		if twistName == name {
+			return BoardTwist(i)
+		}
+	}
+	return TwistOff
+}
+
+// Next cycles to the following twist, wrapping back to Off
+func (t BoardTwist) Next() BoardTwist {
+	return (t + 1) % BoardTwistCount
+}
+
+// transform returns the level transform for a run started from seed
+func (t BoardTwist) transform(seed uint64) levels.Transform {
+	switch t {
+	case TwistMirror:
+		return levels.TransformMirror
+	case TwistFlip:
+		return levels.TransformFlip
+	case TwistRotate:
+		return levels.TransformRotate
+	case TwistRandom:
+		return levels.Transform(1 + seed%uint64(levels.TransformCount-1))
+	}
+	return levels.TransformNone
+}
+
+// transformName is how a transform is saved with a score, empty for a level played as it is
+func transformName(transform levels.Transform) string {
+	if transform == levels.TransformNone {
+		return ""
+	}
+	return transform.String()
+}
+
+// ghostLevel is the level name the run's ghost is kept under, apart from the same level turned
+// another way round
+func (g *Game) ghostLevel() string {
+	if g.transform == levels.TransformNone {
+		return g.level.Name
+	}
+	return g.level.Name + " " + g.transform.String()
+}
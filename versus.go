@@ -0,0 +1,329 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/input"
+)
+
+const (
+	versusRounds        = 2
+	versusRoundTime     = 60  // Seconds the snake has to survive each round
+	versusBombBudget    = 12  // Bombs the bomber can place each round
+	versusBombCooldown  = 1.5 // Seconds between bomb placements
+	versusNoPlaceRadius = 3   // Cells around the snake head where bombs can't be placed
+	versusSurvivalBonus = 5   // Points for surviving the whole round
+)
+
+// versusRound records the outcome of one round for the player controlling the snake
+type versusRound struct {
+	snakePlayer int
+	points      int
+	survived    bool
+}
+
+// StartVersus runs the asymmetric two-player mode: one player steers the snake with the arrow keys
+// while the other places a limited budget of bombs with the mouse. Roles swap after each round,
+// and the player whose snake scored the most (including the survival bonus) wins.
+func (g *Game) StartVersus() {
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+
+	results := make([]versusRound, 0, versusRounds)
+	for round := 0; round < versusRounds; round++ {
+		snakePlayer := round%2 + 1
+		if !g.openVersusIntro(round+1, snakePlayer) {
+			g.state = StateMainMenu
+			g.audio.PlayMusic(&g.audio.MenuMusic)
+			return
+		}
+
+		result, ok := g.playVersusRound(snakePlayer)
+		if !ok {
+			g.state = StateMainMenu
+			g.audio.PlayMusic(&g.audio.MenuMusic)
+			return
+		}
+		results = append(results, result)
+	}
+
+	g.audio.PlayMusic(&g.audio.MenuMusic)
+	g.openVersusResults(results)
+}
+
+// playVersusRound plays a single round and returns its result.
+// It returns false if the players quit from the pause screen or closed the window.
+func (g *Game) playVersusRound(snakePlayer int) (versusRound, bool) {
+	bomberPlayer := 3 - snakePlayer
+	result := versusRound{snakePlayer: snakePlayer}
+	g.score = Score{}
+
+	// One food piece at a time and no spawned bombs, bombs are the bomber's job
+	width, height := g.boardSize()
+	engine := game.NewEngine(game.Config{
+		Width:           width,
+		Height:          height,
+		TickRate:        tickRate,
+		MaxFood:         1,
+		FoodInterval:    versusRoundTime,
+		ScoreMultiplier: 1,
+	}, g.newSeed())
+
+	bombsLeft := versusBombBudget
+	lastBombTime := float32(0) // The bomber waits one cooldown at the start of the round
+	g.startCountdown()
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		if g.controls.Pressed(input.ActionPause) {
+			if !g.openPauseScreen(&engine.State) {
+				return result, false
+			}
+			continue
+		} else if rl.WindowShouldClose() {
+			g.running = false
+			return result, false
+		}
+
+		// Snake player input
+		g.handleSnakeInput(engine)
+
+		// Bomber player input, bombs snap to the grid under the mouse
+		roundTime := g.score.duration
+		cell := g.boardMouseCell()
+		canPlace := bombsLeft > 0 && !g.countingDown() &&
+			roundTime-lastBombTime >= versusBombCooldown &&
+			canPlaceVersusBomb(engine, cell)
+		if canPlace && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
+			engine.AddBomb(cell)
+			bombsLeft--
+			lastBombTime = roundTime
+		}
+
+		// Freeze the round while the window is backgrounded
+		g.playEventSounds(engine.Update(g.holdForCountdown(g.simulationDelta(backgrounded))*g.gameSpeed), &engine.State)
+		g.score.points = engine.State.Points
+		g.score.duration = engine.Duration()
+
+		// The round ends when the snake dies or outlasts the timer
+		if engine.State.Over {
+			result.points = g.score.points
+			return result, true
+		}
+		if g.score.duration >= versusRoundTime {
+			result.survived = true
+			result.points = g.score.points + versusSurvivalBonus
+			return result, true
+		}
+
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		// Show the no-place zone around the head and a placement preview under the mouse
+		g.beginBoard(g.boardCamera())
+		zone := float32(versusNoPlaceRadius * gridSize)
+		head := cellPosition(engine.State.Snake.Head())
+		rl.DrawRectangleV(
+			rl.Vector2{X: head.X - zone, Y: head.Y - zone},
+			rl.Vector2{X: zone*2 + gridSize, Y: zone*2 + gridSize},
+			rl.Color{R: 255, G: 255, B: 255, A: 20},
+		)
+		previewColor := rl.Color{R: 230, G: 41, B: 55, A: 120}
+		if !canPlace {
+			previewColor = rl.Color{R: 130, G: 130, B: 130, A: 80}
+		}
+		preview := cellPosition(cell)
+		rl.DrawRectangleLinesEx(rl.NewRectangle(preview.X, preview.Y, gridSize, gridSize), 2, previewColor)
+
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		rl.EndMode2D()
+
+		// Draw HUD
+		fontSize := float32(20)
+		snakeText := fmt.Sprintf("P%d Snake: %d", snakePlayer, g.score.points)
+		bomberText := fmt.Sprintf("P%d Bombs: %d", bomberPlayer, bombsLeft)
+		timeText := fmt.Sprintf("Time left: %.0fs", max(0, versusRoundTime-g.score.duration))
+		rl.DrawTextEx(g.menu.font, snakeText, rl.Vector2{X: 10, Y: 10}, fontSize, 1, rl.Green)
+		bomberSize := rl.MeasureTextEx(g.menu.font, bomberText, fontSize, 1)
+		rl.DrawTextEx(g.menu.font, bomberText, rl.Vector2{X: float32(g.screenWidth) - bomberSize.X - 10, Y: 10}, fontSize, 1, rl.Red)
+		timeSize := rl.MeasureTextEx(g.menu.font, timeText, fontSize, 1)
+		rl.DrawTextEx(g.menu.font, timeText, rl.Vector2{X: float32(g.screenWidth)/2 - timeSize.X/2, Y: 10}, fontSize, 1, rl.White)
+
+		// Bomb cooldown bar under the bomber's count
+		cooldown := min(1, (roundTime-lastBombTime)/versusBombCooldown)
+		barWidth := bomberSize.X
+		rl.DrawRectangleV(
+			rl.Vector2{X: float32(g.screenWidth) - barWidth - 10, Y: 15 + bomberSize.Y},
+			rl.Vector2{X: barWidth * cooldown, Y: 4},
+			rl.Red,
+		)
+
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// canPlaceVersusBomb reports whether a bomb may go in the cell: on the board, not on the snake or
+// anything else there such as food or another bomb, and outside the no-place zone around the
+// snake's head
+func canPlaceVersusBomb(engine *game.Engine, cell game.Point) bool {
+	if !engine.InBounds(cell) {
+		return false
+	}
+
+	state := &engine.State
+	head := state.Snake.Head()
+	if abs(cell.X-head.X) <= versusNoPlaceRadius && abs(cell.Y-head.Y) <= versusNoPlaceRadius {
+		return false
+	}
+
+	for _, segment := range state.Snake.Segments {
+		if segment == cell {
+			return false
+		}
+	}
+	_, taken := engine.EntityAt(cell)
+	return !taken
+}
+
+// openVersusIntro announces the round and who plays which role.
+// It returns false if the players back out to the main menu.
+func (g *Game) openVersusIntro(round, snakePlayer int) bool {
+	bomberPlayer := 3 - snakePlayer
+	lines := []string{
+		fmt.Sprintf("Player %d: Snake (arrow keys)", snakePlayer),
+		fmt.Sprintf("Player %d: Bomber (mouse, %d bombs)", bomberPlayer, versusBombBudget),
+		fmt.Sprintf("Survive %d seconds for +%d", versusRoundTime, versusSurvivalBonus),
+		"Click or press Enter to start",
+	}
+	titleText := fmt.Sprintf("ROUND %d", round)
+	titleFontSize := float32(60)
+	textFontSize := float32(24)
+	titleSize := rl.MeasureTextEx(g.menu.font, titleText, titleFontSize, 1)
+
+	// Ignore the click that opened this screen
+	g.menu.buttonReleased = !rl.IsMouseButtonDown(rl.MouseLeftButton)
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.IsKeyPressed(rl.KeyEscape) {
+			return false
+		} else if rl.WindowShouldClose() {
+			g.running = false
+			return false
+		}
+		if rl.IsKeyPressed(rl.KeyEnter) || g.menu.handleButtonClick() {
+			return true
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(g.theme.Background)
+
+		rl.DrawTextEx(
+			g.menu.font,
+			titleText,
+			rl.Vector2{X: float32(g.screenWidth)/2 - titleSize.X/2, Y: float32(g.screenHeight) * 0.15},
+			titleFontSize,
+			1,
+			rl.White,
+		)
+		for i, line := range lines {
+			g.drawCenteredText(line, float32(g.screenHeight)*0.4+float32(i)*textFontSize*1.6, textFontSize, rl.LightGray)
+		}
+
+		g.endFrame()
+	}
+}
+
+// openVersusResults shows each player's round and the winner
+func (g *Game) openVersusResults(results []versusRound) {
+	// Each player plays the snake once, so their round is their score
+	points := [3]int{}
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		points[result.snakePlayer] += result.points
+		status := "crashed"
+		if result.survived {
+			status = "survived"
+		}
+		lines = append(lines, fmt.Sprintf("Player %d: %d (%s)", result.snakePlayer, result.points, status))
+	}
+
+	titleText := "DRAW!"
+	if points[1] > points[2] {
+		titleText = "PLAYER 1 WINS!"
+	} else if points[2] > points[1] {
+		titleText = "PLAYER 2 WINS!"
+	}
+	g.openResultsScreen(titleText, lines)
+}
+
+// openResultsScreen shows the winner of a match and a line per player until Back to Menu is clicked
+func (g *Game) openResultsScreen(titleText string, lines []string) {
+	buttonWidth := float32(240)
+	buttonHeight := float32(50)
+
+	exitButton := NewMenuButton(
+		float32(g.screenWidth)/2-buttonWidth/2,
+		float32(g.screenHeight)*0.75,
+		buttonWidth,
+		buttonHeight,
+		"Back to Menu",
+		30,
+		g.menu.font,
+	)
+
+	titleFontSize := float32(60)
+	statsFontSize := float32(30)
+	titleSize := rl.MeasureTextEx(g.menu.font, titleText, titleFontSize, 1)
+	speaker.Say(titleText + " " + strings.Join(lines, ". "))
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		if exitButton.IsHovered(mousePoint) {
+			exitButton.color = rl.Gray
+			if g.menu.handleButtonClick() {
+				g.state = StateMainMenu
+				return
+			}
+		} else {
+			exitButton.color = rl.LightGray
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		rl.DrawTextEx(
+			g.menu.font,
+			titleText,
+			rl.Vector2{X: float32(g.screenWidth)/2 - titleSize.X/2, Y: float32(g.screenHeight) * 0.2},
+			titleFontSize,
+			1,
+			rl.DarkGreen,
+		)
+		for i, line := range lines {
+			g.drawCenteredText(line, float32(g.screenHeight)*0.42+float32(i)*statsFontSize*1.5, statsFontSize, rl.DarkGray)
+		}
+
+		exitButton.Draw()
+		g.endFrame()
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/ai"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/input"
+)
+
+// openVersusSelect picks between the two player versus mode, playing against the AI, a local
+// tournament and hosting or joining a match on the local network
+func (g *Game) openVersusSelect() {
+	buttonWidth := float32(260)
+	buttonHeight := float32(36)
+	buttonSpacing := float32(8)
+	startY := float32(g.screenHeight) * 0.28
+
+	labels := []string{"Two Players", "Vs AI", "AI: " + g.aiSkill.String(), "Tournament", "Host LAN Game", "Join LAN Game", "Back"}
+	buttons := make([]MenuButton, len(labels))
+	for i, label := range labels {
+		buttons[i] = NewMenuButton(
+			float32(g.screenWidth)/2-buttonWidth/2,
+			startY+float32(i)*(buttonHeight+buttonSpacing),
+			buttonWidth,
+			buttonHeight,
+			label,
+			28,
+			g.menu.font,
+		)
+	}
+	twoPlayerButton, vsAIButton, skillButton, tournamentButton, hostButton, joinButton, backButton :=
+		&buttons[0], &buttons[1], &buttons[2], &buttons[3], &buttons[4], &buttons[5], &buttons[6]
+
+	for {
+		g.updateFramePacing()
+		g.audio.UpdateMusic()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if rl.IsKeyReleased(rl.KeyEscape) {
+			g.state = StateMainMenu
+			return
+		}
+
+		mousePoint := rl.GetMousePosition()
+		for i := range buttons {
+			if !buttons[i].IsHovered(mousePoint) {
+				buttons[i].color = rl.LightGray
+				continue
+			}
+			buttons[i].color = rl.Gray
+			if !g.menu.handleButtonClick() {
+				continue
+			}
+			switch &buttons[i] {
+			case twoPlayerButton:
+				g.state = StateVersus
+				return
+			case vsAIButton:
+				g.state = StateVsAI
+				return
+			case skillButton:
+				g.aiSkill = g.aiSkill.Next()
+				skillButton.text = "AI: " + g.aiSkill.String()
+			case tournamentButton:
+				g.state = StateTournament
+				return
+			case hostButton:
+				g.state = StateHostLAN
+				return
+			case joinButton:
+				g.state = StateJoinLAN
+				return
+			case backButton:
+				g.state = StateMainMenu
+				return
+			}
+		}
+
+		g.beginFrame()
+		rl.ClearBackground(menuTheme.Menu)
+		g.menu.updateBackground()
+
+		g.drawCenteredText("VERSUS", float32(g.screenHeight)*0.1, 60, rl.DarkGreen)
+		for i := range buttons {
+			buttons[i].Draw()
+		}
+
+		g.endFrame()
+	}
+}
+
+// StartVsAI plays the selected level against a computer-controlled snake. Both compete for the
+// same food and the rival comes back a few seconds after crashing. The match ends when the player
+// crashes, into anything including the rival, and the most points wins.
+func (g *Game) StartVsAI() {
+	g.audio.SetMasterVolume(g.volume)
+	g.audio.PlayMusic(&g.audio.GameMusic)
+	defer g.audio.PlayMusic(&g.audio.MenuMusic)
+
+	width, height := g.boardSize()
+	config := g.difficulty.Config(width, height)
+	config.Walls = g.level.Walls(width, height)
+	config.Portals = g.level.Portals(width, height)
+	config.SolidEdges = g.solidEdges
+	config.Growth = g.growth
+	config.ShrinkOnBomb = g.shrinkOnBomb
+	engine := game.NewEngine(config, g.newSeed())
+	rival := engine.AddRival()
+	g.particles.Clear()
+	g.camFX.Reset()
+	g.startCountdown()
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		if g.controls.Pressed(input.ActionPause) {
+			if !g.openPauseScreen(&engine.State) {
+				g.recordGame(engine)
+				g.state = StateMainMenu
+				return
+			}
+			continue
+		} else if rl.WindowShouldClose() {
+			g.recordGame(engine)
+			g.running = false
+			return
+		}
+
+		g.handleSnakeInput(engine)
+		if opponent := engine.State.Rivals[rival]; opponent.Alive() {
+			engine.InputRival(rival, ai.Steer(engine, opponent.Snake, g.aiSkill))
+		}
+
+		delta := g.camFX.Step(g.holdForCountdown(g.simulationDelta(backgrounded))) * g.gameSpeed
+		events := engine.Update(delta)
+		g.playEventSounds(events, &engine.State)
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		if engine.State.Over {
+			g.recordGame(engine)
+			g.openVsAIResults(engine.State.Points, engine.State.Rivals[rival].Points)
+			return
+		}
+
+		g.updateDebug(engine)
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		if config.SolidEdges {
+			g.drawSolidEdges()
+		}
+		rl.EndMode2D()
+		g.drawStatusTint(&engine.State)
+		g.drawEffectsHUD(engine.State.Effects)
+
+		fontSize := float32(20)
+		playerText := fmt.Sprintf("You: %d", engine.State.Points)
+		rl.DrawTextEx(g.menu.font, playerText, rl.Vector2{X: 10, Y: 10}, fontSize, 1, rl.Green)
+		rivalText := fmt.Sprintf("AI (%s): %d", g.aiSkill, engine.State.Rivals[rival].Points)
+		rivalSize := rl.MeasureTextEx(g.menu.font, rivalText, fontSize, 1)
+		rl.DrawTextEx(g.menu.font, rivalText, rl.Vector2{X: float32(g.screenWidth) - rivalSize.X - 10, Y: 10}, fontSize, 1, rl.Orange)
+
+		g.drawCountdown()
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// openVsAIResults shows who won a match against the AI
+func (g *Game) openVsAIResults(playerPoints, rivalPoints int) {
+	titleText := "DRAW!"
+	if playerPoints > rivalPoints {
+		titleText = "YOU WIN!"
+	} else if rivalPoints > playerPoints {
+		titleText = "AI WINS!"
+	}
+	g.openResultsScreen(titleText, []string{
+		fmt.Sprintf("You: %d", playerPoints),
+		fmt.Sprintf("AI (%s): %d", g.aiSkill, rivalPoints),
+	})
+}
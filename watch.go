@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/ai"
+	"github.com/ztkent/snake/internal/game"
+	"github.com/ztkent/snake/internal/input"
+)
+
+const attractDelay = 30 // Seconds idle on the main menu before the AI demo starts
+
+// openWatchAI lets the AI play on the selected level and difficulty, starting a new game each time
+// it dies. As the attract mode demo it is silent and any input returns to the main menu, otherwise
+// Escape or the pause binding does.
+func (g *Game) openWatchAI(attract bool) {
+	width, height := g.boardSize()
+	newEngine := func() *game.Engine {
+		config := g.difficulty.Config(width, height)
+		config.Walls = g.level.Walls(width, height)
+		config.Portals = g.level.Portals(width, height)
+		return game.NewEngine(config, uint64(time.Now().UnixNano()))
+	}
+	engine := newEngine()
+	g.particles.Clear()
+	g.camFX.Reset()
+
+	hint := "Esc to return"
+	if attract {
+		hint = "Press any key"
+	}
+
+	for {
+		g.audio.UpdateMusic()
+		backgrounded := g.updateFramePacing()
+
+		if rl.WindowShouldClose() {
+			g.running = false
+			return
+		}
+		if attract && anyInput() {
+			g.state = StateMainMenu
+			return
+		}
+		if !attract && (rl.IsKeyReleased(rl.KeyEscape) || g.controls.Pressed(input.ActionPause)) {
+			g.state = StateMainMenu
+			return
+		}
+
+		engine.Steer(ai.NextDirection(engine))
+		delta := g.camFX.Step(g.simulationDelta(backgrounded))
+		events := engine.Update(delta)
+		if !attract {
+			g.playEventSounds(events, &engine.State)
+		}
+		g.updateParticles(events, engine, delta)
+		g.camFX.React(events)
+		if engine.State.Over {
+			engine = newEngine()
+		}
+
+		g.beginFrame()
+		g.postfx.Begin()
+		rl.ClearBackground(g.theme.Background)
+
+		g.beginBoard(g.camFX.Apply(g.boardCamera()))
+		g.drawBoard(&engine.State, g.snakePositions(engine))
+		rl.EndMode2D()
+		g.drawCenteredText("AI DEMO", 10, 30, rl.Fade(rl.White, 0.8))
+		g.drawCenteredText(hint, float32(g.screenHeight)-30, 20, rl.Fade(rl.White, 0.6))
+		scoreText := fmt.Sprintf("Score: %d", engine.State.Points)
+		rl.DrawTextEx(g.menu.font, scoreText, rl.Vector2{X: 10, Y: 10}, 20, 1, rl.White)
+
+		g.postfx.End()
+		g.endFrame()
+	}
+}
+
+// anyInput reports whether any key, mouse button, mouse movement or gamepad button happened this frame
+func anyInput() bool {
+	if rl.GetKeyPressed() != 0 {
+		return true
+	}
+	for button := rl.GamepadButtonLeftFaceUp; button <= rl.GamepadButtonRightThumb; button++ {
+		if rl.IsGamepadButtonPressed(0, int32(button)) {
+			return true
+		}
+	}
+	delta := rl.GetMouseDelta()
+	if delta.X != 0 || delta.Y != 0 || rl.GetMouseWheelMove() != 0 {
+		return true
+	}
+	for button := rl.MouseButtonLeft; button <= rl.MouseButtonMiddle; button++ {
+		if rl.IsMouseButtonPressed(button) {
+			return true
+		}
+	}
+	return false
+}
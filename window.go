@@ -0,0 +1,87 @@
+package main
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/ztkent/snake/internal/audio"
+)
+
+const (
+	minWindowWidth  = 400
+	minWindowHeight = 225
+)
+
+// Every screen lays itself out on a fixed screenWidth x screenHeight canvas. beginFrame and
+// endFrame wrap rl.BeginDrawing and rl.EndDrawing to render into that canvas and scale it to fit
+// the window, letterboxed to keep the aspect ratio, so the window can be resized or made fullscreen.
+
+// beginFrame starts drawing a frame into the canvas and maps the mouse onto it
+func (g *Game) beginFrame() {
+	g.handleWindowKeys()
+	if g.debugMode {
+		g.debug.recordFrameTime()
+	}
+	menuFocus.EndFrame()
+	if menuFocus.Moved() {
+		g.audio.Play(audio.EventMenuHover)
+	}
+
+	// Map window coordinates to canvas coordinates, so buttons keep working at any size
+	scale, offset := g.canvasTransform()
+	rl.SetMouseOffset(-int(offset.X), -int(offset.Y))
+	rl.SetMouseScale(1/scale, 1/scale)
+
+	rl.BeginDrawing()
+	rl.BeginTextureMode(g.canvas)
+}
+
+// endFrame draws the canvas scaled to the window and ends the frame
+func (g *Game) endFrame() {
+	g.drawDebugOverlay()
+	g.playing = nil
+	g.drawMuteIndicator()
+	g.drawCursor()
+	rl.EndTextureMode()
+
+	scale, offset := g.canvasTransform()
+	rl.ClearBackground(rl.Black)
+	// Render textures are stored upside down, so flip the source rectangle
+	rl.DrawTexturePro(
+		g.canvas.Texture,
+		rl.NewRectangle(0, 0, float32(g.screenWidth), -float32(g.screenHeight)),
+		rl.NewRectangle(offset.X, offset.Y, float32(g.screenWidth)*scale, float32(g.screenHeight)*scale),
+		rl.Vector2{},
+		0,
+		rl.White,
+	)
+	rl.EndDrawing()
+}
+
+// canvasTransform returns the scale and top-left offset that fit the canvas in the window
+func (g *Game) canvasTransform() (float32, rl.Vector2) {
+	windowWidth := float32(rl.GetScreenWidth())
+	windowHeight := float32(rl.GetScreenHeight())
+	scale := min(windowWidth/float32(g.screenWidth), windowHeight/float32(g.screenHeight))
+	return scale, rl.Vector2{
+		X: (windowWidth - float32(g.screenWidth)*scale) / 2,
+		Y: (windowHeight - float32(g.screenHeight)*scale) / 2,
+	}
+}
+
+// handleWindowKeys toggles fullscreen with F11 or Alt+Enter, mute with M and the debug overlay
+// with F3, and opens the console with the backtick key
+func (g *Game) handleWindowKeys() {
+	altDown := rl.IsKeyDown(rl.KeyLeftAlt) || rl.IsKeyDown(rl.KeyRightAlt)
+	if rl.IsKeyPressed(rl.KeyF11) || (altDown && rl.IsKeyPressed(rl.KeyEnter)) {
+		// Borderless keeps the desktop resolution, so switching is instant
+		rl.ToggleBorderlessWindowed()
+	}
+	if rl.IsKeyPressed(rl.KeyM) && !g.typing && !g.controls.IsBound(rl.KeyM) {
+		g.toggleMute()
+	}
+	if g.debugMode && rl.IsKeyPressed(rl.KeyF3) {
+		g.debug.shown = !g.debug.shown
+	}
+	if g.devMode && rl.IsKeyPressed(rl.KeyGrave) && !g.typing && !g.console.open {
+		g.openConsole()
+	}
+}